@@ -1 +1,163 @@
-package bot
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/holypower777/go-twitch/auth"
+	"golang.org/x/oauth2"
+)
+
+const (
+	authorizePath = "authorize"
+	tokenPath     = "token"
+	validatePath  = "validate"
+	revokePath    = "revoke"
+
+	tokenIsRequired = "token is required"
+
+	ResponseTypeCode  = "code"
+	ResponseTypeToken = "token"
+)
+
+var ErrInvalidToken = errors.New("access token is invalid")
+
+type AuthService service
+
+// AuthAPI is implemented by AuthService, so calls can be mocked in tests.
+type AuthAPI interface {
+	ValidateToken(ctx context.Context) (*TokenValidation, *Response, error)
+	RevokeToken(ctx context.Context, token string) (*Response, error)
+	RefreshToken(ctx context.Context) (*oauth2.Token, error)
+	AuthorizeURL(opts *AuthorizeURLOptions) (string, error)
+}
+
+var _ AuthAPI = (*AuthService)(nil)
+
+type TokenValidation struct {
+	ClientId  string   `json:"client_id,omitempty"`
+	Login     string   `json:"login,omitempty"`
+	UserId    string   `json:"user_id,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiresIn int      `json:"expires_in,omitempty"`
+}
+
+// ValidateToken validates the access token the client sends. Twitch requires
+// applications to validate user tokens on start and hourly afterwards.
+// ErrInvalidToken is returned when the token is expired or revoked.
+func (s *AuthService) ValidateToken(ctx context.Context) (*TokenValidation, *Response, error) {
+	req, err := s.client.newAuthRequest(http.MethodGet, validatePath, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validation := new(TokenValidation)
+	resp, err := s.client.Do(ctx, req, validation)
+	if _, ok := err.(*ErrorTokenRefresh); ok || isUnauthorized(err) {
+		return nil, resp, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return validation, resp, nil
+}
+
+// RevokeToken revokes an access token of the client application,
+// e.g. when a user logs out or uninstalls the bot.
+func (s *AuthService) RevokeToken(ctx context.Context, token string) (*Response, error) {
+	if token == "" {
+		return nil, &ErrorInvalidOptions{Options: token, Message: tokenIsRequired}
+	}
+
+	req, err := s.client.newAuthRequest(http.MethodPost, revokePath, url.Values{
+		"client_id": {s.client.credentials.ClientId},
+		"token":     {token},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// RefreshToken refreshes the user token of the client right away. The client
+// refreshes expired tokens itself, both ways call OnTokenRefreshed of the credentials.
+func (s *AuthService) RefreshToken(ctx context.Context) (*oauth2.Token, error) {
+	if s.client.tokenSource == nil {
+		return nil, ErrTokenNotRefreshable
+	}
+
+	return s.client.tokenSource.refresh(ctx, nil)
+}
+
+type AuthorizeURLOptions struct {
+	// ClientId defaults to the client id of the client.
+	ClientId    string `url:"client_id,omitempty"`
+	RedirectURI string `url:"redirect_uri,omitempty" validate:"required"`
+	// ResponseType defaults to code.
+	ResponseType string   `url:"response_type,omitempty"`
+	Scopes       []string `url:"scope,space,omitempty"`
+	State        string   `url:"state,omitempty"`
+	ForceVerify  bool     `url:"force_verify,omitempty"`
+}
+
+// AuthorizeURL builds the URL the user is sent to for authorizing the application.
+// Use NewAuthState for State and ParseAuthCallback on the redirect.
+func (s *AuthService) AuthorizeURL(opts *AuthorizeURLOptions) (string, error) {
+	if err := validateOptions(opts); err != nil {
+		return "", err
+	}
+
+	o := *opts
+	if o.ClientId == "" {
+		o.ClientId = s.client.credentials.ClientId
+	}
+
+	if o.ResponseType == "" {
+		o.ResponseType = ResponseTypeCode
+	}
+
+	u, err := s.client.AuthURL.Parse(authorizePath)
+	if err != nil {
+		return "", err
+	}
+
+	return addParams(u.String(), &o)
+}
+
+func isUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+var (
+	ErrAuthStateMismatch   = auth.ErrAuthStateMismatch
+	ErrRedirectURLRequired = auth.ErrRedirectURLRequired
+	ErrDeviceCodeExpired   = auth.ErrDeviceCodeExpired
+)
+
+// The auth flows live in the auth package, they are aliased here
+// so small bots can get a token with this package alone.
+type (
+	ErrorAuthorization = auth.ErrorAuthorization
+	AuthCodeFlow       = auth.AuthCodeFlow
+	DeviceCodeFlow     = auth.DeviceCodeFlow
+	DeviceCode         = auth.DeviceCode
+)
+
+// NewAuthState returns a random state to protect the authorization against CSRF.
+func NewAuthState() (string, error) {
+	return auth.NewAuthState()
+}
+
+// ParseAuthCallback returns the code of the authorization redirect query.
+func ParseAuthCallback(query url.Values, state string) (string, error) {
+	return auth.ParseAuthCallback(query, state)
+}
+
+// NewPKCEVerifier returns a random code verifier for the PKCE flow.
+func NewPKCEVerifier() (string, error) {
+	return auth.NewPKCEVerifier()
+}
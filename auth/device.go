@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	devicePath = "device"
+
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	authorizationPending = "authorization_pending"
+	slowDown             = "slow_down"
+	slowDownInterval     = 5 * time.Second
+)
+
+var ErrDeviceCodeExpired = errors.New("device code is expired")
+
+// DeviceCode is the code the user has to enter at VerificationURI.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code,omitempty"`
+	UserCode        string `json:"user_code,omitempty"`
+	VerificationURI string `json:"verification_uri,omitempty"`
+	ExpiresIn       int    `json:"expires_in,omitempty"`
+	Interval        int    `json:"interval,omitempty"`
+}
+
+type tokenResponse struct {
+	AccessToken  string   `json:"access_token,omitempty"`
+	RefreshToken string   `json:"refresh_token,omitempty"`
+	ExpiresIn    int      `json:"expires_in,omitempty"`
+	Scope        []string `json:"scope,omitempty"`
+	TokenType    string   `json:"token_type,omitempty"`
+}
+
+func (r *tokenResponse) token() *oauth2.Token {
+	token := &oauth2.Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		TokenType:    r.TokenType,
+	}
+
+	if r.ExpiresIn != 0 {
+		token.Expiry = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+
+	return token.WithExtra(map[string]interface{}{"scope": r.Scope})
+}
+
+// postAuthForm posts form to the auth endpoint and decodes a successful response into v.
+// Error responses are returned as *ErrorAuthorization.
+func postAuthForm(ctx context.Context, httpClient *http.Client, u string, form url.Values, v interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		return &ErrorAuthorization{Code: errResp.Message, Description: http.StatusText(resp.StatusCode)}
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// DeviceCodeFlow gets a user access token with the device code grant,
+// for applications without a browser like bots on headless servers.
+// ClientSecret is only needed for confidential clients.
+type DeviceCodeFlow struct {
+	ClientId     string
+	ClientSecret string
+	Scopes       []string
+	// AuthURL defaults to https://id.twitch.tv/oauth2/.
+	AuthURL    string
+	HTTPClient *http.Client
+
+	// OnDeviceCode is called with the code the user has to enter,
+	// before polling for the token starts.
+	OnDeviceCode func(code *DeviceCode)
+}
+
+func (f *DeviceCodeFlow) authURL() string {
+	if f.AuthURL == "" {
+		return defaultURL
+	}
+
+	return f.AuthURL
+}
+
+func (f *DeviceCodeFlow) RequestDeviceCode(ctx context.Context) (*DeviceCode, error) {
+	form := url.Values{
+		"client_id": {f.ClientId},
+		"scopes":    {strings.Join(f.Scopes, " ")},
+	}
+
+	code := new(DeviceCode)
+	if err := postAuthForm(ctx, f.HTTPClient, f.authURL()+devicePath, form, code); err != nil {
+		return nil, err
+	}
+
+	return code, nil
+}
+
+// PollToken polls for the token every code.Interval seconds
+// until the user authorizes the device or the code expires.
+func (f *DeviceCodeFlow) PollToken(ctx context.Context, code *DeviceCode) (*oauth2.Token, error) {
+	form := url.Values{
+		"client_id":   {f.ClientId},
+		"scopes":      {strings.Join(f.Scopes, " ")},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {grantTypeDeviceCode},
+	}
+
+	if f.ClientSecret != "" {
+		form.Set("client_secret", f.ClientSecret)
+	}
+
+	interval := time.Duration(code.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for {
+		resp := new(tokenResponse)
+		err := postAuthForm(ctx, f.HTTPClient, f.authURL()+tokenPath, form, resp)
+		if err == nil {
+			return resp.token(), nil
+		}
+
+		authErr, ok := err.(*ErrorAuthorization)
+		if !ok {
+			return nil, err
+		}
+
+		switch authErr.Code {
+		case authorizationPending:
+		case slowDown:
+			interval += slowDownInterval
+		default:
+			return nil, err
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, ErrDeviceCodeExpired
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Run requests a device code, passes it to OnDeviceCode and polls for the token.
+func (f *DeviceCodeFlow) Run(ctx context.Context) (*oauth2.Token, error) {
+	if ctx == nil {
+		return nil, errNonNilContext
+	}
+
+	code, err := f.RequestDeviceCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.OnDeviceCode != nil {
+		f.OnDeviceCode(code)
+	}
+
+	return f.PollToken(ctx, code)
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeviceCodeFlow(t *testing.T) {
+	t.Run("must poll until the user authorizes the device", func(t *testing.T) {
+		polls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+
+			switch r.URL.Path {
+			case "/device":
+				if r.Form.Get("client_id") != "ClientId" || r.Form.Get("scopes") != "chat:read chat:edit" {
+					t.Errorf("bad device request: %v", r.Form)
+				}
+				fmt.Fprint(w, `{"device_code":"dev1ce","expires_in":1800,"interval":0,"user_code":"ABCDEFGH","verification_uri":"https://www.twitch.tv/activate?device-code=ABCDEFGH"}`)
+			case "/token":
+				if r.Form.Get("device_code") != "dev1ce" || r.Form.Get("grant_type") != grantTypeDeviceCode {
+					t.Errorf("bad token request: %v", r.Form)
+				}
+
+				polls++
+				if polls < 3 {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"status":400,"message":"authorization_pending"}`)
+					return
+				}
+				fmt.Fprint(w, `{"access_token":"t0ken","expires_in":14124,"refresh_token":"refresh","scope":["chat:read","chat:edit"],"token_type":"bearer"}`)
+			}
+		}))
+		defer server.Close()
+
+		var got *DeviceCode
+		f := &DeviceCodeFlow{
+			ClientId:     "ClientId",
+			Scopes:       []string{"chat:read", "chat:edit"},
+			AuthURL:      server.URL + "/",
+			OnDeviceCode: func(code *DeviceCode) { got = code },
+		}
+
+		token, err := f.Run(context.Background())
+		assertNoError(t, err)
+
+		if got == nil || got.UserCode != "ABCDEFGH" {
+			t.Errorf("bad device code: %+v", got)
+		}
+
+		if token.AccessToken != "t0ken" || polls != 3 {
+			t.Errorf("bad token %+v after %d polls", token, polls)
+		}
+	})
+
+	t.Run("must stop when the code expires or is denied", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			w.WriteHeader(http.StatusBadRequest)
+			if r.Form.Get("device_code") == "denied" {
+				fmt.Fprint(w, `{"status":400,"message":"invalid device code"}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":400,"message":"authorization_pending"}`)
+		}))
+		defer server.Close()
+
+		f := &DeviceCodeFlow{ClientId: "ClientId", AuthURL: server.URL + "/"}
+
+		_, err := f.PollToken(context.Background(), &DeviceCode{DeviceCode: "dev1ce", ExpiresIn: 0, Interval: 1})
+		if err != ErrDeviceCodeExpired {
+			t.Errorf("expected ErrDeviceCodeExpired, got: %v", err)
+		}
+
+		_, err = f.PollToken(context.Background(), &DeviceCode{DeviceCode: "denied", ExpiresIn: 10})
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "authorization failed: invalid device code: Bad Request")
+	})
+}
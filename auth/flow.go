@@ -0,0 +1,251 @@
+// Package auth gets Twitch user access tokens with the authorization code
+// and device code flows and keeps them in token stores.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultURL    = "https://id.twitch.tv/oauth2/"
+	authorizePath = "authorize"
+	tokenPath     = "token"
+)
+
+var (
+	ErrAuthStateMismatch   = errors.New("authorization callback state does not match")
+	ErrRedirectURLRequired = errors.New("redirect url is required")
+
+	errNonNilContext = errors.New("context must be non-nil")
+)
+
+// ErrorAuthorization is returned when the user denies the authorization
+// or Twitch redirects back with an error.
+type ErrorAuthorization struct {
+	Code        string
+	Description string
+}
+
+func (e *ErrorAuthorization) Error() string {
+	return fmt.Sprintf("Message: authorization failed: %s: %s", e.Code, e.Description)
+}
+
+// AuthCodeFlow gets a user access token with the authorization code grant.
+// Run serves RedirectURL on localhost for the time of the flow, so the
+// redirect URL registered for the application must point to localhost.
+type AuthCodeFlow struct {
+	ClientId     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// ForceVerify makes Twitch ask the user to authorize again,
+	// even if the application is authorized already.
+	ForceVerify bool
+	// PKCE makes Run use a code challenge, so public clients
+	// can authorize without ClientSecret.
+	PKCE bool
+	// AuthURL defaults to https://id.twitch.tv/oauth2/.
+	AuthURL string
+	// OpenURL is called with the authorize URL the user has to visit,
+	// e.g. to open it in a browser or print it.
+	OpenURL    func(authorizeURL string) error
+	HTTPClient *http.Client
+}
+
+func (f *AuthCodeFlow) config() *oauth2.Config {
+	authURL := f.AuthURL
+	if authURL == "" {
+		authURL = defaultURL
+	}
+
+	return &oauth2.Config{
+		ClientID:     f.ClientId,
+		ClientSecret: f.ClientSecret,
+		RedirectURL:  f.RedirectURL,
+		Scopes:       f.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   authURL + authorizePath,
+			TokenURL:  authURL + tokenPath,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+func (f *AuthCodeFlow) context(ctx context.Context) context.Context {
+	if f.HTTPClient == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, oauth2.HTTPClient, f.HTTPClient)
+}
+
+// AuthorizeURL returns the URL the user visits to authorize the application.
+func (f *AuthCodeFlow) AuthorizeURL(state string) string {
+	return f.authorizeURL(state)
+}
+
+// AuthorizeURLWithPKCE returns the authorize URL with the code challenge of verifier,
+// the same verifier has to be passed to ExchangeWithPKCE.
+func (f *AuthCodeFlow) AuthorizeURLWithPKCE(state, verifier string) string {
+	return f.authorizeURL(state,
+		oauth2.SetAuthURLParam("code_challenge", pkceChallenge(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+}
+
+func (f *AuthCodeFlow) authorizeURL(state string, opts ...oauth2.AuthCodeOption) string {
+	if f.ForceVerify {
+		opts = append(opts, oauth2.SetAuthURLParam("force_verify", "true"))
+	}
+
+	return f.config().AuthCodeURL(state, opts...)
+}
+
+// Exchange exchanges the code from the redirect for a token.
+func (f *AuthCodeFlow) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	return f.config().Exchange(f.context(ctx), code)
+}
+
+func (f *AuthCodeFlow) ExchangeWithPKCE(ctx context.Context, code, verifier string) (*oauth2.Token, error) {
+	return f.config().Exchange(f.context(ctx), code, oauth2.SetAuthURLParam("code_verifier", verifier))
+}
+
+// NewPKCEVerifier returns a random code verifier for the PKCE flow.
+func NewPKCEVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Run performs the whole flow: it starts a server on RedirectURL, passes the
+// authorize URL to OpenURL, waits for the redirect and exchanges the code.
+// The token can be used as OAuthToken of Credentials.
+func (f *AuthCodeFlow) Run(ctx context.Context) (*oauth2.Token, error) {
+	if ctx == nil {
+		return nil, errNonNilContext
+	}
+
+	redirect, err := url.Parse(f.RedirectURL)
+	if err != nil || redirect.Host == "" {
+		return nil, ErrRedirectURLRequired
+	}
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := NewAuthState()
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+
+	path := redirect.Path
+	if path == "" {
+		path = "/"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		code, err := ParseAuthCallback(r.URL.Query(), state)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			select {
+			case errs <- err:
+			default:
+			}
+			return
+		}
+
+		fmt.Fprint(w, "Authorization is complete, you can close this page.")
+		select {
+		case codes <- code:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	var verifier string
+	authorizeURL := f.AuthorizeURL(state)
+	if f.PKCE {
+		if verifier, err = NewPKCEVerifier(); err != nil {
+			return nil, err
+		}
+		authorizeURL = f.AuthorizeURLWithPKCE(state, verifier)
+	}
+
+	if f.OpenURL != nil {
+		if err := f.OpenURL(authorizeURL); err != nil {
+			return nil, err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-errs:
+		return nil, err
+	case code := <-codes:
+		if f.PKCE {
+			return f.ExchangeWithPKCE(ctx, code, verifier)
+		}
+		return f.Exchange(ctx, code)
+	}
+}
+
+// NewAuthState returns a random state to protect the authorization against CSRF,
+// it has to be kept, e.g. in a cookie, until the user is redirected back.
+func NewAuthState() (string, error) {
+	return randomString(16)
+}
+
+// ParseAuthCallback returns the code of the authorization redirect query.
+// It returns ErrAuthStateMismatch if the state differs from the one passed to
+// the authorize URL and *ErrorAuthorization if the user denied the authorization.
+func ParseAuthCallback(query url.Values, state string) (string, error) {
+	if state == "" || subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return "", ErrAuthStateMismatch
+	}
+
+	if code := query.Get("error"); code != "" {
+		return "", &ErrorAuthorization{Code: code, Description: query.Get("error_description")}
+	}
+
+	return query.Get("code"), nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
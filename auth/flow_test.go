@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// freeRedirectURL returns a localhost redirect url on a free port.
+func freeRedirectURL(t testing.TB) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assertNoError(t, err)
+	defer l.Close()
+
+	return "http://" + l.Addr().String() + "/callback"
+}
+
+func newTokenServer(t testing.TB) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, http.MethodPost)
+		r.ParseForm()
+
+		if r.URL.Path != "/token" || r.Form.Get("code") != "c0de" || r.Form.Get("client_secret") != "ClientSecret" {
+			t.Errorf("bad token request: %s %v", r.URL.Path, r.Form)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"t0ken","refresh_token":"refresh","expires_in":3600,"scope":["chat:read"],"token_type":"bearer"}`)
+	}))
+}
+
+func TestAuthCodeFlow(t *testing.T) {
+	t.Run("must build authorize url", func(t *testing.T) {
+		f := &AuthCodeFlow{
+			ClientId:    "ClientId",
+			RedirectURL: "http://localhost:3000",
+			Scopes:      []string{"chat:read", "chat:edit"},
+			ForceVerify: true,
+		}
+
+		want := "https://id.twitch.tv/oauth2/authorize?client_id=ClientId&force_verify=true&redirect_uri=http%3A%2F%2Flocalhost%3A3000&response_type=code&scope=chat%3Aread+chat%3Aedit&state=state"
+		if got := f.AuthorizeURL("state"); got != want {
+			t.Errorf("bad authorize url\ngot: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("must exchange code from the callback", func(t *testing.T) {
+		server := newTokenServer(t)
+		defer server.Close()
+
+		f := &AuthCodeFlow{
+			ClientId:     "ClientId",
+			ClientSecret: "ClientSecret",
+			RedirectURL:  freeRedirectURL(t),
+			AuthURL:      server.URL + "/",
+		}
+
+		f.OpenURL = func(authorizeURL string) error {
+			u, _ := url.Parse(authorizeURL)
+			go http.Get(f.RedirectURL + "?code=c0de&state=" + u.Query().Get("state"))
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		token, err := f.Run(ctx)
+		assertNoError(t, err)
+
+		if token.AccessToken != "t0ken" || token.RefreshToken != "refresh" {
+			t.Errorf("bad token: %+v", token)
+		}
+	})
+
+	t.Run("must return denied authorization", func(t *testing.T) {
+		f := &AuthCodeFlow{ClientId: "ClientId", RedirectURL: freeRedirectURL(t)}
+
+		f.OpenURL = func(authorizeURL string) error {
+			u, _ := url.Parse(authorizeURL)
+			go http.Get(f.RedirectURL + "?error=access_denied&error_description=The+user+denied+you+access&state=" + u.Query().Get("state"))
+			return nil
+		}
+
+		_, err := f.Run(context.Background())
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "authorization failed: access_denied: The user denied you access")
+	})
+
+	t.Run("must reject callback with another state", func(t *testing.T) {
+		f := &AuthCodeFlow{ClientId: "ClientId", RedirectURL: freeRedirectURL(t)}
+
+		f.OpenURL = func(authorizeURL string) error {
+			go http.Get(f.RedirectURL + "?code=c0de&state=forged")
+			return nil
+		}
+
+		if _, err := f.Run(context.Background()); err != ErrAuthStateMismatch {
+			t.Errorf("expected ErrAuthStateMismatch, got: %v", err)
+		}
+	})
+
+	t.Run("must send code challenge and verifier with pkce", func(t *testing.T) {
+		var challenge string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+
+			if r.Form.Get("client_secret") != "" || pkceChallenge(r.Form.Get("code_verifier")) != challenge {
+				t.Errorf("bad token request: %v", r.Form)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"t0ken","token_type":"bearer"}`)
+		}))
+		defer server.Close()
+
+		f := &AuthCodeFlow{
+			ClientId:    "ClientId",
+			RedirectURL: freeRedirectURL(t),
+			AuthURL:     server.URL + "/",
+			PKCE:        true,
+		}
+
+		f.OpenURL = func(authorizeURL string) error {
+			u, _ := url.Parse(authorizeURL)
+			challenge = u.Query().Get("code_challenge")
+			if challenge == "" || u.Query().Get("code_challenge_method") != "S256" {
+				t.Errorf("bad authorize url: %s", authorizeURL)
+			}
+
+			go http.Get(f.RedirectURL + "?code=c0de&state=" + u.Query().Get("state"))
+			return nil
+		}
+
+		token, err := f.Run(context.Background())
+		assertNoError(t, err)
+
+		if token.AccessToken != "t0ken" {
+			t.Errorf("bad token: %+v", token)
+		}
+	})
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// The example of RFC 7636, appendix B.
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if got := pkceChallenge(verifier); got != want {
+		t.Errorf("bad challenge\ngot: %s\nwant: %s", got, want)
+	}
+
+	v, err := NewPKCEVerifier()
+	assertNoError(t, err)
+	if len(v) != 43 {
+		t.Errorf("bad verifier length: %d", len(v))
+	}
+}
+
+func TestParseAuthCallback(t *testing.T) {
+	state, err := NewAuthState()
+	assertNoError(t, err)
+
+	code, err := ParseAuthCallback(url.Values{"code": {"c0de"}, "state": {state}}, state)
+	assertNoError(t, err)
+	if code != "c0de" {
+		t.Errorf("bad code: %s", code)
+	}
+
+	for _, query := range []url.Values{{"code": {"c0de"}}, {"code": {"c0de"}, "state": {"forged"}}} {
+		if _, err := ParseAuthCallback(query, state); err != ErrAuthStateMismatch {
+			t.Errorf("expected ErrAuthStateMismatch for %v, got: %v", query, err)
+		}
+	}
+
+	if _, err := ParseAuthCallback(url.Values{}, ""); err != ErrAuthStateMismatch {
+		t.Errorf("empty state must not be accepted, got: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+)
+
+func assertErrorMessage(t testing.TB, err error, msg string) {
+	t.Helper()
+
+	if got, want := err.Error(), "Message: "+msg; got != want {
+		t.Errorf("error message is wrong\ngot: %s\nwant: %s", got, want)
+	}
+}
+
+func assertMethod(t testing.TB, r *http.Request, want string) {
+	t.Helper()
+
+	if got := r.Method; got != want {
+		t.Errorf("bad method\ngot: %s\nwant: %s\n", got, want)
+	}
+}
+
+func assertErrorPresence(t testing.TB, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatal("expected error to be returned")
+	}
+}
+
+func assertNoError(t testing.TB, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("doesn't expect error there: %v", err)
+	}
+}
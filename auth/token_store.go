@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/holypower777/go-twitch/internal/fsutil"
+	"golang.org/x/oauth2"
+)
+
+var ErrTokenNotFound = errors.New("token is not found")
+
+// TokenStore keeps user tokens between restarts. Load returns
+// ErrTokenNotFound when there is no token for the user.
+type TokenStore interface {
+	Load(ctx context.Context, userId string) (*oauth2.Token, error)
+	Save(ctx context.Context, userId string, token *oauth2.Token) error
+}
+
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]*oauth2.Token
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]*oauth2.Token)}
+}
+
+func (s *MemoryTokenStore) Load(ctx context.Context, userId string) (*oauth2.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	token, ok := s.tokens[userId]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+
+	return token, nil
+}
+
+func (s *MemoryTokenStore) Save(ctx context.Context, userId string, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[userId] = token
+	return nil
+}
+
+// FileTokenStore keeps tokens of all users in a JSON file. The file is
+// readable only by its owner and is replaced atomically on every save.
+type FileTokenStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+func (s *FileTokenStore) Load(ctx context.Context, userId string) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	token, ok := tokens[userId]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+
+	return token, nil
+}
+
+func (s *FileTokenStore) Save(ctx context.Context, userId string, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[userId] = token
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(s.Path, data)
+}
+
+func (s *FileTokenStore) read() (map[string]*oauth2.Token, error) {
+	tokens := make(map[string]*oauth2.Token)
+
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
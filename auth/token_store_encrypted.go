@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"golang.org/x/oauth2"
+)
+
+const encryptedTokenType = "encrypted"
+
+var ErrTokenNotEncrypted = errors.New("stored token is not encrypted")
+
+// EncryptedTokenStore encrypts tokens with AES-GCM before passing them
+// to the underlying store. The whole token is sealed into AccessToken of
+// the stored one, bound to the user id, so tokens can't be swapped between users.
+type EncryptedTokenStore struct {
+	store TokenStore
+	aead  cipher.AEAD
+}
+
+// NewEncryptedTokenStore wraps store, key must be 16, 24 or 32 bytes long
+// to select AES-128, AES-192 or AES-256.
+func NewEncryptedTokenStore(store TokenStore, key []byte) (*EncryptedTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedTokenStore{store: store, aead: aead}, nil
+}
+
+func (s *EncryptedTokenStore) Load(ctx context.Context, userId string) (*oauth2.Token, error) {
+	sealed, err := s.store.Load(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if sealed.TokenType != encryptedTokenType {
+		return nil, ErrTokenNotEncrypted
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	size := s.aead.NonceSize()
+	if len(data) < size {
+		return nil, ErrTokenNotEncrypted
+	}
+
+	plain, err := s.aead.Open(nil, data[:size], data[size:], []byte(userId))
+	if err != nil {
+		return nil, err
+	}
+
+	token := new(oauth2.Token)
+	if err := json.Unmarshal(plain, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *EncryptedTokenStore) Save(ctx context.Context, userId string, token *oauth2.Token) error {
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data := s.aead.Seal(nonce, nonce, plain, []byte(userId))
+
+	return s.store.Save(ctx, userId, &oauth2.Token{
+		AccessToken: base64.StdEncoding.EncodeToString(data),
+		TokenType:   encryptedTokenType,
+	})
+}
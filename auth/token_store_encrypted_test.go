@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestEncryptedTokenStore(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+
+	t.Run("must save and load tokens", func(t *testing.T) {
+		store, err := NewEncryptedTokenStore(NewMemoryTokenStore(), key)
+		assertNoError(t, err)
+
+		testTokenStore(t, store)
+	})
+
+	t.Run("tokens must be encrypted at rest", func(t *testing.T) {
+		inner := NewMemoryTokenStore()
+		store, _ := NewEncryptedTokenStore(inner, key)
+		ctx := context.Background()
+
+		assertNoError(t, store.Save(ctx, "1", &oauth2.Token{AccessToken: "s3cret-access", RefreshToken: "s3cret-refresh"}))
+
+		sealed, _ := inner.Load(ctx, "1")
+		if strings.Contains(sealed.AccessToken, "s3cret") || sealed.RefreshToken != "" {
+			t.Errorf("token is stored in plain text: %+v", sealed)
+		}
+
+		// Tokens moved to another user must not decrypt.
+		inner.Save(ctx, "2", sealed)
+		if _, err := store.Load(ctx, "2"); err == nil {
+			t.Error("expected error for a token of another user")
+		}
+
+		other, _ := NewEncryptedTokenStore(inner, bytes.Repeat([]byte{8}, 32))
+		if _, err := other.Load(ctx, "1"); err == nil {
+			t.Error("expected error for a wrong key")
+		}
+
+		inner.Save(ctx, "3", &oauth2.Token{AccessToken: "plain"})
+		if _, err := store.Load(ctx, "3"); err != ErrTokenNotEncrypted {
+			t.Errorf("expected ErrTokenNotEncrypted, got: %v", err)
+		}
+	})
+
+	t.Run("must return error for bad key", func(t *testing.T) {
+		_, err := NewEncryptedTokenStore(NewMemoryTokenStore(), []byte("short"))
+		assertErrorPresence(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func testTokenStore(t *testing.T, store TokenStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := store.Load(ctx, "1"); err != ErrTokenNotFound {
+		t.Errorf("expected ErrTokenNotFound, got: %v", err)
+	}
+
+	expiry := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	assertNoError(t, store.Save(ctx, "1", &oauth2.Token{AccessToken: "a1", RefreshToken: "r1", Expiry: expiry}))
+	assertNoError(t, store.Save(ctx, "2", &oauth2.Token{AccessToken: "a2"}))
+
+	token, err := store.Load(ctx, "1")
+	assertNoError(t, err)
+
+	if token.AccessToken != "a1" || token.RefreshToken != "r1" || !token.Expiry.Equal(expiry) {
+		t.Errorf("bad loaded token: %+v", token)
+	}
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	testTokenStore(t, NewMemoryTokenStore())
+}
+
+func TestFileTokenStore(t *testing.T) {
+	t.Run("must save and load tokens", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tokens.json")
+		testTokenStore(t, NewFileTokenStore(path))
+
+		info, err := os.Stat(path)
+		assertNoError(t, err)
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("bad file mode: %v", info.Mode())
+		}
+
+		// A new store must see tokens saved by the previous one.
+		token, err := NewFileTokenStore(path).Load(context.Background(), "2")
+		assertNoError(t, err)
+		if token.AccessToken != "a2" {
+			t.Errorf("bad loaded token: %+v", token)
+		}
+	})
+
+	t.Run("must return error for corrupted file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tokens.json")
+		os.WriteFile(path, []byte("{"), 0600)
+
+		_, err := NewFileTokenStore(path).Load(context.Background(), "1")
+		assertErrorPresence(t, err)
+	})
+}
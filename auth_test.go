@@ -1 +1,146 @@
-package bot
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateToken(t *testing.T) {
+	t.Run("must return token validation", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc(authURLPath+"/"+validatePath, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodGet)
+			fmt.Fprint(w, `{"client_id":"wbmytr93xzw8zbg0p1izqyzzc5mbiz","login":"twitchdev","scopes":["channel:read:subscriptions"],"user_id":"141981764","expires_in":5520838}`)
+		})
+
+		got, _, err := c.Auth.ValidateToken(context.Background())
+		assertNoError(t, err)
+
+		want := &TokenValidation{
+			ClientId:  "wbmytr93xzw8zbg0p1izqyzzc5mbiz",
+			Login:     "twitchdev",
+			UserId:    "141981764",
+			Scopes:    []string{"channel:read:subscriptions"},
+			ExpiresIn: 5520838,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("\ngot: %+v\nwant: %+v", got, want)
+		}
+	})
+
+	t.Run("must return ErrInvalidToken", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc(authURLPath+"/"+validatePath, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"status":401,"message":"invalid access token"}`)
+		})
+
+		if _, _, err := c.Auth.ValidateToken(context.Background()); err != ErrInvalidToken {
+			t.Errorf("expected ErrInvalidToken, got: %v", err)
+		}
+	})
+}
+
+func TestTokenValidation(t *testing.T) {
+	t.Run("must call OnTokenInvalid periodically for invalid token", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc(authURLPath+"/"+validatePath, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		invalid := make(chan struct{}, 1)
+		c.credentials = &Credentials{ClientId: "ClientId", OnTokenInvalid: func() {
+			select {
+			case invalid <- struct{}{}:
+			default:
+			}
+		}}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go c.validateTokenPeriodically(ctx, 10*time.Millisecond)
+
+		select {
+		case <-invalid:
+		case <-time.After(5 * time.Second):
+			t.Fatal("OnTokenInvalid was not called")
+		}
+	})
+
+	t.Run("must not call OnTokenInvalid for valid token or failed request", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		status := http.StatusOK
+		mux.HandleFunc(authURLPath+"/"+validatePath, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{}`)
+		})
+
+		c.credentials = &Credentials{ClientId: "ClientId", OnTokenInvalid: func() {
+			t.Error("OnTokenInvalid must not be called")
+		}}
+
+		c.checkToken(context.Background())
+		status = http.StatusInternalServerError
+		c.checkToken(context.Background())
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	t.Run("tests method and body to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc(authURLPath+"/"+revokePath, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodPost)
+			assertQuery(t, r, params{"client_id": "ClientId", "token": "t0ken"})
+		})
+
+		_, err := c.Auth.RevokeToken(context.Background(), "t0ken")
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error, when token is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		_, err := client.Auth.RevokeToken(context.Background(), "")
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, tokenIsRequired)
+	})
+}
+
+func TestAuthorizeURL(t *testing.T) {
+	t.Run("must build authorize url", func(t *testing.T) {
+		c, _ := NewClient(creds, nil)
+
+		got, err := c.Auth.AuthorizeURL(&AuthorizeURLOptions{
+			RedirectURI: "https://example.com/callback",
+			Scopes:      []string{"channel:manage:polls", "channel:read:polls"},
+			State:       "c3ab8aa609ea11e793ae92361f002671",
+			ForceVerify: true,
+		})
+		assertNoError(t, err)
+
+		want := "https://id.twitch.tv/oauth2/authorize?client_id=ClientId&force_verify=true&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback&response_type=code&scope=channel%3Amanage%3Apolls+channel%3Aread%3Apolls&state=c3ab8aa609ea11e793ae92361f002671"
+		if got != want {
+			t.Errorf("bad authorize url\ngot: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("must return error, when redirect uri is not provided", func(t *testing.T) {
+		c, _ := NewClient(creds, nil)
+		_, err := c.Auth.AuthorizeURL(&AuthorizeURLOptions{})
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "redirect_uri is required")
+	})
+}
@@ -0,0 +1,129 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// maxBatchSize is the number of ids or logins Helix accepts in a request.
+	maxBatchSize = 100
+	// defaultBatchConcurrency is the number of batches requested at once,
+	// if the caller does not limit it.
+	defaultBatchConcurrency = 4
+)
+
+// runBatches calls do for the batches 0 to n-1, at most concurrency at once.
+// It returns the results in the order of the batches and the response of
+// the last batch, or the first error, which cancels the remaining batches.
+func runBatches[T any](ctx context.Context, n, concurrency int, do func(ctx context.Context, i int) (T, *Response, error)) ([]T, *Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		firstErr  error
+		errResp   *Response
+		results   = make([]T, n)
+		responses = make([]*Response, n)
+	)
+
+	forEach(ctx, n, concurrency, func(i int) {
+		result, resp, err := do(ctx, i)
+		if err != nil {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr, errResp = err, resp
+				cancel()
+			}
+			mu.Unlock()
+			return
+		}
+
+		results[i], responses[i] = result, resp
+	})
+
+	if firstErr != nil {
+		return nil, errResp, firstErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var resp *Response
+	if n > 0 {
+		resp = responses[n-1]
+	}
+
+	return results, resp, nil
+}
+
+// forEach calls do for 0 to n-1, at most concurrency at once, which defaults
+// to defaultBatchConcurrency, and waits for the calls to return. No more
+// calls are started once ctx is done, it returns the number of started calls.
+func forEach(ctx context.Context, n, concurrency int, do func(i int)) int {
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+
+	started := 0
+	for ; started < n; started++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			do(i)
+		}(started)
+	}
+	wg.Wait()
+
+	return started
+}
+
+// batchCount returns the number of batches of n items.
+func batchCount(n int) int {
+	return (n + maxBatchSize - 1) / maxBatchSize
+}
+
+// batchBounds returns the bounds of the batch i of n items.
+func batchBounds(i, n int) (int, int) {
+	start, end := i*maxBatchSize, (i+1)*maxBatchSize
+	if end > n {
+		end = n
+	}
+
+	return start, end
+}
+
+// splitBatch returns the ids and logins of the batch i of the ids followed by the logins.
+func splitBatch(ids, logins []string, i int) ([]string, []string) {
+	var batchIds, batchLogins []string
+
+	start, end := batchBounds(i, len(ids)+len(logins))
+	for j := start; j < end; j++ {
+		if j < len(ids) {
+			batchIds = append(batchIds, ids[j])
+		} else {
+			batchLogins = append(batchLogins, logins[j-len(ids)])
+		}
+	}
+
+	return batchIds, batchLogins
+}
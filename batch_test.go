@@ -0,0 +1,67 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBatches(t *testing.T) {
+	t.Run("must limit concurrency and keep order", func(t *testing.T) {
+		var running, peak int32
+		results, _, err := runBatches(context.Background(), 10, 3, func(ctx context.Context, i int) (int, *Response, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+
+			return i * 2, nil, nil
+		})
+		assertNoError(t, err)
+
+		if peak > 3 {
+			t.Errorf("%d batches ran at once, want at most 3", peak)
+		}
+		for i, result := range results {
+			if result != i*2 {
+				t.Fatalf("result %d is %d", i, result)
+			}
+		}
+	})
+
+	t.Run("must cancel remaining batches on error", func(t *testing.T) {
+		errBatch := errors.New("kek")
+		var calls int32
+
+		_, _, err := runBatches(context.Background(), 100, 1, func(ctx context.Context, i int) (int, *Response, error) {
+			atomic.AddInt32(&calls, 1)
+			if i == 1 {
+				return 0, nil, errBatch
+			}
+			return i, nil, nil
+		})
+		if err != errBatch {
+			t.Errorf("got error %v, want %v", err, errBatch)
+		}
+		if calls > 3 {
+			t.Errorf("got %d calls after error", calls)
+		}
+	})
+}
+
+func TestBatchBounds(t *testing.T) {
+	if n := batchCount(201); n != 3 {
+		t.Errorf("got %d batches, want 3", n)
+	}
+
+	if start, end := batchBounds(2, 201); start != 200 || end != 201 {
+		t.Errorf("got bounds %d-%d, want 200-201", start, end)
+	}
+}
@@ -0,0 +1,77 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BulkResult is the result of the call of Bulk for Item.
+type BulkResult[In, Out any] struct {
+	Item     In
+	Value    Out
+	Response *Response
+	Err      error
+}
+
+// Bulk calls call for every item, at most concurrency at once, which
+// defaults to 4 if it is 0, e.g. to look up the channels of 500 broadcasters:
+//
+//	results := twitch.Bulk(ctx, broadcasterIds, 8, func(ctx context.Context, id string) (*Channel, *twitch.Response, error) {
+//		return getChannel(ctx, client, id)
+//	})
+//	for _, result := range results {
+//		if result.Err != nil {
+//			...
+//		}
+//	}
+//
+// Results are returned in the order of items, a failed call does not stop
+// the others. When a response reports the rate limit bucket to be empty,
+// no more calls are started until it resets. Items not called because ctx
+// is done have the error of ctx.
+func Bulk[In, Out any](ctx context.Context, items []In, concurrency int, call func(ctx context.Context, item In) (Out, *Response, error)) []BulkResult[In, Out] {
+	results := make([]BulkResult[In, Out], len(items))
+	for i, item := range items {
+		results[i].Item = item
+	}
+
+	var (
+		mu     sync.Mutex
+		resume time.Time
+	)
+
+	started := forEach(ctx, len(items), concurrency, func(i int) {
+		mu.Lock()
+		wait := time.Until(resume)
+		mu.Unlock()
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				results[i].Err = ctx.Err()
+				return
+			case <-timer.C:
+			}
+		}
+
+		value, resp, err := call(ctx, items[i])
+		results[i].Value, results[i].Response, results[i].Err = value, resp, err
+
+		if resp != nil && resp.Rate.Limit > 0 && resp.Rate.Remaining == 0 {
+			mu.Lock()
+			if resp.Rate.Reset.After(resume) {
+				resume = resp.Rate.Reset
+			}
+			mu.Unlock()
+		}
+	})
+
+	for i := started; i < len(items); i++ {
+		results[i].Err = ctx.Err()
+	}
+
+	return results
+}
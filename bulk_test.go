@@ -0,0 +1,85 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBulk(t *testing.T) {
+	t.Run("must return results and errors in order of items", func(t *testing.T) {
+		errOdd := errors.New("odd")
+		items := []int{1, 2, 3, 4, 5, 6}
+
+		results := Bulk(context.Background(), items, 2, func(ctx context.Context, item int) (int, *Response, error) {
+			if item%2 == 1 {
+				return 0, nil, errOdd
+			}
+			return item * 10, nil, nil
+		})
+
+		if len(results) != len(items) {
+			t.Fatalf("got %d results", len(results))
+		}
+		for i, result := range results {
+			switch {
+			case result.Item != items[i]:
+				t.Errorf("result %d is of item %d", i, result.Item)
+			case result.Item%2 == 1 && result.Err != errOdd:
+				t.Errorf("result %d must have error, got %v", i, result.Err)
+			case result.Item%2 == 0 && (result.Err != nil || result.Value != result.Item*10):
+				t.Errorf("bad result %d: %+v", i, result)
+			}
+		}
+	})
+
+	t.Run("must wait for the rate limit bucket to reset", func(t *testing.T) {
+		reset := time.Now().Add(50 * time.Millisecond)
+		var calls []time.Time
+
+		Bulk(context.Background(), []int{1, 2}, 1, func(ctx context.Context, item int) (int, *Response, error) {
+			calls = append(calls, time.Now())
+			return item, &Response{Rate: Rate{Limit: 800, Remaining: 0, Reset: reset}}, nil
+		})
+
+		if len(calls) != 2 || calls[1].Before(reset) {
+			t.Errorf("second call was not delayed until reset")
+		}
+	})
+
+	t.Run("must return error of context for items not called", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		results := Bulk(ctx, []int{1, 2, 3}, 1, func(ctx context.Context, item int) (int, *Response, error) {
+			cancel()
+			return item, nil, nil
+		})
+
+		if results[0].Err != nil || results[2].Err != context.Canceled {
+			t.Errorf("unexpected errors: %v, %v", results[0].Err, results[2].Err)
+		}
+	})
+
+	t.Run("must run calls of the client", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+getStreamKeyPath, func(w http.ResponseWriter, r *http.Request) {
+			if id := r.URL.Query().Get("broadcaster_id"); id == "2" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"data":[{"stream_key":"key"}]}`))
+		})
+
+		results := Bulk(context.Background(), []string{"1", "2"}, 0, func(ctx context.Context, id string) (StreamKey, *Response, error) {
+			return c.Streams.GetStreamKey(ctx, &BroadcasterID{Id: id})
+		})
+
+		if results[0].Value != "key" || results[0].Err != nil || results[1].Err == nil {
+			t.Errorf("unexpected results: %+v", results)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+type cacheEntry struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// ResponseCache caches responses of GET requests, e.g. to answer repeated
+// user lookups of chat commands without asking Twitch again. Only
+// responses of endpoints with a TTL are cached.
+//
+// The cache belongs to one client, as responses may depend on its token.
+type ResponseCache struct {
+	// TTLs are the lifetimes of cached responses by endpoint, e.g. "users".
+	TTLs map[string]time.Duration
+	// Backend stores the cached responses.
+	Backend CacheBackend
+}
+
+// NewResponseCache returns a cache keeping responses in memory.
+func NewResponseCache(ttls map[string]time.Duration) *ResponseCache {
+	return &ResponseCache{TTLs: ttls, Backend: NewMemoryCacheBackend()}
+}
+
+// Invalidate drops the cached responses of the endpoint.
+func (c *ResponseCache) Invalidate(ctx context.Context, endpoint string) error {
+	return c.Backend.DeletePrefix(ctx, endpoint+"?")
+}
+
+// InvalidateAll drops every cached response.
+func (c *ResponseCache) InvalidateAll(ctx context.Context) error {
+	return c.Backend.DeletePrefix(ctx, "")
+}
+
+func (c *ResponseCache) get(ctx context.Context, key string) (*cacheEntry, error) {
+	data, err := c.Backend.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := new(cacheEntry)
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (c *ResponseCache) set(ctx context.Context, key string, entry *cacheEntry, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.Backend.Set(ctx, key, data, ttl)
+}
+
+// cacheKey returns the key and the TTL of the request,
+// the TTL is 0 if the request is not cached.
+func (c *Client) cacheKey(req *http.Request) (string, time.Duration) {
+	if c.Cache == nil || req.Method != http.MethodGet {
+		return "", 0
+	}
+
+	endpoint := c.endpoint(req)
+	return endpoint + "?" + req.URL.RawQuery, c.Cache.TTLs[endpoint]
+}
+
+// cachedResponse returns the cached response of the request, or nil.
+// Failures of the backend are logged and treated as misses.
+func (c *Client) cachedResponse(ctx context.Context, req *http.Request, key string) *http.Response {
+	entry, err := c.Cache.get(ctx, key)
+	if err != nil {
+		if err != ErrCacheMiss {
+			c.logCacheError(err)
+		}
+		return nil
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(entry.Status),
+		StatusCode:    entry.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
+
+// cacheResponse caches the successful response and replaces its
+// body with the read one. Bodies larger than MaxResponseSize are not
+// cached and fail with *ErrorResponseTooLarge.
+func (c *Client) cacheResponse(ctx context.Context, resp *http.Response, key string, ttl time.Duration) error {
+	body, err := readBody(resp, c.MaxResponseSize)
+	if err != nil {
+		resp.Body.Close()
+		return err
+	}
+
+	entry := &cacheEntry{Status: resp.StatusCode, Header: resp.Header, Body: body}
+	if err := c.Cache.set(ctx, key, entry, ttl); err != nil {
+		c.logCacheError(err)
+	}
+
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return nil
+}
+
+func (c *Client) logCacheError(err error) {
+	if c.Logger != nil {
+		c.Logger.Log("twitch cache failed", "error", err)
+	}
+}
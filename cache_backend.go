@@ -0,0 +1,170 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/holypower777/go-twitch/internal/fsutil"
+)
+
+var ErrCacheMiss = errors.New("cache entry is not found")
+
+// CacheBackend stores entries of ResponseCache, e.g. in memory, a file,
+// bbolt, badger or Redis. Get returns ErrCacheMiss when there is no entry
+// for the key or it is expired.
+type CacheBackend interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix deletes the entries which keys start with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+type cacheValue struct {
+	Value  []byte    `json:"value"`
+	Expiry time.Time `json:"expiry"`
+}
+
+func (v *cacheValue) expired() bool {
+	return !time.Now().Before(v.Expiry)
+}
+
+type MemoryCacheBackend struct {
+	mu      sync.Mutex
+	entries map[string]*cacheValue
+}
+
+func NewMemoryCacheBackend() *MemoryCacheBackend {
+	return &MemoryCacheBackend{entries: make(map[string]*cacheValue)}
+}
+
+func (b *MemoryCacheBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	v, ok := b.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	if v.expired() {
+		delete(b.entries, key)
+		return nil, ErrCacheMiss
+	}
+
+	return v.Value, nil
+}
+
+func (b *MemoryCacheBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[key] = &cacheValue{Value: value, Expiry: time.Now().Add(ttl)}
+	return nil
+}
+
+func (b *MemoryCacheBackend) DeletePrefix(ctx context.Context, prefix string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key := range b.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.entries, key)
+		}
+	}
+
+	return nil
+}
+
+// FileCacheBackend keeps entries in a JSON file, so lookups stay warm
+// across restarts. The file is replaced atomically on every change,
+// expired entries are dropped then.
+type FileCacheBackend struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func NewFileCacheBackend(path string) *FileCacheBackend {
+	return &FileCacheBackend{Path: path}
+}
+
+func (b *FileCacheBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := entries[key]
+	if !ok || v.expired() {
+		return nil, ErrCacheMiss
+	}
+
+	return v.Value, nil
+}
+
+func (b *FileCacheBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return b.update(func(entries map[string]*cacheValue) {
+		entries[key] = &cacheValue{Value: value, Expiry: time.Now().Add(ttl)}
+	})
+}
+
+func (b *FileCacheBackend) DeletePrefix(ctx context.Context, prefix string) error {
+	return b.update(func(entries map[string]*cacheValue) {
+		for key := range entries {
+			if strings.HasPrefix(key, prefix) {
+				delete(entries, key)
+			}
+		}
+	})
+}
+
+func (b *FileCacheBackend) update(f func(entries map[string]*cacheValue)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries, err := b.read()
+	if err != nil {
+		return err
+	}
+
+	for key, v := range entries {
+		if v.expired() {
+			delete(entries, key)
+		}
+	}
+	f(entries)
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(b.Path, data)
+}
+
+func (b *FileCacheBackend) read() (map[string]*cacheValue, error) {
+	entries := make(map[string]*cacheValue)
+
+	data, err := ioutil.ReadFile(b.Path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,87 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testCacheBackend(t *testing.T, b CacheBackend) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := b.Get(ctx, "users?id=1"); err != ErrCacheMiss {
+		t.Errorf("expected ErrCacheMiss, got: %v", err)
+	}
+
+	assertNoError(t, b.Set(ctx, "users?id=1", []byte("1"), time.Minute))
+	assertNoError(t, b.Set(ctx, "users?id=2", []byte("2"), time.Minute))
+	assertNoError(t, b.Set(ctx, "streams?", []byte("3"), time.Minute))
+	assertNoError(t, b.Set(ctx, "games?", []byte("4"), -time.Second))
+
+	value, err := b.Get(ctx, "users?id=1")
+	assertNoError(t, err)
+	if string(value) != "1" {
+		t.Errorf("bad value: %s", value)
+	}
+
+	if _, err := b.Get(ctx, "games?"); err != ErrCacheMiss {
+		t.Errorf("expired entry must be missing, got: %v", err)
+	}
+
+	assertNoError(t, b.DeletePrefix(ctx, "users?"))
+	for _, key := range []string{"users?id=1", "users?id=2"} {
+		if _, err := b.Get(ctx, key); err != ErrCacheMiss {
+			t.Errorf("%s must be deleted, got: %v", key, err)
+		}
+	}
+
+	if _, err := b.Get(ctx, "streams?"); err != nil {
+		t.Errorf("streams must be kept, got: %v", err)
+	}
+}
+
+func TestMemoryCacheBackend(t *testing.T) {
+	testCacheBackend(t, NewMemoryCacheBackend())
+}
+
+func TestFileCacheBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	testCacheBackend(t, NewFileCacheBackend(path))
+
+	t.Run("responses must survive restart", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache.json")
+		ttls := map[string]time.Duration{"streams": time.Minute}
+
+		requests := 0
+		newClient := func() (*Client, func()) {
+			c, mux, _, teardown := setup()
+			mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				fmt.Fprint(w, `{"data":[{"id":"1"}]}`)
+			})
+			c.Cache = &ResponseCache{TTLs: ttls, Backend: NewFileCacheBackend(path)}
+
+			return c, teardown
+		}
+
+		for i := 0; i < 2; i++ {
+			c, teardown := newClient()
+
+			streams, _, err := c.Streams.GetStreams(context.Background(), nil)
+			assertNoError(t, err)
+			if len(streams.Data) != 1 || streams.Data[0].Id != "1" {
+				t.Errorf("bad streams: %+v", streams)
+			}
+
+			teardown()
+		}
+
+		if requests != 1 {
+			t.Errorf("expected 1 request, got: %d", requests)
+		}
+	})
+}
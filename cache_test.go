@@ -0,0 +1,54 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := map[string]int{}
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		requests["users"]++
+		fmt.Fprintf(w, `{"data":[{"id":"%s","login":"twitchdev"}]}`, r.URL.Query().Get("id"))
+	})
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		requests["streams"]++
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	c.Cache = NewResponseCache(map[string]time.Duration{"users": time.Minute})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		users, _, err := c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"1"}})
+		assertNoError(t, err)
+
+		if len(users) != 1 || users[0].Id != "1" {
+			t.Fatalf("bad users: %+v", users)
+		}
+
+		_, _, err = c.Streams.GetStreams(ctx, nil)
+		assertNoError(t, err)
+	}
+
+	_, _, err := c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"2"}})
+	assertNoError(t, err)
+
+	if requests["users"] != 2 || requests["streams"] != 3 {
+		t.Errorf("bad requests: %v", requests)
+	}
+
+	assertNoError(t, c.Cache.Invalidate(ctx, "users"))
+	_, _, err = c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"1"}})
+	assertNoError(t, err)
+
+	if requests["users"] != 3 {
+		t.Errorf("invalidated response must be requested again: %v", requests)
+	}
+}
@@ -0,0 +1,122 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCircuitThreshold = 5
+	defaultCircuitCooldown  = 30 * time.Second
+)
+
+// ErrorCircuitOpen is returned without sending the request
+// while the circuit of the endpoint is open.
+type ErrorCircuitOpen struct {
+	Endpoint string
+	// RetryAt is the time a request to the endpoint is let through again.
+	RetryAt time.Time
+}
+
+func (e *ErrorCircuitOpen) Error() string {
+	return fmt.Sprintf("Message: circuit of %s is open until %s", e.Endpoint, e.RetryAt.Format(time.RFC3339))
+}
+
+type circuit struct {
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// CircuitBreaker stops requests to an endpoint failing with 5xx or timeouts
+// Threshold times in a row, to keep a bot from hammering Twitch during an
+// outage. After Cooldown one request is let through, its success closes
+// the circuit again.
+type CircuitBreaker struct {
+	// Threshold defaults to 5.
+	Threshold int
+	// Cooldown defaults to 30 seconds.
+	Cooldown time.Duration
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown == 0 {
+		return defaultCircuitCooldown
+	}
+
+	return b.Cooldown
+}
+
+// allow returns *ErrorCircuitOpen if the circuit of the endpoint is open.
+func (b *CircuitBreaker) allow(endpoint string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[endpoint]
+	if c == nil || c.openedAt.IsZero() {
+		return nil
+	}
+
+	retryAt := c.openedAt.Add(b.cooldown())
+	if c.probing || time.Now().Before(retryAt) {
+		return &ErrorCircuitOpen{Endpoint: endpoint, RetryAt: retryAt}
+	}
+
+	c.probing = true
+	return nil
+}
+
+// record counts the result of a request to the endpoint.
+func (b *CircuitBreaker) record(endpoint string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.circuits == nil {
+		b.circuits = make(map[string]*circuit)
+	}
+
+	c := b.circuits[endpoint]
+	if c == nil {
+		c = new(circuit)
+		b.circuits[endpoint] = c
+	}
+
+	if !failed {
+		*c = circuit{}
+		return
+	}
+
+	threshold := b.Threshold
+	if threshold == 0 {
+		threshold = defaultCircuitThreshold
+	}
+
+	c.failures++
+	if c.probing || c.failures >= threshold {
+		c.openedAt = time.Now()
+		c.probing = false
+	}
+}
+
+// isOutage reports whether the request failed because of Twitch,
+// i.e. with 5xx or a timeout.
+func isOutage(resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,88 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	newClient := func(t *testing.T, cooldown time.Duration) (*Client, *int32, *int32, func()) {
+		c, mux, _, teardown := setup()
+		c.RetryPolicy = nil
+		c.CircuitBreaker = NewCircuitBreaker(2, cooldown)
+
+		var calls, failing int32 = 0, 1
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			if atomic.LoadInt32(&failing) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		return c, &calls, &failing, teardown
+	}
+
+	t.Run("circuit must open after consecutive failures", func(t *testing.T) {
+		c, calls, _, teardown := newClient(t, time.Minute)
+		defer teardown()
+
+		ctx := context.Background()
+		for i := 0; i < 2; i++ {
+			_, _, err := c.Streams.GetStreams(ctx, nil)
+			assertErrorPresence(t, err)
+		}
+
+		_, _, err := c.Streams.GetStreams(ctx, nil)
+		var open *ErrorCircuitOpen
+		if !errors.As(err, &open) {
+			t.Fatalf("expected *ErrorCircuitOpen, got: %v", err)
+		}
+		if open.Endpoint != "streams" {
+			t.Errorf("expected endpoint streams, got: %s", open.Endpoint)
+		}
+		if *calls != 2 {
+			t.Errorf("expected 2 requests, got: %d", *calls)
+		}
+
+		_, _, err = c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"141981764"}})
+		assertNoError(t, err)
+	})
+
+	t.Run("circuit must close after successful probe", func(t *testing.T) {
+		c, calls, failing, teardown := newClient(t, 20*time.Millisecond)
+		defer teardown()
+
+		ctx := context.Background()
+		c.Streams.GetStreams(ctx, nil)
+		c.Streams.GetStreams(ctx, nil)
+
+		time.Sleep(30 * time.Millisecond)
+		c.Streams.GetStreams(ctx, nil)
+		if *calls != 3 {
+			t.Fatalf("expected probe after cooldown, got %d requests", *calls)
+		}
+
+		_, _, err := c.Streams.GetStreams(ctx, nil)
+		var open *ErrorCircuitOpen
+		if !errors.As(err, &open) {
+			t.Fatalf("failed probe must open the circuit, got: %v", err)
+		}
+
+		time.Sleep(30 * time.Millisecond)
+		atomic.StoreInt32(failing, 0)
+		for i := 0; i < 3; i++ {
+			_, _, err = c.Streams.GetStreams(ctx, nil)
+			assertNoError(t, err)
+		}
+	})
+}
@@ -1,4 +1,19 @@
-package bot
+// Package twitch is a client of the Twitch Helix API, its auth server
+// and EventSub.
+//
+// Parts that can be imported on their own are subpackages:
+//
+//   - auth gets and stores user access tokens, this package aliases it
+//   - irc connects to chat with the token of a Client
+//   - twitchtest runs fake Twitch servers for integration tests
+//
+// The package used to be named bot. Code written against it keeps working
+// by naming the import:
+//
+//	import bot "github.com/holypower777/go-twitch"
+package twitch
+
+//go:generate go run ./internal/gen -in endpoints.json -out endpoints_gen.go
 
 import (
 	"bytes"
@@ -7,13 +22,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/google/go-querystring/query"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 	"golang.org/x/oauth2/twitch"
@@ -22,31 +39,70 @@ import (
 const (
 	defaultBaseURL          = "https://api.twitch.tv/helix/"
 	defaultAuthURL          = "https://id.twitch.tv/oauth2/"
+	tokenValidationInterval = time.Hour
 	applicationJSON         = "application/json"
 	userAgent               = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/65.0.3325.162 Safari/537.36"
 	headerRateLimit         = "Ratelimit-Limit"
 	headerRateReset         = "Ratelimit-Reset"
 	headerRateRemaining     = "Ratelimit-Remaining"
 	notSuccessResponse      = "response is not success"
+	maxErrorResponseSize    = 64 << 10
 	userIdIsRequired        = "user_id is required"
-	userIdLoginIsRequired   = "id or login parameter is required"
 	broadcasterIdIsRequired = "broadcaster_id is required"
 )
 
+// DefaultMaxResponseSize is the MaxResponseSize of new clients, far above
+// the size of a page of Helix.
+const DefaultMaxResponseSize = 16 << 20
+
 var errNonNilContext = errors.New("context must be non-nil")
 
+// ErrNoData is returned by endpoints returning a single item,
+// when the data of the response is empty.
+var ErrNoData = errors.New("response contains no data")
+
+// Errors of common statuses, an *ErrorResponse with the status matches
+// them with errors.Is.
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// requestIdHeaders are the headers identifying a request in the logs of
+// Twitch and its proxies, in order of preference.
+var requestIdHeaders = []string{"X-Request-Id", "Twitch-Trace-Id", "X-Amzn-Trace-Id"}
+
+var statusErrors = map[int]error{
+	http.StatusBadRequest:      ErrBadRequest,
+	http.StatusUnauthorized:    ErrUnauthorized,
+	http.StatusForbidden:       ErrForbidden,
+	http.StatusNotFound:        ErrNotFound,
+	http.StatusTooManyRequests: ErrRateLimited,
+}
+
 func addParams(s string, opts interface{}) (string, error) {
+	if err := validateOptions(opts); err != nil {
+		return s, err
+	}
+
 	v := reflect.ValueOf(opts)
 	if v.Kind() == reflect.Ptr && v.IsNil() {
 		return s, nil
 	}
 
-	u, err := url.Parse(s)
+	qs, err := encodeQuery(opts)
 	if err != nil {
 		return s, err
 	}
 
-	qs, err := query.Values(opts)
+	if len(qs) == 0 {
+		return s, nil
+	}
+
+	u, err := url.Parse(s)
 	if err != nil {
 		return s, err
 	}
@@ -61,9 +117,78 @@ type Client struct {
 	BaseURL     *url.URL
 	AuthURL     *url.URL
 	UserAgent   string
+	// RetryPolicy decides whether failed requests are sent again,
+	// requests are not retried if it is nil.
+	RetryPolicy RetryPolicy
+	// RateLimiter delays requests while the rate limit bucket is empty,
+	// requests are sent right away if it is nil. It may be shared
+	// with other clients using the same bucket.
+	RateLimiter *RateLimiter
+	// OnRateLimited is called when a request is answered with 429 or
+	// delayed by RateLimiter, with the endpoint and the time to wait.
+	OnRateLimited func(endpoint string, wait time.Duration)
+	// RateUsage tracks the use of the rate limits by endpoint, if set.
+	RateUsage *RateUsage
+	// Logger logs every request sent by the client, if set.
+	Logger Logger
+	// Debug makes Logger dump requests and responses,
+	// with credentials and stream keys redacted.
+	Debug bool
+	// Metrics records requests, rate limits, retries and
+	// reconnects of EventSub WebSockets, if set.
+	Metrics Metrics
+	// StrictDecoding makes decoding of responses fail on fields the types
+	// of the library don't have, e.g. to detect changes of the API in CI.
+	StrictDecoding bool
+	// KeepRawBody makes responses keep the raw body in RawBody,
+	// e.g. to read fields the types of the library don't have yet.
+	KeepRawBody bool
+	// MaxResponseSize limits the size of response bodies read by Do, larger
+	// bodies fail with *ErrorResponseTooLarge. NewClient sets it to
+	// DefaultMaxResponseSize, bodies are read without limit if it is 0.
+	MaxResponseSize int64
+	// Cache caches responses of GET requests, if set.
+	Cache *ResponseCache
+	// CoalesceRequests makes concurrent identical GET requests share one
+	// request to Twitch. Callers stop waiting when their context is done,
+	// the shared request is canceled once all of them did or the client
+	// is closed.
+	CoalesceRequests bool
+	// CircuitBreaker stops requests to endpoints during outages, if set.
+	CircuitBreaker *CircuitBreaker
+	// ConcurrencyLimiter limits the requests in flight, requests are not
+	// limited if it is nil. It may be shared with other clients.
+	ConcurrencyLimiter *ConcurrencyLimiter
+	// Timeout limits the time of every call, including retries and reading
+	// the response. WithTimeout overrides it for a call.
+	Timeout time.Duration
+	// BroadcasterId is used by calls of a broadcaster when their options
+	// leave it empty.
+	BroadcasterId string
+	// Header is added to every request to Helix.
+	Header http.Header
+	// DryRun makes POST, PATCH, PUT and DELETE requests be logged by Logger
+	// instead of sent, e.g. to test moderation automation on real channels.
+	// They are answered with 204 No Content, so calls returning the created
+	// item return ErrNoData or an empty response.
+	DryRun bool
+
+	// The services may be replaced with mocks in tests.
+	Auth     AuthAPI
+	EventSub EventSubAPI
+	Streams  StreamsAPI
+	Users    UsersAPI
+
+	eventSubBudget *EventSubBudget
+	tokenSource    *tokenSource
+	appHTTPClient  *http.Client
+
+	middleware []Middleware
+	flights    *flightGroup
 
-	Streams *StreamsService
-	Users   *UsersService
+	// ctx is the parent of background work, canceled by Close.
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	common service
 }
@@ -76,6 +201,19 @@ type Credentials struct {
 	ClientId     string
 	ClientSecret string
 	OAuthToken   *oauth2.Token
+	// AccessToken is used as is when OAuthToken is not set,
+	// it is never refreshed and doesn't need ClientSecret.
+	AccessToken string
+	// TokenStore keeps the token of UserId between restarts: it is loaded
+	// when OAuthToken is not set, and every refreshed token is saved.
+	TokenStore TokenStore
+	UserId     string
+	// OnTokenRefreshed is called with every new token obtained
+	// with the refresh token of OAuthToken, so it can be persisted.
+	OnTokenRefreshed func(token *oauth2.Token)
+	// OnTokenInvalid is called when the hourly validation of the user token
+	// finds out it is expired or revoked, e.g. to reauthorize or shut down.
+	OnTokenInvalid func()
 }
 
 type ErrorEmptyCredentials struct {
@@ -87,78 +225,193 @@ func (e *ErrorEmptyCredentials) Error() string {
 }
 
 func NewClient(creds *Credentials, httpClient *http.Client) (*Client, error) {
+	return NewClientWithContext(context.Background(), creds, httpClient)
+}
+
+// NewClientWithContext returns a client whose background work, i.e. token
+// validation and token fetches, is canceled with ctx or by Close.
+func NewClientWithContext(ctx context.Context, creds *Credentials, httpClient *http.Client) (*Client, error) {
+	if ctx == nil {
+		return nil, errNonNilContext
+	}
+
 	if creds.ClientId == "" {
 		return nil, &ErrorEmptyCredentials{"ClientId"}
 	}
 
-	if creds.ClientSecret == "" {
+	if err := loadToken(ctx, creds); err != nil {
+		return nil, err
+	}
+
+	// ClientSecret may be empty for public clients,
+	// which can only use a user token obtained with PKCE.
+	if creds.ClientSecret == "" && creds.OAuthToken == nil && creds.AccessToken == "" {
 		return nil, &ErrorEmptyCredentials{"ClientSecret"}
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
 	authURL, _ := url.Parse(defaultAuthURL)
 
+	// Without an httpClient, requests are sent with the shared tuned transport.
+	provided := httpClient != nil
+	if !provided {
+		httpClient = &http.Client{Transport: defaultTransport}
+	}
+
+	// Tokens are fetched with the httpClient too.
+	tokenCtx := context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+
 	// If OAuthToken is provided, the httpClient will contain
 	// provided OAuth token.
 	// The token will auto-refresh as necessary.
-	// THe token will auto-validate every hour.
+	var source *tokenSource
 	if creds.OAuthToken != nil {
-		oauth2Config := &oauth2.Config{
-			ClientID:     creds.ClientId,
-			ClientSecret: creds.ClientSecret,
-			Endpoint: oauth2.Endpoint{
-				AuthURL: authURL.String(),
-			},
-		}
-
-		ticker := time.NewTicker(30 * time.Minute)
-		quit := make(chan struct{})
-		go func() {
-			for {
-				select {
-				case <-ticker.C:
-				case <-quit:
-					ticker.Stop()
-					return
-				}
-			}
-		}()
+		source = &tokenSource{token: creds.OAuthToken, httpClient: httpClient}
+		// The source caches the token itself, so a refresh after 401
+		// is picked up by the next request.
+		httpClient = withTokenSource(httpClient, source)
+	}
 
-		httpClient = oauth2Config.Client(context.Background(), creds.OAuthToken)
+	// If only AccessToken is provided, it is sent with every request
+	// of the httpClient.
+	if creds.OAuthToken == nil && creds.AccessToken != "" {
+		httpClient = withTokenSource(httpClient, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: creds.AccessToken}))
 	}
 
 	// If OAuthToken is not provided, the httpClient will contain
 	// provided user access token.
 	// The token will auto-refresh as necessary.
-	if creds.OAuthToken == nil && httpClient == nil {
+	if creds.OAuthToken == nil && creds.AccessToken == "" && !provided {
 		oauth2Config := &clientcredentials.Config{
 			ClientID:     creds.ClientId,
 			ClientSecret: creds.ClientSecret,
 			TokenURL:     twitch.Endpoint.TokenURL,
 		}
 
-		httpClient = oauth2Config.Client(context.Background())
+		httpClient = oauth2Config.Client(tokenCtx)
 	}
 
-	if httpClient == nil {
-		httpClient = &http.Client{}
+	// With a user token and the secret, the client also holds an app token
+	// for endpoints that require one.
+	var appHTTPClient *http.Client
+	if creds.ClientSecret != "" && (creds.OAuthToken != nil || creds.AccessToken != "") {
+		oauth2Config := &clientcredentials.Config{
+			ClientID:     creds.ClientId,
+			ClientSecret: creds.ClientSecret,
+			TokenURL:     twitch.Endpoint.TokenURL,
+		}
+
+		appHTTPClient = oauth2Config.Client(tokenCtx)
 	}
 
 	baseURL, _ := url.Parse(defaultBaseURL)
 
 	c := &Client{
-		credentials: creds,
-		HTTPClient:  httpClient,
-		BaseURL:     baseURL,
-		AuthURL:     authURL,
-		UserAgent:   "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/65.0.3325.162 Safari/537.36",
+		credentials:     creds,
+		HTTPClient:      httpClient,
+		BaseURL:         baseURL,
+		AuthURL:         authURL,
+		UserAgent:       "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/65.0.3325.162 Safari/537.36",
+		RateLimiter:     NewRateLimiter(),
+		MaxResponseSize: DefaultMaxResponseSize,
+		eventSubBudget:  new(EventSubBudget),
+		tokenSource:     source,
+		appHTTPClient:   appHTTPClient,
+		flights:         new(flightGroup),
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+	if source != nil {
+		source.client = c
 	}
 	c.common.client = c
+	c.Auth = (*AuthService)(&c.common)
+	c.EventSub = (*EventSubService)(&c.common)
 	c.Streams = (*StreamsService)(&c.common)
 	c.Users = (*UsersService)(&c.common)
 
+	// User tokens must be validated every hour.
+	if creds.OAuthToken != nil || creds.AccessToken != "" {
+		go c.validateTokenPeriodically(ctx, tokenValidationInterval)
+	}
+
 	return c, nil
 }
 
+// Close stops the background work of the client.
+// The client must not be used after Close.
+func (c *Client) Close() {
+	c.cancel()
+}
+
+// withTokenSource returns a copy of httpClient sending the token of source,
+// the transport of httpClient stays the base of the oauth2 transport.
+func withTokenSource(httpClient *http.Client, source oauth2.TokenSource) *http.Client {
+	c := new(http.Client)
+	if httpClient != nil {
+		*c = *httpClient
+	}
+	c.Transport = &oauth2.Transport{Source: source, Base: c.Transport}
+
+	return c
+}
+
+func (c *Client) validateTokenPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkToken(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkToken calls OnTokenInvalid when the token is invalid,
+// other validation errors are ignored until the next check.
+func (c *Client) checkToken(ctx context.Context) {
+	_, _, err := c.Auth.ValidateToken(ctx)
+	if err == ErrInvalidToken && c.credentials.OnTokenInvalid != nil {
+		c.credentials.OnTokenInvalid()
+	}
+}
+
+// loadToken sets OAuthToken from the token store, or saves
+// the provided OAuthToken to it.
+func loadToken(ctx context.Context, creds *Credentials) error {
+	if creds.TokenStore == nil || creds.UserId == "" {
+		return nil
+	}
+
+	if creds.OAuthToken != nil {
+		return creds.TokenStore.Save(ctx, creds.UserId, creds.OAuthToken)
+	}
+
+	token, err := creds.TokenStore.Load(ctx, creds.UserId)
+	if err == ErrTokenNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	creds.OAuthToken = token
+	return nil
+}
+
+// NewClientWithToken returns a client sending accessToken with every request,
+// e.g. a token received from an external auth service.
+func NewClientWithToken(clientId, accessToken string, httpClient *http.Client) (*Client, error) {
+	if accessToken == "" {
+		return nil, &ErrorEmptyCredentials{"AccessToken"}
+	}
+
+	return NewClient(&Credentials{ClientId: clientId, AccessToken: accessToken}, httpClient)
+}
+
 func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
 	u, err := c.BaseURL.Parse(path)
 
@@ -166,16 +419,17 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 		return nil, err
 	}
 
-	var buf io.ReadWriter
+	var buf io.Reader
 	if body != nil {
-		buf = &bytes.Buffer{}
-		enc := json.NewEncoder(buf)
-		enc.SetEscapeHTML(false)
-		err := enc.Encode(body)
+		if err := validateOptions(body); err != nil {
+			return nil, err
+		}
 
+		data, err := encodeBody(body)
 		if err != nil {
 			return nil, err
 		}
+		buf = bytes.NewReader(data)
 	}
 
 	req, err := http.NewRequest(method, u.String(), buf)
@@ -189,6 +443,73 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 	}
 
 	req.Header.Set("Client-Id", c.credentials.ClientId)
+	req.Header.Set("User-Agent", c.UserAgent)
+	for key, values := range c.Header {
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+
+	return req, nil
+}
+
+// bufferPool holds the buffers bodies are encoded to and read into,
+// so polling bots do not grow a new buffer for every request.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// maxPooledBuffer is the capacity of buffers that are not pooled
+// again, so a single large response is not kept forever.
+const maxPooledBuffer = 1 << 20
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+// encodeBody returns the JSON of body, encoded in a pooled buffer.
+func encodeBody(body interface{}) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(body); err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), buf.Bytes()...), nil
+}
+
+// newAuthRequest creates a request to the auth server,
+// form is sent url encoded if it is not nil.
+func (c *Client) newAuthRequest(method, path string, form url.Values) (*http.Request, error) {
+	u, err := c.AuthURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
 	req.Header.Set("User-Agent", c.UserAgent)
 	return req, nil
 }
@@ -203,25 +524,112 @@ type Response struct {
 	*http.Response
 
 	Rate Rate
+	// RawBody is the body of the response, if KeepRawBody of the client is set.
+	RawBody json.RawMessage
+	// RequestId identifies the request in the logs of Twitch, e.g. for
+	// support tickets. It is empty if the response has none.
+	RequestId string
+	// Buckets are the rates of the buckets of the endpoint besides the bucket
+	// of Rate, by name, e.g. helixclipscreation of the Ratelimit-Helixclipscreation-*
+	// headers. It is nil if the endpoint has none.
+	Buckets map[string]Rate
+	// Cursor is the cursor of the next page of a paginated endpoint,
+	// it is empty on the last page.
+	Cursor string
 }
 
 type Pagination struct {
 	Cursor string `json:"cursor,omitempty"`
 }
 
+// DataResponse is the envelope of Helix responses: the items, the cursor
+// of the next page and, for some endpoints, the total number of items.
+type DataResponse[T any] struct {
+	Data       []T `json:"data,omitempty"`
+	Pagination `json:"pagination,omitempty"`
+	Total      int `json:"total,omitempty"`
+}
+
+func (r *DataResponse[T]) page() ([]T, string) {
+	return r.Data, r.Cursor
+}
+
 type ErrorResponse struct {
 	*http.Response
 
+	// ErrorText is the error of the response body, e.g. Unauthorized.
+	ErrorText string
+	// Message is the message of the response body, e.g. Missing scope: user:read:email.
 	Message string
+	// RequestId identifies the request in the logs of Twitch,
+	// it is empty if the response has none.
+	RequestId string
+}
+
+// Unwrap returns the error of the status, e.g. ErrNotFound, or nil.
+func (e *ErrorResponse) Unwrap() error {
+	return statusErrors[e.StatusCode]
+}
+
+// errorResponseBody is the body of error responses of Twitch.
+type errorResponseBody struct {
+	Error   string `json:"error,omitempty"`
+	Status  int    `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// newErrorResponse returns the error of a response, which status is not success.
+func newErrorResponse(r *http.Response) *ErrorResponse {
+	errResp := &ErrorResponse{Response: r, Message: notSuccessResponse, RequestId: requestId(r.Header)}
+
+	// Error bodies of Twitch are short, a larger body is not one of them.
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxErrorResponseSize))
+	if err != nil || len(data) == 0 {
+		return errResp
+	}
+
+	body := new(errorResponseBody)
+	if json.Unmarshal(data, body) == nil {
+		errResp.ErrorText = body.Error
+		if body.Message != "" {
+			errResp.Message = body.Message
+		}
+	}
+
+	return errResp
 }
 
 func (e *ErrorResponse) Error() string {
-	return fmt.Sprintf("Method: %v\nURL: %v\nStatus Code: %d\nMessage: %v\nResponse: %v",
+	msg := fmt.Sprintf("Method: %v\nURL: %v\nStatus Code: %d\nMessage: %v",
 		e.Request.Method,
 		e.Request.URL,
 		e.StatusCode,
 		e.Message,
-		e.Response,
+	)
+
+	if e.RequestId != "" {
+		msg += "\nRequest Id: " + e.RequestId
+	}
+
+	return msg + fmt.Sprintf("\nResponse: %v", e.Response)
+}
+
+// ErrorResponseTooLarge is returned by Do when the body of a response
+// is larger than MaxResponseSize of the client, and by BareDo when such
+// a body would be buffered for the Cache or CoalesceRequests.
+type ErrorResponseTooLarge struct {
+	*http.Response
+
+	// Limit is MaxResponseSize of the client.
+	Limit int64
+}
+
+func (e *ErrorResponseTooLarge) Error() string {
+	return fmt.Sprintf("Method: %v\nURL: %v\nStatus Code: %d\nMessage: response body is larger than %d bytes",
+		e.Request.Method,
+		e.Request.URL,
+		e.StatusCode,
+		e.Limit,
 	)
 }
 
@@ -235,69 +643,384 @@ func (e *ErrorInvalidOptions) Error() string {
 }
 
 func NewResponse(r *http.Response) *Response {
-	resp := &Response{Response: r}
+	resp := &Response{Response: r, RequestId: requestId(r.Header)}
 	resp.parseRate()
 
 	return resp
 }
 
 func (r *Response) parseRate() {
+	r.Rate = parseRate(r.Response.Header, headerRateLimit, headerRateRemaining, headerRateReset)
+	r.Buckets = parseBuckets(r.Response.Header)
+}
+
+// parseRate returns the rate of the limit, remaining and reset headers.
+func parseRate(header http.Header, limitKey, remainingKey, resetKey string) Rate {
 	var rate Rate
-	if limit := r.Response.Header.Get(headerRateLimit); limit != "" {
+	if limit := header.Get(limitKey); limit != "" {
 		rate.Limit, _ = strconv.Atoi(limit)
 	}
 
-	if reset := r.Response.Header.Get(headerRateReset); reset != "" {
+	if reset := header.Get(resetKey); reset != "" {
 		rst, _ := strconv.ParseInt(reset, 10, 64)
 		rate.Reset = time.Unix(rst, 0)
 	}
 
-	if remaining := r.Response.Header.Get(headerRateRemaining); remaining != "" {
+	if remaining := header.Get(remainingKey); remaining != "" {
 		rate.Remaining, _ = strconv.Atoi(remaining)
 	}
 
-	r.Rate = rate
+	return rate
+}
+
+// requestId returns the first of the requestIdHeaders set in header.
+func requestId(header http.Header) string {
+	for _, name := range requestIdHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+
+	return ""
 }
 
 func (r *Response) isSuccess() bool {
 	return r.StatusCode >= 200 && r.StatusCode <= 299
 }
 
-func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+// BareDo sends the request and returns the response with its body open,
+// e.g. for endpoints returning other formats than JSON. The caller must
+// close the body. A response with a status other than 2xx is returned
+// as *ErrorResponse with its body closed.
+func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, error) {
 	if ctx == nil {
 		return nil, errNonNilContext
 	}
 
-	req = req.WithContext(ctx)
+	ctx, cancel := c.withTimeout(ctx)
+	req = withExtra(req.WithContext(ctx))
+
+	cacheKey, ttl := c.cacheKey(req)
+	if ttl > 0 {
+		if resp := c.cachedResponse(ctx, req, cacheKey); resp != nil {
+			cancel()
+			return NewResponse(resp), nil
+		}
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	var resp *http.Response
+	var err error
+	if c.CoalesceRequests && req.Method == http.MethodGet {
+		resp, err = c.flights.do(ctx, c.ctx, flightKey(ctx, req), c.MaxResponseSize, func(ctx context.Context) (*http.Response, error) {
+			// The shared request is not canceled with the caller that
+			// sent it, only once every caller gave up or the client
+			// is closed.
+			ctx, cancel := c.withTimeout(ctx)
+			resp, err := c.send(ctx, req.WithContext(ctx))
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
 
+			return resp, nil
+		})
+	} else {
+		resp, err = c.send(ctx, req)
+	}
 	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	response := NewResponse(resp)
+
+	if success := response.isSuccess(); !success {
+		defer resp.Body.Close()
+		return nil, newErrorResponse(resp)
+	}
+
+	if ttl > 0 {
+		if err := c.cacheResponse(ctx, resp, cacheKey, ttl); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	response, err := c.BareDo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.Response
+	defer resp.Body.Close()
+
+	if v == nil && !c.KeepRawBody {
+		return response, nil
+	}
+
+	limit := c.MaxResponseSize
+	if limit > 0 && resp.ContentLength > limit {
+		return response, &ErrorResponseTooLarge{Response: resp, Limit: limit}
+	}
+
+	body := io.Reader(resp.Body)
+	if limit > 0 {
+		// One more byte tells a body of the limit from a larger one.
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if _, err := buf.ReadFrom(body); err != nil {
+		return response, err
+	}
+
+	if limit > 0 && int64(buf.Len()) > limit {
+		return response, &ErrorResponseTooLarge{Response: resp, Limit: limit}
+	}
+
+	if c.KeepRawBody {
+		response.RawBody = append(json.RawMessage(nil), buf.Bytes()...)
+	}
+
+	if v != nil {
+		err = c.decode(buf.Bytes(), v)
+	}
+
+	if p, ok := v.(interface{ nextCursor() string }); ok && err == nil {
+		response.Cursor = p.nextCursor()
+	}
+
+	return response, err
+}
+
+// readBody reads the body of resp, which is buffered to be shared, it
+// returns *ErrorResponseTooLarge if the body is larger than limit.
+// The body is read without limit if limit is 0.
+func readBody(resp *http.Response, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	if resp.ContentLength > limit {
+		return nil, &ErrorResponseTooLarge{Response: resp, Limit: limit}
+	}
+
+	// One more byte tells a body of the limit from a larger one.
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		return nil, &ErrorResponseTooLarge{Response: resp, Limit: limit}
+	}
+
+	return body, nil
+}
+
+// decode decodes the JSON body into v, empty bodies are left undecoded.
+func (c *Client) decode(body []byte, v interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if c.StrictDecoding {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.DisallowUnknownFields()
+		return dec.Decode(v)
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// send sends the request, sending it again as long as RetryPolicy asks to.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.wait(ctx, func(wait time.Duration) { c.rateLimited(req, wait) }); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.CircuitBreaker != nil {
+			if err := c.CircuitBreaker.allow(c.endpoint(req)); err != nil {
+				return nil, err
+			}
+		}
+
+		release := func() {}
+		if c.ConcurrencyLimiter != nil {
+			var err error
+			if release, err = c.ConcurrencyLimiter.acquire(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.sendWithRefresh(ctx, req)
+		if resp != nil {
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: release}
+		} else {
+			release()
+		}
+
+		if c.CircuitBreaker != nil && (resp != nil || err != nil) {
+			c.CircuitBreaker.record(c.endpoint(req), isOutage(resp, err))
+		}
+
+		if resp != nil {
+			response := NewResponse(resp)
+			if c.RateUsage != nil {
+				c.RateUsage.record(req.Method+" "+c.endpoint(req), response)
+			}
+
+			rate := response.Rate
+			if c.RateLimiter != nil {
+				c.RateLimiter.Update(rate)
+			}
+
+			if c.Metrics != nil && rate.Limit != 0 {
+				c.Metrics.ObserveRateLimit(rate.Remaining, rate.Limit)
+			}
+
+			if resp.StatusCode == http.StatusTooManyRequests {
+				c.rateLimited(req, rate.untilReset())
+			}
+		}
+
+		if c.RetryPolicy == nil || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay, retry := c.RetryPolicy.ShouldRetry(resp, err, attempt)
+		if !retry || !rewindable(req) {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if c.Metrics != nil {
+			c.Metrics.IncRetry(c.endpoint(req))
+		}
+
+		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return nil, ctx.Err()
-		default:
+		case <-timer.C:
+		}
+
+		if req, err = rewind(ctx, req); err != nil {
+			return nil, err
 		}
+	}
+}
+
+func (c *Client) rateLimited(req *http.Request, wait time.Duration) {
+	if c.OnRateLimited == nil {
+		return
+	}
+
+	c.OnRateLimited(c.endpoint(req), wait)
+}
+
+// endpoint returns the path of the request relative to BaseURL, e.g. streams.
+func (c *Client) endpoint(req *http.Request) string {
+	return strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, c.BaseURL.Path), "/")
+}
+
+// sendWithRefresh sends the request. A request rejected with 401 is sent once
+// more after refreshing the user token, if the client holds a refresh token.
+func (c *Client) sendWithRefresh(ctx context.Context, req *http.Request) (*http.Response, error) {
+	httpClient := c.httpClientFor(ctx)
+
+	// Concurrent requests rejected with the same token refresh it once.
+	var seen *oauth2.Token
+	if c.tokenSource != nil {
+		seen = c.tokenSource.current()
+	}
+
+	resp, err := c.sendRequest(ctx, httpClient, req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	if httpClient != c.HTTPClient || c.tokenSource == nil || !rewindable(req) {
+		return resp, nil
+	}
+
+	if !c.tokenSource.refreshable() {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if token, err := c.tokenSource.refresh(ctx, seen); token == nil {
+		return nil, &ErrorTokenRefresh{Err: err}
+	}
+
+	retry, err := rewind(ctx, req)
+	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
+	return c.sendRequest(ctx, httpClient, retry)
+}
 
-	response := NewResponse(resp)
+func (c *Client) sendRequest(ctx context.Context, httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	roundTrip := RoundTripFunc(httpClient.Do)
+	if c.DryRun && mutating(req.Method) {
+		roundTrip = c.dryRun
+	}
+	if c.Logger != nil {
+		roundTrip = c.logRoundTrip(roundTrip)
+	}
 
-	if success := response.isSuccess(); !success {
-		return nil, &ErrorResponse{resp, notSuccessResponse}
+	if c.Metrics != nil {
+		roundTrip = c.observeRoundTrip(roundTrip)
 	}
 
-	if v != nil {
-		decErr := json.NewDecoder(resp.Body).Decode(v)
-		if decErr == io.EOF {
-			decErr = nil
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		roundTrip = c.middleware[i](roundTrip)
+	}
+
+	resp, err := roundTrip(req)
+	if err != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
 		}
-		if decErr != nil {
-			err = decErr
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// rewindable reports whether the body of the request can be sent again.
+func rewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of the request with a fresh body.
+func rewind(ctx context.Context, req *http.Request) (*http.Request, error) {
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
 		}
+		retry.Body = body
 	}
 
-	return response, err
+	return retry, nil
 }
@@ -1,16 +1,23 @@
-package bot
+package twitch
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"golang.org/x/oauth2"
 )
 
 func TestNewClient(t *testing.T) {
@@ -42,6 +49,120 @@ func TestNewClient(t *testing.T) {
 		assertErrorPresence(t, err)
 		assertErrorMessage(t, err, "ClientSecret field is required")
 	})
+
+	t.Run("public client with user token doesn't need secret", func(t *testing.T) {
+		_, err := NewClient(&Credentials{ClientId: "kek", OAuthToken: &oauth2.Token{AccessToken: "t0ken"}}, nil)
+		assertNoError(t, err)
+	})
+
+	t.Run("provided transport must be kept with user token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got, want := r.Header.Get("Authorization"), "Bearer t0ken"; got != want {
+				t.Errorf("bad authorization header\ngot: %s\nwant: %s", got, want)
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		}))
+		defer server.Close()
+
+		transport := &countingTransport{}
+		httpClient := &http.Client{Transport: transport, Timeout: time.Minute}
+
+		c, err := NewClient(&Credentials{ClientId: "kek", OAuthToken: &oauth2.Token{AccessToken: "t0ken"}}, httpClient)
+		assertNoError(t, err)
+		c.BaseURL, _ = url.Parse(server.URL + baseURLPath + "/")
+
+		_, _, err = c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+		assertNoError(t, err)
+
+		if transport.requests != 1 {
+			t.Errorf("expected 1 request through the provided transport, got: %d", transport.requests)
+		}
+
+		if c.HTTPClient.Timeout != time.Minute {
+			t.Errorf("timeout of the provided client is lost: %v", c.HTTPClient.Timeout)
+		}
+	})
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientClose(t *testing.T) {
+	t.Run("must stop token validation", func(t *testing.T) {
+		c, err := NewClient(&Credentials{ClientId: "kek", OAuthToken: &oauth2.Token{AccessToken: "t0ken"}}, nil)
+		assertNoError(t, err)
+
+		done := make(chan struct{})
+		go func() {
+			c.validateTokenPeriodically(c.ctx, time.Hour)
+			close(done)
+		}()
+
+		c.Close()
+		c.Close()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("validation was not stopped")
+		}
+	})
+
+	t.Run("parent context must cancel background work", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		c, err := NewClientWithContext(ctx, creds, nil)
+		assertNoError(t, err)
+
+		cancel()
+		if c.ctx.Err() == nil {
+			t.Error("client context must be canceled with the parent")
+		}
+	})
+
+	t.Run("must return error, when context is nil", func(t *testing.T) {
+		if _, err := NewClientWithContext(nil, creds, nil); err != errNonNilContext {
+			t.Errorf("expected errNonNilContext, got: %v", err)
+		}
+	})
+}
+
+func TestNewClientWithToken(t *testing.T) {
+	t.Run("access token must be sent with requests", func(t *testing.T) {
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mux.HandleFunc("/helix/users", func(w http.ResponseWriter, r *http.Request) {
+			if got, want := r.Header.Get("Authorization"), "Bearer t0ken"; got != want {
+				t.Errorf("bad authorization header\ngot: %s\nwant: %s", got, want)
+			}
+
+			if got := r.Header.Get("Client-Id"); got != "ClientId" {
+				t.Errorf("bad client id: %s", got)
+			}
+
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		c, err := NewClientWithToken("ClientId", "t0ken", nil)
+		assertNoError(t, err)
+		c.BaseURL, _ = url.Parse(server.URL + baseURLPath + "/")
+
+		_, _, err = c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+		assertNoError(t, err)
+	})
+
+	t.Run("access token is required", func(t *testing.T) {
+		_, err := NewClientWithToken("ClientId", "", nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "AccessToken field is required")
+	})
 }
 
 func TestNewRequest(t *testing.T) {
@@ -171,6 +292,268 @@ func TestDo(t *testing.T) {
 	})
 }
 
+func TestStrictDecoding(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"1","new_field":true}]}`)
+	})
+
+	_, _, err := c.Streams.GetStreams(context.Background(), nil)
+	assertNoError(t, err)
+
+	c.StrictDecoding = true
+	_, _, err = c.Streams.GetStreams(context.Background(), nil)
+	assertErrorPresence(t, err)
+
+	if !strings.Contains(err.Error(), "new_field") {
+		t.Errorf("error must name the unknown field, got: %v", err)
+	}
+}
+
+func TestKeepRawBody(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	body := `{"data":[{"id":"1","new_field":true}]}`
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	c.KeepRawBody = true
+	streams, resp, err := c.Streams.GetStreams(context.Background(), nil)
+	assertNoError(t, err)
+
+	if string(resp.RawBody) != body || streams.Data[0].Id != "1" {
+		t.Errorf("bad raw body %s of %+v", resp.RawBody, streams)
+	}
+
+	var raw struct {
+		Data []struct {
+			NewField bool `json:"new_field"`
+		} `json:"data"`
+	}
+	assertNoError(t, json.Unmarshal(resp.RawBody, &raw))
+	if !raw.Data[0].NewField {
+		t.Error("new field must be readable from the raw body")
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	body := `{"data":[{"id":"1"}]}`
+
+	cases := []struct {
+		name          string
+		limit         int64
+		contentLength bool
+		tooLarge      bool
+	}{
+		{"body of the limit must be decoded", int64(len(body)), true, false},
+		{"larger body must fail", int64(len(body)) - 1, true, true},
+		{"larger body without length must fail", int64(len(body)) - 1, false, true},
+		{"body must be read without limit", 0, true, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+				if tc.contentLength {
+					w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+				}
+				fmt.Fprint(w, body)
+				w.(http.Flusher).Flush()
+			})
+
+			c.MaxResponseSize = tc.limit
+			streams, _, err := c.Streams.GetStreams(context.Background(), nil)
+
+			var tooLarge *ErrorResponseTooLarge
+			if errors.As(err, &tooLarge) != tc.tooLarge {
+				t.Fatalf("got error %v", err)
+			}
+
+			if tc.tooLarge {
+				if tooLarge.Limit != tc.limit {
+					t.Errorf("got limit %d, want %d", tooLarge.Limit, tc.limit)
+				}
+				return
+			}
+
+			assertNoError(t, err)
+			if streams.Data[0].Id != "1" {
+				t.Errorf("bad streams %+v", streams)
+			}
+		})
+	}
+
+	buffered := []struct {
+		name  string
+		setup func(c *Client)
+	}{
+		{"cached", func(c *Client) { c.Cache = NewResponseCache(map[string]time.Duration{"streams": time.Minute}) }},
+		{"coalesced", func(c *Client) { c.CoalesceRequests = true }},
+	}
+
+	for _, tc := range buffered {
+		t.Run("larger "+tc.name+" body must fail", func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, body)
+				w.(http.Flusher).Flush()
+			})
+
+			tc.setup(c)
+			c.MaxResponseSize = int64(len(body)) - 1
+
+			// BareDo doesn't limit the body itself, but it must not buffer it.
+			req, _ := c.NewRequest(http.MethodGet, "streams", nil)
+			_, err := c.BareDo(context.Background(), req)
+
+			var tooLarge *ErrorResponseTooLarge
+			if !errors.As(err, &tooLarge) || tooLarge.Limit != c.MaxResponseSize {
+				t.Errorf("expected *ErrorResponseTooLarge, got: %v", err)
+			}
+		})
+	}
+
+	t.Run("NewClient must set the default", func(t *testing.T) {
+		c, _ := NewClient(creds, nil)
+		if c.MaxResponseSize != DefaultMaxResponseSize {
+			t.Errorf("got %d", c.MaxResponseSize)
+		}
+	})
+}
+
+func TestErrorResponse(t *testing.T) {
+	t.Run("must contain message of the body", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"Unauthorized","status":401,"message":"Missing scope: user:read:email"}`)
+		})
+
+		req, _ := c.NewRequest(http.MethodGet, "users", nil)
+		_, err := c.Do(context.Background(), req, nil)
+
+		errResp, ok := err.(*ErrorResponse)
+		if !ok {
+			t.Fatalf("expected *ErrorResponse, got: %v", err)
+		}
+
+		if errResp.ErrorText != "Unauthorized" || errResp.Message != "Missing scope: user:read:email" {
+			t.Errorf("bad error response: %q %q", errResp.ErrorText, errResp.Message)
+		}
+	})
+
+	t.Run("must match errors of statuses", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		statuses := map[string]int{}
+		mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statuses[r.URL.Query().Get("error")])
+		})
+
+		for status, want := range statusErrors {
+			statuses[want.Error()] = status
+
+			req, _ := c.NewRequest(http.MethodGet, "status?error="+url.QueryEscape(want.Error()), nil)
+			_, err := c.Do(context.Background(), req, nil)
+
+			if !errors.Is(err, want) {
+				t.Errorf("expected %v for status %d, got: %v", want, status, err)
+			}
+		}
+	})
+
+	t.Run("must contain request id", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Twitch-Trace-Id", "trace")
+			w.Header().Set("X-Request-Id", "request")
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		req, _ := c.NewRequest(http.MethodGet, "users", nil)
+		_, err := c.Do(context.Background(), req, nil)
+
+		errResp, ok := err.(*ErrorResponse)
+		if !ok {
+			t.Fatalf("expected *ErrorResponse, got: %v", err)
+		}
+
+		if errResp.RequestId != "request" || !strings.Contains(err.Error(), "\nRequest Id: request\n") {
+			t.Errorf("bad request id %q of %v", errResp.RequestId, err)
+		}
+	})
+
+	t.Run("must keep default message without body", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `<html>Bad Gateway</html>`)
+		})
+
+		req, _ := c.NewRequest(http.MethodGet, "users", nil)
+		_, err := c.Do(context.Background(), req, nil)
+
+		if errResp, ok := err.(*ErrorResponse); !ok || errResp.Message != notSuccessResponse {
+			t.Errorf("bad error: %v", err)
+		}
+	})
+}
+
+func TestBareDo(t *testing.T) {
+	t.Run("must return response with open body", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		body := "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"
+		mux.HandleFunc("/schedule/icalendar", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/calendar")
+			fmt.Fprint(w, body)
+		})
+
+		req, _ := c.NewRequest(http.MethodGet, "schedule/icalendar", nil)
+		resp, err := c.BareDo(context.Background(), req)
+		assertNoError(t, err)
+		defer resp.Body.Close()
+
+		got, err := ioutil.ReadAll(resp.Body)
+		assertNoError(t, err)
+
+		if string(got) != body {
+			t.Errorf("body is not equal\ngot: %q\nwant: %q", got, body)
+		}
+	})
+
+	t.Run("must return ErrorResponse", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/schedule/icalendar", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		req, _ := c.NewRequest(http.MethodGet, "schedule/icalendar", nil)
+		if _, err := c.BareDo(context.Background(), req); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got: %v", err)
+		}
+	})
+}
+
 func TestNewResponse(t *testing.T) {
 	c, mux, _, teardown := setup()
 	defer teardown()
@@ -189,6 +572,7 @@ func TestNewResponse(t *testing.T) {
 		w.Header().Set(headerRateLimit, strconv.Itoa(rateLimit))
 		w.Header().Set(headerRateRemaining, strconv.Itoa(rateRemaining))
 		w.Header().Set(headerRateReset, strconv.Itoa(int(rateReset.Unix())))
+		w.Header().Set("Twitch-Trace-Id", "trace")
 		w.WriteHeader(http.StatusCreated)
 	})
 
@@ -225,5 +609,118 @@ func TestNewResponse(t *testing.T) {
 		if got, want := resp.Rate.Reset.Unix(), rateReset.Unix(); got != want {
 			t.Errorf("rate reset is not equal\ngot: %v\nwant: %v\n", got, want)
 		}
+
+		if got, want := resp.RequestId, "trace"; got != want {
+			t.Errorf("request id is not equal\ngot: %v\nwant: %v\n", got, want)
+		}
+	})
+}
+
+type mockEventSub struct {
+	EventSubAPI
+	created []*EventSubSubscriptionOptions
+}
+
+func (m *mockEventSub) GetAllSubscriptions(ctx context.Context, opts *EventSubSubscriptionsOptions, max int) ([]*EventSubSubscription, *Response, error) {
+	return nil, nil, nil
+}
+
+func (m *mockEventSub) CreateSubscription(ctx context.Context, opts *EventSubSubscriptionOptions) (*EventSubSubscriptionsResponse, *Response, error) {
+	m.created = append(m.created, opts)
+	return &EventSubSubscriptionsResponse{
+		DataResponse: DataResponse[*EventSubSubscription]{Data: []*EventSubSubscription{{Type: opts.Type}}},
+	}, nil, nil
+}
+
+func TestServiceMocks(t *testing.T) {
+	t.Run("client must call mocked service", func(t *testing.T) {
+		c, err := NewClient(creds, httpClient)
+		assertNoError(t, err)
+
+		mock := new(mockEventSub)
+		c.EventSub = mock
+
+		r := NewEventSubReconciler(c, &EventSubTransport{Method: "conduit", ConduitId: "conduit"})
+		r.Add(EventSubChannelRaid, "1", &EventSubCondition{ToBroadcasterUserId: "141981764"})
+
+		result, err := r.Reconcile(context.Background())
+		assertNoError(t, err)
+
+		if len(mock.created) != 1 || len(result.Created) != 1 {
+			t.Errorf("expected 1 created subscription, got: %d", len(mock.created))
+		}
 	})
 }
+
+// benchTransport answers every request with body, without a network.
+type benchTransport []byte
+
+func (t benchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {applicationJSON}},
+		Body:       io.NopCloser(bytes.NewReader(t)),
+		Request:    req,
+	}, nil
+}
+
+func newBenchClient(b *testing.B, body string) *Client {
+	c, err := NewClient(creds, &http.Client{Transport: benchTransport(body)})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return c
+}
+
+// BenchmarkGetStreams polls streams the way bots watching channels do.
+func BenchmarkGetStreams(b *testing.B) {
+	var data []string
+	for i := 0; i < 20; i++ {
+		data = append(data, fmt.Sprintf(`{"id":"%d","user_id":"%d","user_login":"user%d","type":"live","title":"stream %d","viewer_count":%d,"started_at":"2024-03-01T12:00:00Z","tag_ids":[]}`, i, i, i, i, i*10))
+	}
+	c := newBenchClient(b, `{"data":[`+strings.Join(data, ",")+`],"pagination":{"cursor":"c"}}`)
+	ctx := context.Background()
+
+	b.Run("nil options", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := c.Streams.GetStreams(ctx, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("options", func(b *testing.B) {
+		opts := &StreamsOptions{First: 20, UserLogins: []string{"user1", "user2", "user3"}}
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := c.Streams.GetStreams(ctx, opts); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkNewRequest(b *testing.B) {
+	c := newBenchClient(b, `{}`)
+	body := &EventSubSubscriptionOptions{
+		Type:      EventSubChannelRaid,
+		Version:   "1",
+		Condition: EventSubCondition{ToBroadcasterUserId: "141981764"},
+		Transport: EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "AQoQexAWVYKSTIu4ec_2VAxyuhAB"},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.NewRequest(http.MethodPost, eventSubSubscriptionsPath, body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package twitch
+
+import (
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// Option overrides settings of a copy of the client made by With.
+type Option func(c *Client)
+
+// With returns a copy of the client with opts applied, e.g. to call Twitch
+// on behalf of another user in a multi-tenant service:
+//
+//	userClient := client.With(twitch.WithAccessToken(accessToken), twitch.WithBroadcasterId(userId))
+//
+// The copy is cheap: it shares the transport, rate limiter, circuit breaker,
+// metrics and the background work of c, Close of the copy does nothing.
+// Copies with another token don't share the cache of c and their tokens are
+// not validated periodically.
+func (c *Client) With(opts ...Option) *Client {
+	clone := *c
+	clone.Header = c.Header.Clone()
+	clone.middleware = append([]Middleware(nil), c.middleware...)
+	clone.flights = new(flightGroup)
+	clone.cancel = func() {}
+	clone.common.client = &clone
+	clone.Auth = (*AuthService)(&clone.common)
+	clone.EventSub = (*EventSubService)(&clone.common)
+	clone.Streams = (*StreamsService)(&clone.common)
+	clone.Users = (*UsersService)(&clone.common)
+
+	for _, opt := range opts {
+		opt(&clone)
+	}
+
+	return &clone
+}
+
+// WithAccessToken makes the copy send accessToken with every request,
+// it is never refreshed.
+func WithAccessToken(accessToken string) Option {
+	return func(c *Client) {
+		c.setToken(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken}))
+		c.tokenSource = nil
+
+		creds := *c.credentials
+		creds.OAuthToken = nil
+		creds.AccessToken = accessToken
+		c.credentials = &creds
+	}
+}
+
+// WithOAuthToken makes the copy send the user token, refreshing it when it
+// expires. Every new token is passed to onRefreshed, if set, instead of
+// the callbacks and the token store of the credentials of the client.
+func WithOAuthToken(token *oauth2.Token, onRefreshed func(token *oauth2.Token)) Option {
+	return func(c *Client) {
+		creds := *c.credentials
+		creds.OAuthToken = token
+		creds.TokenStore = nil
+		creds.UserId = ""
+		creds.OnTokenRefreshed = onRefreshed
+		creds.OnTokenInvalid = nil
+		c.credentials = &creds
+
+		var httpClient *http.Client
+		if c.tokenSource != nil {
+			httpClient = c.tokenSource.httpClient
+		}
+
+		source := &tokenSource{client: c, token: token, httpClient: httpClient}
+		c.setToken(source)
+		c.tokenSource = source
+	}
+}
+
+// WithBroadcasterId sets BroadcasterId of the copy.
+func WithBroadcasterId(id string) Option {
+	return func(c *Client) {
+		c.BroadcasterId = id
+	}
+}
+
+// WithHeaders adds header to Header of the copy,
+// replacing the values of the same keys.
+func WithHeaders(header http.Header) Option {
+	return func(c *Client) {
+		if c.Header == nil {
+			c.Header = make(http.Header)
+		}
+
+		for key, values := range header {
+			c.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+	}
+}
+
+// WithProxy makes the copy send its requests through proxyURL, or through
+// the proxy of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+// if it is nil. The copy gets its own connections to the proxy.
+//
+// Tokens shared with c are still fetched and refreshed by c. To send them
+// through the proxy too, create the client with a transport of NewTransport
+// with TransportOptions.Proxy.
+func WithProxy(proxyURL *url.URL) Option {
+	proxy := proxyFunc(proxyURL)
+
+	return func(c *Client) {
+		c.HTTPClient = withProxy(c.HTTPClient, proxy)
+		if c.appHTTPClient != nil {
+			c.appHTTPClient = withProxy(c.appHTTPClient, proxy)
+		}
+	}
+}
+
+// setToken replaces the user token sent by the client with source.
+func (c *Client) setToken(source oauth2.TokenSource) {
+	// A client without a user token sends the app token, which
+	// stays available for requests with WithAppToken.
+	if c.appHTTPClient == nil && c.tokenSource == nil && c.credentials.AccessToken == "" {
+		c.appHTTPClient = c.HTTPClient
+	}
+
+	c.HTTPClient = withTokenSource(baseHTTPClient(c.HTTPClient), source)
+	c.Cache = nil
+}
+
+// baseHTTPClient returns a copy of httpClient without the token it sends.
+func baseHTTPClient(httpClient *http.Client) *http.Client {
+	c := *httpClient
+	if t, ok := c.Transport.(*oauth2.Transport); ok {
+		c.Transport = t.Base
+	}
+
+	return &c
+}
+
+// broadcasterId returns id, or BroadcasterId if id is empty.
+func (c *Client) broadcasterId(id string) string {
+	if id == "" {
+		return c.BroadcasterId
+	}
+
+	return id
+}
@@ -0,0 +1,118 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestWith(t *testing.T) {
+	t.Run("copy must send overridden token, broadcaster id and headers", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/streams/key", func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Authorization"); got != "Bearer user-token" {
+				t.Errorf("bad Authorization: %q", got)
+			}
+			if got := r.Header.Get("X-Tenant"); got != "tenant" {
+				t.Errorf("bad X-Tenant: %q", got)
+			}
+			assertQuery(t, r, params{"broadcaster_id": "141981764"})
+			fmt.Fprint(w, `{"data":[{"stream_key":"live_key"}]}`)
+		})
+
+		userClient := c.With(
+			WithAccessToken("user-token"),
+			WithBroadcasterId("141981764"),
+			WithHeaders(http.Header{"x-tenant": {"tenant"}}),
+		)
+
+		key, _, err := userClient.Streams.GetStreamKey(context.Background(), nil)
+		assertNoError(t, err)
+		if key != "live_key" {
+			t.Errorf("bad key: %s", key)
+		}
+
+		if c.BroadcasterId != "" || c.Header != nil {
+			t.Error("client must not be changed by With")
+		}
+		if userClient.RateLimiter != c.RateLimiter {
+			t.Error("copy must share the rate limiter")
+		}
+
+		_, _, err = c.Streams.GetStreamKey(context.Background(), nil)
+		assertErrorMessage(t, err, broadcasterIdIsRequired)
+	})
+
+	t.Run("copy must refresh its own token", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc(authURLPath+"/token", func(w http.ResponseWriter, r *http.Request) {
+			if got := r.FormValue("refresh_token"); got != "refresh" {
+				t.Errorf("bad refresh_token: %q", got)
+			}
+			w.Header().Set("Content-Type", applicationJSON)
+			fmt.Fprint(w, `{"access_token":"new","refresh_token":"new-refresh","expires_in":3600}`)
+		})
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Authorization"); got != "Bearer new" {
+				t.Errorf("bad Authorization: %q", got)
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		var refreshed *oauth2.Token
+		token := &oauth2.Token{AccessToken: "old", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)}
+		userClient := c.With(WithOAuthToken(token, func(token *oauth2.Token) { refreshed = token }))
+
+		_, _, err := userClient.Streams.GetStreams(context.Background(), nil)
+		assertNoError(t, err)
+		if refreshed == nil || refreshed.RefreshToken != "new-refresh" {
+			t.Errorf("bad refreshed token: %+v", refreshed)
+		}
+	})
+
+	t.Run("copy must send requests through the proxy", func(t *testing.T) {
+		c, _, _, teardown := setup()
+		defer teardown()
+
+		var proxied []string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = append(proxied, r.URL.String())
+			fmt.Fprint(w, `{"data":[]}`)
+		}))
+		defer proxy.Close()
+
+		proxyURL, _ := url.Parse(proxy.URL)
+		c.BaseURL, _ = url.Parse("http://twitch.invalid/helix/")
+
+		_, _, err := c.With(WithProxy(proxyURL)).Streams.GetStreams(context.Background(), nil)
+		assertNoError(t, err)
+
+		if len(proxied) != 1 || proxied[0] != "http://twitch.invalid/helix/streams" {
+			t.Errorf("bad proxied requests: %v", proxied)
+		}
+
+		if _, _, err := c.Streams.GetStreams(context.Background(), nil); err == nil {
+			t.Error("client must not use the proxy of the copy")
+		}
+	})
+
+	t.Run("Close of the copy must not stop the client", func(t *testing.T) {
+		c, _, _, teardown := setup()
+		defer teardown()
+
+		c.With().Close()
+		if c.ctx.Err() != nil {
+			t.Error("client must not be closed")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+)
+
+// ConcurrencyLimiter limits the number of requests in flight, e.g. so
+// bursts of chat commands don't open hundreds of connections at once.
+// A request is in flight from its sending until its response body is
+// closed, requests beyond the limit wait for a slot.
+//
+// A ConcurrencyLimiter is safe for concurrent use and may be shared by
+// Clients, to limit their requests together:
+//
+//	client.ConcurrencyLimiter = twitch.NewConcurrencyLimiter(16)
+type ConcurrencyLimiter struct {
+	slots chan struct{}
+}
+
+// NewConcurrencyLimiter returns a limiter of n requests in flight,
+// at least 1.
+func NewConcurrencyLimiter(n int) *ConcurrencyLimiter {
+	if n < 1 {
+		n = 1
+	}
+
+	return &ConcurrencyLimiter{slots: make(chan struct{}, n)}
+}
+
+// InFlight returns the number of requests in flight.
+func (l *ConcurrencyLimiter) InFlight() int {
+	return len(l.slots)
+}
+
+// acquire waits for a slot and returns the function releasing it,
+// which may be called more than once.
+func (l *ConcurrencyLimiter) acquire(ctx context.Context) (func(), error) {
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.slots })
+	}, nil
+}
@@ -0,0 +1,76 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	t.Run("must limit requests in flight", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var (
+			mu                sync.Mutex
+			inFlight, maxSeen int
+		)
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxSeen {
+				maxSeen = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		c.ConcurrencyLimiter = NewConcurrencyLimiter(2)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, err := c.Streams.GetStreams(context.Background(), nil)
+				assertNoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		if maxSeen != 2 {
+			t.Errorf("expected 2 requests in flight at most, got %d", maxSeen)
+		}
+
+		if n := c.ConcurrencyLimiter.InFlight(); n != 0 {
+			t.Errorf("all slots must be released, %d are not", n)
+		}
+	})
+
+	t.Run("waiting request must be canceled with its context", func(t *testing.T) {
+		c, _, _, teardown := setup()
+		defer teardown()
+
+		c.ConcurrencyLimiter = NewConcurrencyLimiter(1)
+		release, err := c.ConcurrencyLimiter.acquire(context.Background())
+		assertNoError(t, err)
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		if _, _, err := c.Streams.GetStreams(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package twitch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+
+	"golang.org/x/oauth2"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvClientId      = "TWITCH_CLIENT_ID"
+	EnvClientSecret  = "TWITCH_CLIENT_SECRET"
+	EnvAccessToken   = "TWITCH_ACCESS_TOKEN"
+	EnvRefreshToken  = "TWITCH_REFRESH_TOKEN"
+	EnvBroadcasterId = "TWITCH_BROADCASTER_ID"
+	EnvUserId        = "TWITCH_USER_ID"
+)
+
+// Config is the configuration of a client read from the environment or a file.
+type Config struct {
+	ClientId     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	// AccessToken is the user access token. With RefreshToken, it is
+	// refreshed when it expires.
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// BroadcasterId is BroadcasterId of the client.
+	BroadcasterId string `json:"broadcaster_id,omitempty"`
+	UserId        string `json:"user_id,omitempty"`
+}
+
+// ErrorInvalidConfig is returned when a config file can't be parsed or
+// the config lacks a required key.
+type ErrorInvalidConfig struct {
+	// Source is the path of the file, or the environment.
+	Source  string
+	Message string
+}
+
+func (e *ErrorInvalidConfig) Error() string {
+	return fmt.Sprintf("Message: %s: %s", e.Source, e.Message)
+}
+
+// ConfigFromEnv returns the config of the TWITCH_* environment variables,
+// e.g. TWITCH_CLIENT_ID.
+func ConfigFromEnv() *Config {
+	return &Config{
+		ClientId:      os.Getenv(EnvClientId),
+		ClientSecret:  os.Getenv(EnvClientSecret),
+		AccessToken:   os.Getenv(EnvAccessToken),
+		RefreshToken:  os.Getenv(EnvRefreshToken),
+		BroadcasterId: os.Getenv(EnvBroadcasterId),
+		UserId:        os.Getenv(EnvUserId),
+	}
+}
+
+// LoadConfig reads the config file at path, a JSON object with the keys of
+// the json tags of Config:
+//
+//	{"client_id": "abc", "client_secret": "s3cret", "broadcaster_id": 123}
+//
+// Values are strings, ids may be numbers as well. Unknown keys are rejected.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		ClientId      string   `json:"client_id"`
+		ClientSecret  string   `json:"client_secret"`
+		AccessToken   string   `json:"access_token"`
+		RefreshToken  string   `json:"refresh_token"`
+		BroadcasterId configId `json:"broadcaster_id"`
+		UserId        configId `json:"user_id"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, &ErrorInvalidConfig{Source: path, Message: err.Error()}
+	}
+
+	cfg := &Config{
+		ClientId:      file.ClientId,
+		ClientSecret:  file.ClientSecret,
+		AccessToken:   file.AccessToken,
+		RefreshToken:  file.RefreshToken,
+		BroadcasterId: string(file.BroadcasterId),
+		UserId:        string(file.UserId),
+	}
+
+	if err := cfg.validate(path); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// configId is an id of a config file, a string or an integer.
+type configId string
+
+func (id *configId) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = configId(s)
+		return nil
+	}
+
+	var n uint64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("id must be a string or an integer, got: %s", data)
+	}
+	*id = configId(strconv.FormatUint(n, 10))
+
+	return nil
+}
+
+// validate returns ErrorInvalidConfig if the config can't make a client.
+func (cfg *Config) validate(source string) error {
+	if cfg.ClientId == "" {
+		return &ErrorInvalidConfig{Source: source, Message: "client_id is required"}
+	}
+
+	if cfg.ClientSecret == "" && cfg.AccessToken == "" && cfg.RefreshToken == "" {
+		return &ErrorInvalidConfig{Source: source, Message: "client_secret, access_token or refresh_token is required"}
+	}
+
+	return nil
+}
+
+// Credentials returns the credentials of the config. With a refresh token,
+// the user token is refreshed when it expires, otherwise the access token
+// is used as is.
+func (cfg *Config) Credentials() *Credentials {
+	creds := &Credentials{
+		ClientId:     cfg.ClientId,
+		ClientSecret: cfg.ClientSecret,
+		UserId:       cfg.UserId,
+	}
+
+	if cfg.RefreshToken != "" {
+		creds.OAuthToken = &oauth2.Token{AccessToken: cfg.AccessToken, RefreshToken: cfg.RefreshToken}
+	} else {
+		creds.AccessToken = cfg.AccessToken
+	}
+
+	return creds
+}
+
+// NewClientFromConfig returns a client with the credentials and
+// the broadcaster id of cfg.
+func NewClientFromConfig(cfg *Config, httpClient *http.Client) (*Client, error) {
+	if err := cfg.validate("config"); err != nil {
+		return nil, err
+	}
+
+	c, err := NewClient(cfg.Credentials(), httpClient)
+	if err != nil {
+		return nil, err
+	}
+	c.BroadcasterId = cfg.BroadcasterId
+
+	return c, nil
+}
+
+// NewClientFromEnv returns a client configured by the environment variables
+// of ConfigFromEnv, e.g. for a bot started with:
+//
+//	TWITCH_CLIENT_ID=abc TWITCH_CLIENT_SECRET=s3cret ./bot
+func NewClientFromEnv(httpClient *http.Client) (*Client, error) {
+	cfg := ConfigFromEnv()
+	if err := cfg.validate("environment"); err != nil {
+		return nil, err
+	}
+
+	return NewClientFromConfig(cfg, httpClient)
+}
+
+// NewClientFromFile returns a client configured by the config file at path,
+// see LoadConfig.
+func NewClientFromFile(path string, httpClient *http.Client) (*Client, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientFromConfig(cfg, httpClient)
+}
@@ -0,0 +1,85 @@
+package twitch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	want := &Config{ClientId: "id", ClientSecret: "s3cret#1", AccessToken: "t0ken", BroadcasterId: "123"}
+
+	files := map[string]string{
+		"bot.json":     `{"client_id":"id","client_secret":"s3cret#1","access_token":"t0ken","broadcaster_id":"123"}`,
+		"numeric.json": `{"client_id":"id","client_secret":"s3cret#1","access_token":"t0ken","broadcaster_id":123}`,
+		"bot.conf":     `{"client_id": "id", "client_secret": "s3cret#1", "access_token": "t0ken", "broadcaster_id": "123"}`,
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			assertNoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			cfg, err := LoadConfig(path)
+			assertNoError(t, err)
+
+			if !reflect.DeepEqual(cfg, want) {
+				t.Errorf("config is not equal\ngot: %+v\nwant: %+v", cfg, want)
+			}
+		})
+	}
+
+	invalid := map[string]string{
+		"unknown.json":  `{"client_id":"id","client_secret":"s","scope":"chat:read"}`,
+		"float.json":    `{"client_id":"id","client_secret":"s","broadcaster_id":1.5}`,
+		"number.json":   `{"client_id":1,"client_secret":"s"}`,
+		"yaml.yaml":     "client_id: id\nclient_secret: s\n",
+		"no_id.json":    `{"client_secret":"s"}`,
+		"no_token.json": `{"client_id":"id"}`,
+	}
+
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			assertNoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			var errConfig *ErrorInvalidConfig
+			if _, err := LoadConfig(path); !errors.As(err, &errConfig) || errConfig.Source != path {
+				t.Errorf("expected ErrorInvalidConfig, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Run("must read credentials and broadcaster id", func(t *testing.T) {
+		t.Setenv(EnvClientId, "id")
+		t.Setenv(EnvAccessToken, "t0ken")
+		t.Setenv(EnvRefreshToken, "r3fresh")
+		t.Setenv(EnvBroadcasterId, "123")
+
+		c, err := NewClientFromEnv(nil)
+		assertNoError(t, err)
+		defer c.Close()
+
+		if c.BroadcasterId != "123" || c.credentials.ClientId != "id" {
+			t.Errorf("bad client %+v", c)
+		}
+
+		if token := c.credentials.OAuthToken; token == nil || token.AccessToken != "t0ken" || token.RefreshToken != "r3fresh" {
+			t.Errorf("bad token %+v", token)
+		}
+	})
+
+	t.Run("must require client id", func(t *testing.T) {
+		t.Setenv(EnvClientId, "")
+		t.Setenv(EnvClientSecret, "s3cret")
+
+		_, err := NewClientFromEnv(nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "environment: client_id is required")
+	})
+}
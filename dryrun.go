@@ -0,0 +1,44 @@
+package twitch
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// mutating reports whether requests with method change the state of Twitch.
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// dryRun logs the request instead of sending it and answers it with
+// 204 No Content, credentials and stream keys of the body are redacted.
+func (c *Client) dryRun(req *http.Request) (*http.Response, error) {
+	if c.Logger != nil {
+		var body []byte
+		if req.GetBody != nil {
+			if r, err := req.GetBody(); err == nil {
+				body, _ = ioutil.ReadAll(r)
+				r.Close()
+			}
+		}
+
+		c.Logger.Log("twitch dry run", "method", req.Method, "url", req.URL.Redacted(), "body", redact(body, req.Header))
+	}
+
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/chat/announcements", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("%s request must not be sent", r.Method)
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	var logged []interface{}
+	c.Logger = LoggerFunc(func(msg string, keyvals ...interface{}) {
+		if msg == "twitch dry run" {
+			logged = keyvals
+		}
+	})
+	c.DryRun = true
+
+	t.Run("mutating request must be logged, not sent", func(t *testing.T) {
+		body := map[string]string{"message": "hi", "access_token": "s3cret"}
+		req, _ := c.NewRequest(http.MethodPost, "chat/announcements?broadcaster_id=1", body)
+
+		resp, err := c.Do(context.Background(), req, new(struct{}))
+		assertNoError(t, err)
+
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("got status %d", resp.StatusCode)
+		}
+
+		got := fmt.Sprint(logged...)
+		if !strings.Contains(got, "methodPOST") || !strings.Contains(got, "/chat/announcements?broadcaster_id=1") ||
+			!strings.Contains(got, `"message":"hi"`) || strings.Contains(got, "s3cret") {
+			t.Errorf("bad log: %v", logged)
+		}
+	})
+
+	t.Run("GET request must be sent", func(t *testing.T) {
+		req, _ := c.NewRequest(http.MethodGet, "chat/announcements", nil)
+
+		resp, err := c.Do(context.Background(), req, nil)
+		assertNoError(t, err)
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("got status %d", resp.StatusCode)
+		}
+	})
+}
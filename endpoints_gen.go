@@ -0,0 +1,97 @@
+// Code generated by internal/gen from endpoints.json. DO NOT EDIT.
+
+package twitch
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	createStreamMarkerPath = "streams/markers"
+	getUserBlockListPath   = "users/blocks"
+)
+
+// streamsGeneratedAPI holds the generated methods of StreamsAPI.
+type streamsGeneratedAPI interface {
+	CreateStreamMarker(ctx context.Context, opts *StreamMarkerOptions) (*StreamMarkersResponse, *Response, error)
+}
+
+// usersGeneratedAPI holds the generated methods of UsersAPI.
+type usersGeneratedAPI interface {
+	GetUserBlockList(ctx context.Context, opts *UserBlockListOptions) (*BlockedUsersResponse, *Response, error)
+}
+
+type StreamMarkerOptions struct {
+	UserId      string `json:"user_id,omitempty" validate:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+type StreamMarker struct {
+	Id              string    `json:"id,omitempty"`
+	CreatedAt       Timestamp `json:"created_at,omitempty,omitzero"`
+	PositionSeconds int       `json:"position_seconds,omitempty"`
+	Description     string    `json:"description,omitempty"`
+}
+
+type StreamMarkersResponse = DataResponse[*StreamMarker]
+
+// CreateStreamMarker marks the current position of the live stream of the user, it requires the channel:manage:broadcast scope.
+func (s *StreamsService) CreateStreamMarker(ctx context.Context, opts *StreamMarkerOptions) (*StreamMarkersResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, createStreamMarkerPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := new(StreamMarkersResponse)
+	resp, err := s.client.Do(ctx, req, data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return data, resp, nil
+}
+
+type UserBlockListOptions struct {
+	BroadcasterId string `url:"broadcaster_id,omitempty" validate:"required"`
+	First         int    `url:"first,omitempty" max:"100"`
+	After         string `url:"after,omitempty"`
+}
+
+type BlockedUser struct {
+	UserId      string `json:"user_id,omitempty"`
+	UserLogin   string `json:"user_login,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+type BlockedUsersResponse = DataResponse[*BlockedUser]
+
+// GetUserBlockList returns the users blocked by the broadcaster, it requires the user:read:blocked_users scope.
+//
+// BroadcasterId defaults to BroadcasterId of the client.
+func (s *UsersService) GetUserBlockList(ctx context.Context, opts *UserBlockListOptions) (*BlockedUsersResponse, *Response, error) {
+	o := new(UserBlockListOptions)
+	if opts != nil {
+		*o = *opts
+	}
+	o.BroadcasterId = s.client.broadcasterId(o.BroadcasterId)
+	opts = o
+
+	u, err := addParams(getUserBlockListPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := new(BlockedUsersResponse)
+	resp, err := s.client.Do(ctx, req, data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return data, resp, nil
+}
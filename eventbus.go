@@ -0,0 +1,234 @@
+package twitch
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	eventTypesMu sync.RWMutex
+	eventTypes   = map[string]reflect.Type{}
+)
+
+func init() {
+	RegisterEventType[ChannelAdBreakBeginEvent](EventSubChannelAdBreakBegin)
+
+	RegisterEventType[AutoModMessageHoldEvent](EventSubAutoModMessageHold)
+	RegisterEventType[AutoModMessageUpdateEvent](EventSubAutoModMessageUpdate)
+	RegisterEventType[AutoModSettingsUpdateEvent](EventSubAutoModSettingsUpdate)
+
+	RegisterEventType[ChannelChatMessageEvent](EventSubChannelChatMessage)
+	RegisterEventType[ChannelChatNotificationEvent](EventSubChannelChatNotification)
+	RegisterEventType[ChannelChatMessageDeleteEvent](EventSubChannelChatMessageDelete)
+	RegisterEventType[ChannelChatClearEvent](EventSubChannelChatClear)
+	RegisterEventType[ChannelChatClearUserMessagesEvent](EventSubChannelChatClearUserMessages)
+	RegisterEventType[ChannelChatSettingsUpdateEvent](EventSubChannelChatSettingsUpdate)
+
+	RegisterEventType[ConduitShardDisabledEvent](EventSubConduitShardDisabled)
+
+	RegisterEventType[DropEntitlementGrantEvents](EventSubDropEntitlementGrant)
+	RegisterEventType[ExtensionBitsTransactionCreateEvent](EventSubExtensionBitsTransactionCreate)
+
+	RegisterEventType[ChannelGoalBeginEvent](EventSubChannelGoalBegin)
+	RegisterEventType[ChannelGoalProgressEvent](EventSubChannelGoalProgress)
+	RegisterEventType[ChannelGoalEndEvent](EventSubChannelGoalEnd)
+	RegisterEventType[ChannelCharityDonationEvent](EventSubChannelCharityCampaignDonate)
+	RegisterEventType[ChannelCharityCampaignEvent](
+		EventSubChannelCharityCampaignStart,
+		EventSubChannelCharityCampaignProgress,
+		EventSubChannelCharityCampaignStop,
+	)
+
+	RegisterEventType[ChannelGuestStarSessionBeginEvent](EventSubChannelGuestStarSessionBegin)
+	RegisterEventType[ChannelGuestStarSessionEndEvent](EventSubChannelGuestStarSessionEnd)
+	RegisterEventType[ChannelGuestStarGuestUpdateEvent](EventSubChannelGuestStarGuestUpdate)
+	RegisterEventType[ChannelGuestStarSlotUpdateEvent](EventSubChannelGuestStarSlotUpdate)
+	RegisterEventType[ChannelGuestStarSettingsUpdateEvent](EventSubChannelGuestStarSettingsUpdate)
+
+	RegisterEventType[ChannelHypeTrainBeginEvent](EventSubChannelHypeTrainBegin)
+	RegisterEventType[ChannelHypeTrainProgressEvent](EventSubChannelHypeTrainProgress)
+	RegisterEventType[ChannelHypeTrainEndEvent](EventSubChannelHypeTrainEnd)
+
+	RegisterEventType[ChannelBanEvent](EventSubChannelBan)
+	RegisterEventType[ChannelUnbanEvent](EventSubChannelUnban)
+	RegisterEventType[ChannelModerateEvent](EventSubChannelModerate)
+	RegisterEventType[ChannelShieldModeBeginEvent](EventSubChannelShieldModeBegin)
+	RegisterEventType[ChannelShieldModeEndEvent](EventSubChannelShieldModeEnd)
+	RegisterEventType[ChannelWarningSendEvent](EventSubChannelWarningSend)
+	RegisterEventType[ChannelWarningAcknowledgeEvent](EventSubChannelWarningAcknowledge)
+	RegisterEventType[ChannelSuspiciousUserMessageEvent](EventSubChannelSuspiciousUserMessage)
+	RegisterEventType[ChannelSuspiciousUserUpdateEvent](EventSubChannelSuspiciousUserUpdate)
+	RegisterEventType[ChannelUnbanRequestCreateEvent](EventSubChannelUnbanRequestCreate)
+	RegisterEventType[ChannelUnbanRequestResolveEvent](EventSubChannelUnbanRequestResolve)
+
+	RegisterEventType[ChannelPollBeginEvent](EventSubChannelPollBegin)
+	RegisterEventType[ChannelPollProgressEvent](EventSubChannelPollProgress)
+	RegisterEventType[ChannelPollEndEvent](EventSubChannelPollEnd)
+	RegisterEventType[ChannelPredictionBeginEvent](EventSubChannelPredictionBegin)
+	RegisterEventType[ChannelPredictionProgressEvent](EventSubChannelPredictionProgress)
+	RegisterEventType[ChannelPredictionLockEvent](EventSubChannelPredictionLock)
+	RegisterEventType[ChannelPredictionEndEvent](EventSubChannelPredictionEnd)
+
+	RegisterEventType[ChannelRaidEvent](EventSubChannelRaid)
+	RegisterEventType[ChannelRoleEvent](
+		EventSubChannelModeratorAdd,
+		EventSubChannelModeratorRemove,
+		EventSubChannelVipAdd,
+		EventSubChannelVipRemove,
+	)
+
+	RegisterEventType[ChannelShoutoutCreateEvent](EventSubChannelShoutoutCreate)
+	RegisterEventType[ChannelShoutoutReceiveEvent](EventSubChannelShoutoutReceive)
+
+	RegisterEventType[UserWhisperMessageEvent](EventSubUserWhisperMessage)
+	RegisterEventType[UserAuthorizationEvent](EventSubUserAuthorizationGrant, EventSubUserAuthorizationRevoke)
+	RegisterEventType[UserUpdateEvent](EventSubUserUpdate)
+}
+
+// RegisterEventType tells the event bus that notifications of the subscription
+// types are decoded into T. Every type of this package is registered already,
+// it is only needed for types the package doesn't know yet.
+func RegisterEventType[T any](subscriptionTypes ...string) {
+	eventTypesMu.Lock()
+	defer eventTypesMu.Unlock()
+
+	for _, typ := range subscriptionTypes {
+		eventTypes[typ] = reflect.TypeOf((*T)(nil)).Elem()
+	}
+}
+
+// eventTypeOf returns the event type of the subscription type, or nil
+// if none is registered.
+func eventTypeOf(subscriptionType string) reflect.Type {
+	eventTypesMu.RLock()
+	defer eventTypesMu.RUnlock()
+
+	return eventTypes[subscriptionType]
+}
+
+type eventBusHandler struct {
+	typ reflect.Type
+	// subscriptionType is empty for handlers registered with On, they
+	// receive every subscription type decoded into typ and events
+	// passed to Emit.
+	subscriptionType string
+	call             func(event interface{})
+}
+
+// handles reports whether the handler receives notifications of the
+// subscription type, which are decoded into eventType.
+func (h *eventBusHandler) handles(subscriptionType string, eventType reflect.Type) bool {
+	if h.subscriptionType != "" {
+		return h.subscriptionType == subscriptionType
+	}
+
+	return h.typ == eventType
+}
+
+// EventBus delivers notifications to handlers registered per Go event type
+// with On and OnType. Every notification is decoded once for every event type
+// that has handlers, and all of them receive the same value.
+//
+// Publish can be used as OnNotification of any EventSub transport.
+type EventBus struct {
+	// OnError is called when a notification can't be decoded.
+	OnError func(notification *EventSubNotification, err error)
+
+	mu       sync.RWMutex
+	handlers []*eventBusHandler
+}
+
+func NewEventBus() *EventBus {
+	return new(EventBus)
+}
+
+// On registers handler for every subscription type decoded into T and for
+// events of type T passed to Emit. It returns a function removing the handler.
+// Subscription types are looked up when notifications are published, so T
+// may be registered with RegisterEventType later or be used only with Emit.
+func On[T any](bus *EventBus, handler func(event *T)) (remove func()) {
+	return bus.add(&eventBusHandler{
+		typ:  reflect.TypeOf((*T)(nil)).Elem(),
+		call: func(event interface{}) { handler(event.(*T)) },
+	})
+}
+
+// OnType registers handler only for notifications of the subscription type,
+// e.g. to tell channel.vip.add from channel.vip.remove, which share an event type.
+func OnType[T any](bus *EventBus, subscriptionType string, handler func(event *T)) (remove func()) {
+	return bus.add(&eventBusHandler{
+		typ:              reflect.TypeOf((*T)(nil)).Elem(),
+		subscriptionType: subscriptionType,
+		call:             func(event interface{}) { handler(event.(*T)) },
+	})
+}
+
+// Emit delivers an already decoded event to handlers registered with On for T,
+// it is meant for producers other than EventSub.
+func Emit[T any](bus *EventBus, event *T) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	for _, h := range bus.snapshot() {
+		if h.typ == typ && h.subscriptionType == "" {
+			h.call(event)
+		}
+	}
+}
+
+func (b *EventBus) add(h *eventBusHandler) func() {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, h)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for i, handler := range b.handlers {
+			if handler == h {
+				b.handlers = append(b.handlers[:i:i], b.handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (b *EventBus) snapshot() []*eventBusHandler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.handlers
+}
+
+// Publish decodes the notification and delivers it to the handlers of its subscription type.
+func (b *EventBus) Publish(notification *EventSubNotification) {
+	if notification.Subscription == nil {
+		return
+	}
+
+	subscriptionType := notification.Subscription.Type
+	eventType := eventTypeOf(subscriptionType)
+	events := make(map[reflect.Type]interface{})
+
+	for _, h := range b.snapshot() {
+		if !h.handles(subscriptionType, eventType) {
+			continue
+		}
+
+		event, ok := events[h.typ]
+		if !ok {
+			event = reflect.New(h.typ).Interface()
+			if err := notification.Decode(event); err != nil {
+				if b.OnError != nil {
+					b.OnError(notification, err)
+				}
+				event = nil
+			}
+			events[h.typ] = event
+		}
+
+		if event != nil {
+			h.call(event)
+		}
+	}
+}
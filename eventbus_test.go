@@ -0,0 +1,118 @@
+package twitch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func raidNotification(viewers string) *EventSubNotification {
+	return &EventSubNotification{
+		Subscription: &EventSubSubscription{Type: EventSubChannelRaid},
+		Event:        json.RawMessage(`{"viewers":` + viewers + `}`),
+	}
+}
+
+func TestEventBus(t *testing.T) {
+	t.Run("must decode once and deliver typed events", func(t *testing.T) {
+		bus := NewEventBus()
+
+		var got []*ChannelRaidEvent
+		On(bus, func(event *ChannelRaidEvent) { got = append(got, event) })
+		On(bus, func(event *ChannelRaidEvent) { got = append(got, event) })
+		On(bus, func(event *ChannelBanEvent) { t.Error("unexpected ban event") })
+
+		bus.Publish(raidNotification("9001"))
+
+		if len(got) != 2 || got[0] != got[1] || got[0].Viewers != 9001 {
+			t.Errorf("bad delivered events: %v", got)
+		}
+	})
+
+	t.Run("must deliver shared event types by subscription type", func(t *testing.T) {
+		bus := NewEventBus()
+
+		all, vipAdd := 0, 0
+		On(bus, func(event *ChannelRoleEvent) { all++ })
+		OnType(bus, EventSubChannelVipAdd, func(event *ChannelRoleEvent) { vipAdd++ })
+
+		for _, typ := range []string{EventSubChannelVipAdd, EventSubChannelVipRemove} {
+			bus.Publish(&EventSubNotification{
+				Subscription: &EventSubSubscription{Type: typ},
+				Event:        json.RawMessage(`{"user_id":"1"}`),
+			})
+		}
+
+		if all != 2 || vipAdd != 1 {
+			t.Errorf("bad calls: %d, %d", all, vipAdd)
+		}
+	})
+
+	t.Run("removed handlers must not be called", func(t *testing.T) {
+		bus := NewEventBus()
+
+		calls := 0
+		remove := On(bus, func(event *ChannelRaidEvent) { calls++ })
+		bus.Publish(raidNotification("1"))
+		remove()
+		bus.Publish(raidNotification("1"))
+
+		if calls != 1 {
+			t.Errorf("expected one call, got: %d", calls)
+		}
+	})
+
+	t.Run("must report decoding errors", func(t *testing.T) {
+		bus := NewEventBus()
+
+		var errs []error
+		bus.OnError = func(n *EventSubNotification, err error) { errs = append(errs, err) }
+		On(bus, func(event *ChannelRaidEvent) { t.Error("handler must not be called") })
+		On(bus, func(event *ChannelRaidEvent) { t.Error("handler must not be called") })
+
+		bus.Publish(raidNotification(`"many"`))
+
+		if len(errs) != 1 {
+			t.Errorf("expected one error, got: %v", errs)
+		}
+	})
+
+	t.Run("emit must deliver to handlers of the type", func(t *testing.T) {
+		bus := NewEventBus()
+
+		var got *ChannelRaidEvent
+		On(bus, func(event *ChannelRaidEvent) { got = event })
+		OnType(bus, EventSubChannelRaid, func(event *ChannelRaidEvent) { t.Error("OnType handler must not be called") })
+
+		want := &ChannelRaidEvent{Viewers: 1}
+		Emit(bus, want)
+
+		if got != want {
+			t.Errorf("\ngot: %v\nwant: %v", got, want)
+		}
+	})
+
+	t.Run("must deliver types registered after the handler", func(t *testing.T) {
+		type laterEvent struct {
+			Name string `json:"name"`
+		}
+
+		bus := NewEventBus()
+
+		var got []string
+		On(bus, func(event *laterEvent) { got = append(got, event.Name) })
+
+		notification := &EventSubNotification{
+			Subscription: &EventSubSubscription{Type: "test.later"},
+			Event:        json.RawMessage(`{"name":"published"}`),
+		}
+		bus.Publish(notification)
+		Emit(bus, &laterEvent{Name: "emitted"})
+
+		RegisterEventType[laterEvent]("test.later")
+		bus.Publish(notification)
+
+		if len(got) != 2 || got[0] != "emitted" || got[1] != "published" {
+			t.Errorf("bad delivered events: %v", got)
+		}
+	})
+}
@@ -0,0 +1,320 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	eventSubSubscriptionsPath = "eventsub/subscriptions"
+
+	EventSubTransportWebhook   = "webhook"
+	EventSubTransportWebSocket = "websocket"
+	EventSubTransportConduit   = "conduit"
+
+	EventSubStatusEnabled                            = "enabled"
+	EventSubStatusWebhookCallbackVerificationPending = "webhook_callback_verification_pending"
+	EventSubStatusWebhookCallbackVerificationFailed  = "webhook_callback_verification_failed"
+	EventSubStatusNotificationFailuresExceeded       = "notification_failures_exceeded"
+	EventSubStatusAuthorizationRevoked               = "authorization_revoked"
+	EventSubStatusModeratorRemoved                   = "moderator_removed"
+	EventSubStatusUserRemoved                        = "user_removed"
+	EventSubStatusVersionRemoved                     = "version_removed"
+	EventSubStatusWebSocketDisconnected              = "websocket_disconnected"
+	EventSubStatusWebSocketFailedPingPong            = "websocket_failed_ping_pong"
+	EventSubStatusWebSocketReceivedInboundTraffic    = "websocket_received_inbound_traffic"
+	EventSubStatusWebSocketConnectionUnused          = "websocket_connection_unused"
+	EventSubStatusWebSocketInternalError             = "websocket_internal_error"
+	EventSubStatusWebSocketNetworkTimeout            = "websocket_network_timeout"
+	EventSubStatusWebSocketNetworkError              = "websocket_network_error"
+
+	broadcasterUserIdIsRequired = "broadcaster_user_id is required"
+	eventSubStatusIsRequired    = "status is required"
+	sessionIdIsRequired         = "session_id is required"
+)
+
+type EventSubService service
+
+// EventSubAPI is implemented by EventSubService, so calls can be mocked in tests.
+type EventSubAPI interface {
+	Budget() *EventSubBudget
+	CreateSubscription(ctx context.Context, opts *EventSubSubscriptionOptions) (*EventSubSubscriptionsResponse, *Response, error)
+	GetSubscriptions(ctx context.Context, opts *EventSubSubscriptionsOptions) (*EventSubSubscriptionsResponse, *Response, error)
+	DeleteSubscription(ctx context.Context, opts *EventSubSubscriptionId) (*Response, error)
+	DeleteAllWithStatus(ctx context.Context, status string) ([]*EventSubSubscription, *Response, error)
+	DeleteAllForSession(ctx context.Context, sessionId string) ([]*EventSubSubscription, *Response, error)
+	GetSubscriptionsByStatus(ctx context.Context, opts *EventSubSubscriptionsOptions) (map[string][]*EventSubSubscription, *Response, error)
+	SubscribeChannelAdBreakBegin(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscribeChannelChatSettingsUpdate(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	GetConduits(ctx context.Context) ([]*Conduit, *Response, error)
+	CreateConduit(ctx context.Context, opts *ConduitOptions) (*Conduit, *Response, error)
+	UpdateConduit(ctx context.Context, opts *ConduitOptions) (*Conduit, *Response, error)
+	DeleteConduit(ctx context.Context, opts *ConduitId) (*Response, error)
+	GetConduitShards(ctx context.Context, opts *ConduitShardsOptions) (*ConduitShardsResponse, *Response, error)
+	UpdateConduitShards(ctx context.Context, opts *UpdateConduitShardsOptions) (*ConduitShardsResponse, *Response, error)
+	SubscribeConduitShardDisabled(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscribeChannelRaid(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscribeChannelModeratorAdd(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscribeChannelModeratorRemove(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscribeChannelVipAdd(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscribeChannelVipRemove(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscribeUserWhisperMessage(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error)
+	SubscriptionPages(ctx context.Context, opts *EventSubSubscriptionsOptions) *Pages[*EventSubSubscription]
+	ConduitShardPages(ctx context.Context, opts *ConduitShardsOptions) *Pages[*ConduitShard]
+	GetAllSubscriptions(ctx context.Context, opts *EventSubSubscriptionsOptions, max int) ([]*EventSubSubscription, *Response, error)
+	GetAllConduitShards(ctx context.Context, opts *ConduitShardsOptions, max int) ([]*ConduitShard, *Response, error)
+}
+
+var _ EventSubAPI = (*EventSubService)(nil)
+
+// EventSubCondition holds every condition key used by the EventSub
+// subscription types. Only the keys required by a particular type should be set.
+type EventSubCondition struct {
+	BroadcasterUserId     string `json:"broadcaster_user_id,omitempty"`
+	FromBroadcasterUserId string `json:"from_broadcaster_user_id,omitempty"`
+	ToBroadcasterUserId   string `json:"to_broadcaster_user_id,omitempty"`
+	ModeratorUserId       string `json:"moderator_user_id,omitempty"`
+	UserId                string `json:"user_id,omitempty"`
+	RewardId              string `json:"reward_id,omitempty"`
+	ClientId              string `json:"client_id,omitempty"`
+	ExtensionClientId     string `json:"extension_client_id,omitempty"`
+	OrganizationId        string `json:"organization_id,omitempty"`
+	CategoryId            string `json:"category_id,omitempty"`
+	CampaignId            string `json:"campaign_id,omitempty"`
+	ConduitId             string `json:"conduit_id,omitempty"`
+}
+
+type EventSubTransport struct {
+	Method         string     `json:"method,omitempty" validate:"required"`
+	Callback       string     `json:"callback,omitempty"`
+	Secret         string     `json:"secret,omitempty"`
+	SessionId      string     `json:"session_id,omitempty"`
+	ConduitId      string     `json:"conduit_id,omitempty"`
+	ConnectedAt    *Timestamp `json:"connected_at,omitempty"`
+	DisconnectedAt *Timestamp `json:"disconnected_at,omitempty"`
+}
+
+type EventSubSubscription struct {
+	Id        string            `json:"id,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Type      string            `json:"type,omitempty"`
+	Version   string            `json:"version,omitempty"`
+	Condition EventSubCondition `json:"condition,omitempty"`
+	Transport EventSubTransport `json:"transport,omitempty"`
+	CreatedAt Timestamp         `json:"created_at,omitempty,omitzero"`
+	Cost      int               `json:"cost,omitempty"`
+}
+
+type EventSubSubscriptionOptions struct {
+	Type      string            `json:"type,omitempty" validate:"required"`
+	Version   string            `json:"version,omitempty" validate:"required"`
+	Condition EventSubCondition `json:"condition,omitempty"`
+	Transport EventSubTransport `json:"transport,omitempty"`
+}
+
+type EventSubSubscriptionsResponse struct {
+	DataResponse[*EventSubSubscription]
+	TotalCost    int `json:"total_cost,omitempty"`
+	MaxTotalCost int `json:"max_total_cost,omitempty"`
+}
+
+type EventSubSubscriptionsOptions struct {
+	Status         string `url:"status,omitempty"`
+	Type           string `url:"type,omitempty"`
+	UserId         string `url:"user_id,omitempty"`
+	SubscriptionId string `url:"subscription_id,omitempty"`
+	After          string `url:"after,omitempty"`
+}
+
+// Budget returns the subscription cost budget, it is updated
+// from every response of the subscriptions endpoints.
+func (s *EventSubService) Budget() *EventSubBudget {
+	return s.client.eventSubBudget
+}
+
+func (s *EventSubService) CreateSubscription(ctx context.Context, opts *EventSubSubscriptionOptions) (*EventSubSubscriptionsResponse, *Response, error) {
+	if err := validateOptions(opts); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.Budget().check(eventSubCost(opts)); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, eventSubSubscriptionsPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Webhook and conduit subscriptions require an app access token.
+	if opts.Transport.Method != EventSubTransportWebSocket {
+		ctx = preferAppToken(ctx)
+	}
+
+	return s.doSubscriptions(ctx, req)
+}
+
+func (s *EventSubService) GetSubscriptions(ctx context.Context, opts *EventSubSubscriptionsOptions) (*EventSubSubscriptionsResponse, *Response, error) {
+	u, err := addParams(eventSubSubscriptionsPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.doSubscriptions(ctx, req)
+}
+
+type EventSubSubscriptionId struct {
+	Id string `url:"id,omitempty" validate:"required"`
+}
+
+func (s *EventSubService) DeleteSubscription(ctx context.Context, opts *EventSubSubscriptionId) (*Response, error) {
+	u, err := addParams(eventSubSubscriptionsPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// DeleteAllWithStatus deletes every subscription with the given status,
+// e.g. webhook_callback_verification_failed ones that still count against the cost budget.
+func (s *EventSubService) DeleteAllWithStatus(ctx context.Context, status string) ([]*EventSubSubscription, *Response, error) {
+	if status == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: status, Message: eventSubStatusIsRequired}
+	}
+
+	subs, resp, err := s.GetAllSubscriptions(ctx, &EventSubSubscriptionsOptions{Status: status}, 0)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return s.deleteAll(ctx, subs, resp)
+}
+
+// DeleteAllForSession deletes every subscription bound to the given WebSocket session.
+func (s *EventSubService) DeleteAllForSession(ctx context.Context, sessionId string) ([]*EventSubSubscription, *Response, error) {
+	if sessionId == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: sessionId, Message: sessionIdIsRequired}
+	}
+
+	subs, resp, err := s.GetAllSubscriptions(ctx, nil, 0)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var session []*EventSubSubscription
+	for _, sub := range subs {
+		if sub.Transport.Method == EventSubTransportWebSocket && sub.Transport.SessionId == sessionId {
+			session = append(session, sub)
+		}
+	}
+
+	return s.deleteAll(ctx, session, resp)
+}
+
+func (s *EventSubService) deleteAll(ctx context.Context, subs []*EventSubSubscription, resp *Response) ([]*EventSubSubscription, *Response, error) {
+	deleted := make([]*EventSubSubscription, 0, len(subs))
+	for _, sub := range subs {
+		r, err := s.DeleteSubscription(ctx, &EventSubSubscriptionId{sub.Id})
+		if err != nil {
+			return deleted, r, err
+		}
+
+		deleted = append(deleted, sub)
+		resp = r
+	}
+
+	return deleted, resp, nil
+}
+
+// GetSubscriptionsByStatus pages through all subscriptions matching opts
+// and groups them by status.
+func (s *EventSubService) GetSubscriptionsByStatus(ctx context.Context, opts *EventSubSubscriptionsOptions) (map[string][]*EventSubSubscription, *Response, error) {
+	subs, resp, err := s.GetAllSubscriptions(ctx, opts, 0)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	grouped := make(map[string][]*EventSubSubscription)
+	for _, sub := range subs {
+		grouped[sub.Status] = append(grouped[sub.Status], sub)
+	}
+
+	return grouped, resp, nil
+}
+
+func (s *EventSubService) doSubscriptions(ctx context.Context, req *http.Request) (*EventSubSubscriptionsResponse, *Response, error) {
+	subs := new(EventSubSubscriptionsResponse)
+	resp, err := s.client.Do(ctx, req, subs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	s.Budget().update(subs.TotalCost, subs.MaxTotalCost)
+
+	return subs, resp, nil
+}
+
+func (s *EventSubService) subscribe(ctx context.Context, typ, version string, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	opts := &EventSubSubscriptionOptions{Type: typ, Version: version}
+	if condition != nil {
+		opts.Condition = *condition
+	}
+
+	if transport != nil {
+		opts.Transport = *transport
+	}
+
+	return s.CreateSubscription(ctx, opts)
+}
+
+func (s *EventSubService) subscribeBroadcaster(ctx context.Context, typ string, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	condition = s.withBroadcaster(condition)
+	if condition.BroadcasterUserId == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: condition, Message: broadcasterUserIdIsRequired}
+	}
+
+	return s.subscribe(ctx, typ, "1", condition, transport)
+}
+
+// withBroadcaster returns a copy of condition with BroadcasterUserId
+// defaulting to BroadcasterId of the client.
+func (s *EventSubService) withBroadcaster(condition *EventSubCondition) *EventSubCondition {
+	c := new(EventSubCondition)
+	if condition != nil {
+		*c = *condition
+	}
+	c.BroadcasterUserId = s.client.broadcasterId(c.BroadcasterUserId)
+
+	return c
+}
+
+// EventSubNotification is a notification received over any EventSub
+// transport. Event holds the raw event, use Decode to get a typed one.
+type EventSubNotification struct {
+	MessageId    string
+	Timestamp    Timestamp
+	Subscription *EventSubSubscription
+	Event        json.RawMessage
+	// Events is set instead of Event for batched types like drop.entitlement.grant.
+	Events json.RawMessage
+}
+
+func (n *EventSubNotification) Decode(v interface{}) error {
+	if n.Events != nil {
+		return json.Unmarshal(n.Events, v)
+	}
+
+	return json.Unmarshal(n.Event, v)
+}
@@ -0,0 +1,29 @@
+package twitch
+
+import (
+	"context"
+	"time"
+)
+
+const EventSubChannelAdBreakBegin = "channel.ad_break.begin"
+
+type ChannelAdBreakBeginEvent struct {
+	DurationSeconds      int       `json:"duration_seconds,omitempty"`
+	StartedAt            Timestamp `json:"started_at,omitempty,omitzero"`
+	IsAutomatic          bool      `json:"is_automatic,omitempty"`
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	RequesterUserId      string    `json:"requester_user_id,omitempty"`
+	RequesterUserLogin   string    `json:"requester_user_login,omitempty"`
+	RequesterUserName    string    `json:"requester_user_name,omitempty"`
+}
+
+// EndsAt returns the expected end of the ad break.
+func (e *ChannelAdBreakBeginEvent) EndsAt() time.Time {
+	return e.StartedAt.Add(time.Duration(e.DurationSeconds) * time.Second)
+}
+
+func (s *EventSubService) SubscribeChannelAdBreakBegin(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	return s.subscribeBroadcaster(ctx, EventSubChannelAdBreakBegin, condition, transport)
+}
@@ -0,0 +1,71 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestChannelAdBreakBeginEvent(t *testing.T) {
+	data := `{
+		"duration_seconds": 60,
+		"started_at": ` + referenceTimeStr + `,
+		"is_automatic": false,
+		"broadcaster_user_id": "1337",
+		"requester_user_id": "1337",
+		"requester_user_login": "cool_user",
+		"requester_user_name": "Cool_User"
+	}`
+
+	want := &ChannelAdBreakBeginEvent{
+		DurationSeconds:    60,
+		StartedAt:          Timestamp{referenceTime},
+		BroadcasterUserId:  "1337",
+		RequesterUserId:    "1337",
+		RequesterUserLogin: "cool_user",
+		RequesterUserName:  "Cool_User",
+	}
+
+	event := new(ChannelAdBreakBeginEvent)
+	assertJSONUnmarshal(t, data, event, want)
+
+	if got, want := event.EndsAt(), referenceTime.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("wrong end time\ngot: %v\nwant: %v", got, want)
+	}
+}
+
+func TestSubscribeChannelAdBreakBegin(t *testing.T) {
+	t.Run("tests subscription type to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(EventSubSubscriptionOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+
+			if opts.Type != EventSubChannelAdBreakBegin || opts.Condition.BroadcasterUserId != "1337" {
+				t.Errorf("bad subscription options: %+v", opts)
+			}
+
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		ctx := context.Background()
+		_, _, err := c.EventSub.SubscribeChannelAdBreakBegin(ctx,
+			&EventSubCondition{BroadcasterUserId: "1337"},
+			&EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "1"},
+		)
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error, when broadcaster_user_id is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+		_, _, err := client.EventSub.SubscribeChannelAdBreakBegin(ctx, nil, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, broadcasterUserIdIsRequired)
+	})
+}
@@ -0,0 +1,94 @@
+package twitch
+
+const (
+	EventSubAutoModMessageHold    = "automod.message.hold"
+	EventSubAutoModMessageUpdate  = "automod.message.update"
+	EventSubAutoModSettingsUpdate = "automod.settings.update"
+
+	AutoModMessageStatusApproved = "Approved"
+	AutoModMessageStatusDenied   = "Denied"
+	AutoModMessageStatusExpired  = "Expired"
+)
+
+type AutoModBoundary struct {
+	StartPos int `json:"start_pos,omitempty"`
+	EndPos   int `json:"end_pos,omitempty"`
+}
+
+type AutoModDetails struct {
+	Category   string             `json:"category,omitempty"`
+	Level      int                `json:"level,omitempty"`
+	Boundaries []*AutoModBoundary `json:"boundaries,omitempty"`
+}
+
+type AutoModBlockedTerm struct {
+	TermId                    string          `json:"term_id,omitempty"`
+	Boundary                  AutoModBoundary `json:"boundary,omitempty"`
+	OwnerBroadcasterUserId    string          `json:"owner_broadcaster_user_id,omitempty"`
+	OwnerBroadcasterUserLogin string          `json:"owner_broadcaster_user_login,omitempty"`
+	OwnerBroadcasterUserName  string          `json:"owner_broadcaster_user_name,omitempty"`
+}
+
+type AutoModBlockedTerms struct {
+	TermsFound []*AutoModBlockedTerm `json:"terms_found,omitempty"`
+}
+
+// AutoModMessageHoldEvent covers both versions of the event: version 1 sets
+// Level and Category, version 2 sets Reason and AutoMod or BlockedTerm.
+type AutoModMessageHoldEvent struct {
+	BroadcasterUserId    string               `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string               `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string               `json:"broadcaster_user_name,omitempty"`
+	UserId               string               `json:"user_id,omitempty"`
+	UserLogin            string               `json:"user_login,omitempty"`
+	Username             string               `json:"user_name,omitempty"`
+	MessageId            string               `json:"message_id,omitempty"`
+	Message              ChatMessage          `json:"message,omitempty"`
+	Level                int                  `json:"level,omitempty"`
+	Category             string               `json:"category,omitempty"`
+	Reason               string               `json:"reason,omitempty"`
+	AutoMod              *AutoModDetails      `json:"automod,omitempty"`
+	BlockedTerm          *AutoModBlockedTerms `json:"blocked_term,omitempty"`
+	HeldAt               Timestamp            `json:"held_at,omitempty,omitzero"`
+}
+
+type AutoModMessageUpdateEvent struct {
+	BroadcasterUserId    string               `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string               `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string               `json:"broadcaster_user_name,omitempty"`
+	UserId               string               `json:"user_id,omitempty"`
+	UserLogin            string               `json:"user_login,omitempty"`
+	Username             string               `json:"user_name,omitempty"`
+	ModeratorUserId      string               `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string               `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string               `json:"moderator_user_name,omitempty"`
+	MessageId            string               `json:"message_id,omitempty"`
+	Message              ChatMessage          `json:"message,omitempty"`
+	Level                int                  `json:"level,omitempty"`
+	Category             string               `json:"category,omitempty"`
+	Reason               string               `json:"reason,omitempty"`
+	AutoMod              *AutoModDetails      `json:"automod,omitempty"`
+	BlockedTerm          *AutoModBlockedTerms `json:"blocked_term,omitempty"`
+	Status               string               `json:"status,omitempty"`
+	HeldAt               Timestamp            `json:"held_at,omitempty,omitzero"`
+}
+
+// AutoModSettingsUpdateEvent has OverallLevel set only when the broadcaster
+// uses the overall level instead of individual settings.
+type AutoModSettingsUpdateEvent struct {
+	BroadcasterUserId       string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin    string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName     string `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId         string `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin      string `json:"moderator_user_login,omitempty"`
+	ModeratorUserName       string `json:"moderator_user_name,omitempty"`
+	OverallLevel            *int   `json:"overall_level,omitempty"`
+	Disability              int    `json:"disability,omitempty"`
+	Aggression              int    `json:"aggression,omitempty"`
+	SexualitySexOrGender    int    `json:"sexuality_sex_or_gender,omitempty"`
+	Misogyny                int    `json:"misogyny,omitempty"`
+	Bullying                int    `json:"bullying,omitempty"`
+	Swearing                int    `json:"swearing,omitempty"`
+	RaceEthnicityOrReligion int    `json:"race_ethnicity_or_religion,omitempty"`
+	SexBasedTerms           int    `json:"sex_based_terms,omitempty"`
+}
@@ -0,0 +1,74 @@
+package twitch
+
+import "testing"
+
+func TestAutoModMessageEvents(t *testing.T) {
+	t.Run("hold event must be decoded", func(t *testing.T) {
+		data := `{
+			"broadcaster_user_id": "1337",
+			"user_id": "456789012",
+			"user_login": "baduser",
+			"user_name": "BadUser",
+			"message_id": "bad-message-id",
+			"message": {"text": "This is a bad message", "fragments": [{"type": "text", "text": "This is a bad message"}]},
+			"reason": "automod",
+			"automod": {"category": "aggressive", "level": 1, "boundaries": [{"start_pos": 0, "end_pos": 10}]},
+			"blocked_term": null,
+			"held_at": ` + referenceTimeStr + `
+		}`
+
+		want := &AutoModMessageHoldEvent{
+			BroadcasterUserId: "1337",
+			UserId:            "456789012",
+			UserLogin:         "baduser",
+			Username:          "BadUser",
+			MessageId:         "bad-message-id",
+			Message: ChatMessage{
+				Text:      "This is a bad message",
+				Fragments: []*ChatFragment{{Type: ChatFragmentText, Text: "This is a bad message"}},
+			},
+			Reason: "automod",
+			AutoMod: &AutoModDetails{
+				Category:   "aggressive",
+				Level:      1,
+				Boundaries: []*AutoModBoundary{{EndPos: 10}},
+			},
+			HeldAt: Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(AutoModMessageHoldEvent), want)
+	})
+
+	t.Run("update event must contain status", func(t *testing.T) {
+		data := `{"message_id": "bad-message-id", "moderator_user_id": "9001", "level": 5, "category": "aggressive", "status": "Approved"}`
+
+		want := &AutoModMessageUpdateEvent{
+			MessageId:       "bad-message-id",
+			ModeratorUserId: "9001",
+			Level:           5,
+			Category:        "aggressive",
+			Status:          AutoModMessageStatusApproved,
+		}
+
+		assertJSONUnmarshal(t, data, new(AutoModMessageUpdateEvent), want)
+	})
+}
+
+func TestAutoModSettingsUpdateEvent(t *testing.T) {
+	t.Run("overall level must be set when provided", func(t *testing.T) {
+		data := `{"broadcaster_user_id": "1337", "overall_level": 2, "aggression": 2}`
+
+		level := 2
+		want := &AutoModSettingsUpdateEvent{BroadcasterUserId: "1337", OverallLevel: &level, Aggression: 2}
+
+		assertJSONUnmarshal(t, data, new(AutoModSettingsUpdateEvent), want)
+	})
+
+	t.Run("overall level must be nil when null", func(t *testing.T) {
+		data := `{"broadcaster_user_id": "1337", "overall_level": null, "swearing": 4}`
+
+		want := &AutoModSettingsUpdateEvent{BroadcasterUserId: "1337", Swearing: 4}
+
+		assertJSONUnmarshal(t, data, new(AutoModSettingsUpdateEvent), want)
+	})
+}
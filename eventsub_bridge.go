@@ -0,0 +1,102 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const defaultBridgeTopicPrefix = "twitch.eventsub."
+
+// Publisher sends bridged notifications to a message broker, e.g. NATS or Kafka.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, msg *BridgeMessage) error
+}
+
+type PublisherFunc func(ctx context.Context, topic string, msg *BridgeMessage) error
+
+func (f PublisherFunc) Publish(ctx context.Context, topic string, msg *BridgeMessage) error {
+	return f(ctx, topic, msg)
+}
+
+// BridgeMessage is a notification as it is forwarded to a broker.
+// It is meant to be marshaled to JSON, the event is kept raw.
+type BridgeMessage struct {
+	MessageId           string                `json:"message_id,omitempty"`
+	MessageTimestamp    time.Time             `json:"message_timestamp,omitempty"`
+	SubscriptionType    string                `json:"subscription_type,omitempty"`
+	SubscriptionVersion string                `json:"subscription_version,omitempty"`
+	Subscription        *EventSubSubscription `json:"subscription,omitempty"`
+	Event               json.RawMessage       `json:"event,omitempty"`
+	Events              json.RawMessage       `json:"events,omitempty"`
+}
+
+func NewBridgeMessage(notification *EventSubNotification) *BridgeMessage {
+	msg := &BridgeMessage{
+		MessageId:        notification.MessageId,
+		MessageTimestamp: notification.Timestamp.Time,
+		Subscription:     notification.Subscription,
+		Event:            notification.Event,
+		Events:           notification.Events,
+	}
+
+	if notification.Subscription != nil {
+		msg.SubscriptionType = notification.Subscription.Type
+		msg.SubscriptionVersion = notification.Subscription.Version
+	}
+
+	return msg
+}
+
+// Notification turns the message back into a notification on the consuming side,
+// e.g. to pass it to EventBus.Publish.
+func (m *BridgeMessage) Notification() *EventSubNotification {
+	return &EventSubNotification{
+		MessageId:    m.MessageId,
+		Timestamp:    Timestamp{m.MessageTimestamp},
+		Subscription: m.Subscription,
+		Event:        m.Event,
+		Events:       m.Events,
+	}
+}
+
+// EventSubBridge forwards every notification to Publisher, so other services
+// can consume events without holding their own Twitch connection.
+// Use Handler as OnNotification of any EventSub transport.
+type EventSubBridge struct {
+	Publisher Publisher
+	// Topic returns the topic of a notification,
+	// it defaults to "twitch.eventsub." followed by the subscription type.
+	Topic   func(notification *EventSubNotification) string
+	OnError func(notification *EventSubNotification, err error)
+}
+
+func NewEventSubBridge(publisher Publisher) *EventSubBridge {
+	return &EventSubBridge{Publisher: publisher}
+}
+
+func (b *EventSubBridge) Forward(ctx context.Context, notification *EventSubNotification) error {
+	return b.Publisher.Publish(ctx, b.topic(notification), NewBridgeMessage(notification))
+}
+
+// Handler returns a notification hook forwarding with ctx,
+// errors are passed to OnError.
+func (b *EventSubBridge) Handler(ctx context.Context) func(notification *EventSubNotification) {
+	return func(notification *EventSubNotification) {
+		if err := b.Forward(ctx, notification); err != nil && b.OnError != nil {
+			b.OnError(notification, err)
+		}
+	}
+}
+
+func (b *EventSubBridge) topic(notification *EventSubNotification) string {
+	if b.Topic != nil {
+		return b.Topic(notification)
+	}
+
+	if notification.Subscription == nil {
+		return defaultBridgeTopicPrefix + "unknown"
+	}
+
+	return defaultBridgeTopicPrefix + notification.Subscription.Type
+}
@@ -0,0 +1,59 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEventSubBridge(t *testing.T) {
+	t.Run("must forward notifications with metadata", func(t *testing.T) {
+		var topics []string
+		var messages []*BridgeMessage
+		b := NewEventSubBridge(PublisherFunc(func(ctx context.Context, topic string, msg *BridgeMessage) error {
+			topics = append(topics, topic)
+			messages = append(messages, msg)
+			return nil
+		}))
+
+		n := &EventSubNotification{
+			MessageId:    "befa7b53",
+			Timestamp:    Timestamp{referenceTime},
+			Subscription: &EventSubSubscription{Id: "1", Type: EventSubChannelRaid, Version: "1"},
+			Event:        json.RawMessage(`{"viewers":9001}`),
+		}
+		assertNoError(t, b.Forward(context.Background(), n))
+
+		if len(topics) != 1 || topics[0] != "twitch.eventsub.channel.raid" {
+			t.Errorf("bad topics: %v", topics)
+		}
+
+		data, _ := json.Marshal(messages[0])
+		want := `{"message_id":"befa7b53","message_timestamp":"2006-01-02T15:04:05Z","subscription_type":"channel.raid","subscription_version":"1","subscription":{"id":"1","type":"channel.raid","version":"1","condition":{},"transport":{}},"event":{"viewers":9001}}`
+		if string(data) != want {
+			t.Errorf("bad message\ngot: %s\nwant: %s", data, want)
+		}
+
+		if got := messages[0].Notification(); !reflect.DeepEqual(got, n) {
+			t.Errorf("bad notification\ngot: %+v\nwant: %+v", got, n)
+		}
+	})
+
+	t.Run("handler must report errors", func(t *testing.T) {
+		errPublish := errors.New("broker is down")
+		b := NewEventSubBridge(PublisherFunc(func(ctx context.Context, topic string, msg *BridgeMessage) error {
+			return errPublish
+		}))
+		b.Topic = func(n *EventSubNotification) string { return "events" }
+
+		var got error
+		b.OnError = func(n *EventSubNotification, err error) { got = err }
+		b.Handler(context.Background())(&EventSubNotification{})
+
+		if got != errPublish {
+			t.Errorf("expected publish error, got: %v", got)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package twitch
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrEventSubBudgetExceeded = errors.New("eventsub subscription cost budget is exceeded")
+
+// EventSubBudget tracks total_cost and max_total_cost reported by the
+// subscriptions endpoints. When the remaining cost drops to Reserve or below,
+// OnLow is called before creating a subscription and, if Block is set,
+// the subscription is not created and ErrEventSubBudgetExceeded is returned.
+// Subscriptions that cost nothing are never blocked, see eventSubCost.
+type EventSubBudget struct {
+	Reserve int
+	Block   bool
+	OnLow   func(totalCost, maxTotalCost int)
+
+	mu           sync.Mutex
+	totalCost    int
+	maxTotalCost int
+}
+
+func (b *EventSubBudget) TotalCost() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.totalCost
+}
+
+func (b *EventSubBudget) MaxTotalCost() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.maxTotalCost
+}
+
+// Remaining returns -1 until the limits are known.
+func (b *EventSubBudget) Remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxTotalCost == 0 {
+		return -1
+	}
+
+	return b.maxTotalCost - b.totalCost
+}
+
+func (b *EventSubBudget) update(totalCost, maxTotalCost int) {
+	if maxTotalCost == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.totalCost, b.maxTotalCost = totalCost, maxTotalCost
+}
+
+// check is called before creating a subscription of the cost.
+func (b *EventSubBudget) check(cost int) error {
+	remaining := b.Remaining()
+	if remaining < 0 || remaining > b.Reserve {
+		return nil
+	}
+
+	if b.OnLow != nil {
+		b.OnLow(b.TotalCost(), b.MaxTotalCost())
+	}
+
+	// Twitch accepts subscriptions without cost at any total cost.
+	if b.Block && cost > 0 {
+		return ErrEventSubBudgetExceeded
+	}
+
+	return nil
+}
+
+// eventSubFreeOfAuthorization are the types not requiring the authorization
+// of a user. They cost 1 unless the user authorized the client anyway,
+// which isn't known before creating them. See "No authorization required" in
+// https://dev.twitch.tv/docs/eventsub/eventsub-subscription-types/ and
+// https://dev.twitch.tv/docs/eventsub/manage-subscriptions/#subscription-limits
+var eventSubFreeOfAuthorization = map[string]bool{
+	"channel.update":                       true,
+	"channel.shared_chat.begin":            true,
+	"channel.shared_chat.update":           true,
+	"channel.shared_chat.end":              true,
+	"stream.online":                        true,
+	"stream.offline":                       true,
+	EventSubChannelRaid:                    true,
+	EventSubConduitShardDisabled:           true,
+	EventSubDropEntitlementGrant:           true,
+	EventSubExtensionBitsTransactionCreate: true,
+	EventSubUserAuthorizationGrant:         true,
+	EventSubUserAuthorizationRevoke:        true,
+	EventSubUserUpdate:                     true,
+}
+
+// eventSubCost estimates the cost of a subscription: subscriptions authorized
+// by the user of their condition, e.g. the broadcaster, cost 0.
+func eventSubCost(opts *EventSubSubscriptionOptions) int {
+	if eventSubFreeOfAuthorization[opts.Type] {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,174 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestEventSubBudget(t *testing.T) {
+	t.Run("must be updated from responses", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[],"total":0,"total_cost":7,"max_total_cost":10}`)
+		})
+
+		if got := c.EventSub.Budget().Remaining(); got != -1 {
+			t.Errorf("unknown budget must be -1, got: %d", got)
+		}
+
+		ctx := context.Background()
+		_, _, err := c.EventSub.GetSubscriptions(ctx, nil)
+		assertNoError(t, err)
+
+		budget := c.EventSub.Budget()
+		if budget.TotalCost() != 7 || budget.MaxTotalCost() != 10 || budget.Remaining() != 3 {
+			t.Errorf("budget is not updated: %d/%d", budget.TotalCost(), budget.MaxTotalCost())
+		}
+	})
+
+	t.Run("must warn and block when reserve is reached", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		created := 0
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				created++
+			}
+			fmt.Fprint(w, `{"data":[],"total_cost":9,"max_total_cost":10}`)
+		})
+
+		warnings := 0
+		budget := c.EventSub.Budget()
+		budget.Reserve = 1
+		budget.OnLow = func(totalCost, maxTotalCost int) {
+			warnings++
+		}
+
+		ctx := context.Background()
+		c.EventSub.GetSubscriptions(ctx, nil)
+
+		opts := &EventSubSubscriptionOptions{
+			Type:      EventSubChannelRaid,
+			Version:   "1",
+			Transport: EventSubTransport{Method: EventSubTransportWebSocket},
+		}
+
+		_, _, err := c.EventSub.CreateSubscription(ctx, opts)
+		assertNoError(t, err)
+
+		budget.Block = true
+		_, _, err = c.EventSub.CreateSubscription(ctx, opts)
+		if err != ErrEventSubBudgetExceeded {
+			t.Errorf("expected ErrEventSubBudgetExceeded, got: %v", err)
+		}
+
+		if warnings != 2 || created != 1 {
+			t.Errorf("expected 2 warnings and 1 subscription, got: %d, %d", warnings, created)
+		}
+	})
+
+	t.Run("must not block subscriptions without cost", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		created := 0
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				created++
+			}
+			fmt.Fprint(w, `{"data":[],"total_cost":10,"max_total_cost":10}`)
+		})
+
+		budget := c.EventSub.Budget()
+		budget.Block = true
+
+		ctx := context.Background()
+		c.EventSub.GetSubscriptions(ctx, nil)
+
+		// Bans require the authorization of the broadcaster and cost 0.
+		_, _, err := c.EventSub.CreateSubscription(ctx, &EventSubSubscriptionOptions{
+			Type:      EventSubChannelBan,
+			Version:   "1",
+			Transport: EventSubTransport{Method: EventSubTransportWebSocket},
+		})
+		assertNoError(t, err)
+
+		_, _, err = c.EventSub.CreateSubscription(ctx, &EventSubSubscriptionOptions{
+			Type:      EventSubChannelRaid,
+			Version:   "1",
+			Transport: EventSubTransport{Method: EventSubTransportWebSocket},
+		})
+		if err != ErrEventSubBudgetExceeded {
+			t.Errorf("expected ErrEventSubBudgetExceeded, got: %v", err)
+		}
+
+		if created != 1 {
+			t.Errorf("expected 1 subscription, got: %d", created)
+		}
+	})
+
+	t.Run("must estimate the cost of types without authorization", func(t *testing.T) {
+		types := []string{
+			"channel.update",
+			"channel.shared_chat.begin",
+			"channel.shared_chat.update",
+			"channel.shared_chat.end",
+			"stream.online",
+			"stream.offline",
+			EventSubChannelRaid,
+			EventSubConduitShardDisabled,
+			EventSubDropEntitlementGrant,
+			EventSubExtensionBitsTransactionCreate,
+			EventSubUserAuthorizationGrant,
+			EventSubUserAuthorizationRevoke,
+			EventSubUserUpdate,
+		}
+
+		for _, typ := range types {
+			t.Run(typ, func(t *testing.T) {
+				if cost := eventSubCost(&EventSubSubscriptionOptions{Type: typ}); cost != 1 {
+					t.Errorf("expected cost 1, got: %d", cost)
+				}
+			})
+		}
+
+		if cost := eventSubCost(&EventSubSubscriptionOptions{Type: EventSubChannelChatMessage}); cost != 0 {
+			t.Errorf("types requiring authorization must cost 0, got: %d", cost)
+		}
+	})
+}
+
+func TestGetSubscriptionsByStatus(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, http.MethodGet)
+
+		if r.URL.Query().Get("after") == "" {
+			assertQuery(t, r, params{"type": "channel.raid"})
+			fmt.Fprint(w, `{"data":[{"id":"1","status":"enabled"},{"id":"2","status":"authorization_revoked"}],"pagination":{"cursor":"next"}}`)
+			return
+		}
+
+		assertQuery(t, r, params{"type": "channel.raid", "after": "next"})
+		fmt.Fprint(w, `{"data":[{"id":"3","status":"enabled"}],"pagination":{}}`)
+	})
+
+	ctx := context.Background()
+	grouped, _, err := c.EventSub.GetSubscriptionsByStatus(ctx, &EventSubSubscriptionsOptions{Type: EventSubChannelRaid})
+	assertNoError(t, err)
+
+	if got := len(grouped[EventSubStatusEnabled]); got != 2 {
+		t.Errorf("expected 2 enabled subscriptions, got: %d", got)
+	}
+
+	if got := len(grouped[EventSubStatusAuthorizationRevoked]); got != 1 {
+		t.Errorf("expected 1 revoked subscription, got: %d", got)
+	}
+}
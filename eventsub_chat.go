@@ -0,0 +1,255 @@
+package twitch
+
+import "context"
+
+const (
+	EventSubChannelChatMessage           = "channel.chat.message"
+	EventSubChannelChatNotification      = "channel.chat.notification"
+	EventSubChannelChatMessageDelete     = "channel.chat.message_delete"
+	EventSubChannelChatClear             = "channel.chat.clear"
+	EventSubChannelChatClearUserMessages = "channel.chat.clear_user_messages"
+	EventSubChannelChatSettingsUpdate    = "channel.chat_settings.update"
+
+	ChatFragmentText      = "text"
+	ChatFragmentCheermote = "cheermote"
+	ChatFragmentEmote     = "emote"
+	ChatFragmentMention   = "mention"
+)
+
+// SubscriptionTier is the tier of a subscription.
+type SubscriptionTier string
+
+const (
+	SubscriptionTier1 SubscriptionTier = "1000"
+	SubscriptionTier2 SubscriptionTier = "2000"
+	SubscriptionTier3 SubscriptionTier = "3000"
+)
+
+func (t SubscriptionTier) Valid() bool {
+	return t == SubscriptionTier1 || t == SubscriptionTier2 || t == SubscriptionTier3
+}
+
+type ChatCheermote struct {
+	Prefix string `json:"prefix,omitempty"`
+	Bits   int    `json:"bits,omitempty"`
+	Tier   int    `json:"tier,omitempty"`
+}
+
+type ChatEmote struct {
+	Id         string   `json:"id,omitempty"`
+	EmoteSetId string   `json:"emote_set_id,omitempty"`
+	OwnerId    string   `json:"owner_id,omitempty"`
+	Format     []string `json:"format,omitempty"`
+}
+
+type ChatMention struct {
+	UserId    string `json:"user_id,omitempty"`
+	UserLogin string `json:"user_login,omitempty"`
+	Username  string `json:"user_name,omitempty"`
+}
+
+// ChatFragment is a part of a chat message, only the object
+// matching Type is set.
+type ChatFragment struct {
+	Type      string         `json:"type,omitempty"`
+	Text      string         `json:"text,omitempty"`
+	Cheermote *ChatCheermote `json:"cheermote,omitempty"`
+	Emote     *ChatEmote     `json:"emote,omitempty"`
+	Mention   *ChatMention   `json:"mention,omitempty"`
+}
+
+type ChatMessage struct {
+	Text      string          `json:"text,omitempty"`
+	Fragments []*ChatFragment `json:"fragments,omitempty"`
+}
+
+type ChatBadge struct {
+	SetId string `json:"set_id,omitempty"`
+	Id    string `json:"id,omitempty"`
+	Info  string `json:"info,omitempty"`
+}
+
+type ChatCheer struct {
+	Bits int `json:"bits,omitempty"`
+}
+
+type ChatReply struct {
+	ParentMessageId   string `json:"parent_message_id,omitempty"`
+	ParentMessageBody string `json:"parent_message_body,omitempty"`
+	ParentUserId      string `json:"parent_user_id,omitempty"`
+	ParentUserLogin   string `json:"parent_user_login,omitempty"`
+	ParentUserName    string `json:"parent_user_name,omitempty"`
+	ThreadMessageId   string `json:"thread_message_id,omitempty"`
+	ThreadUserId      string `json:"thread_user_id,omitempty"`
+	ThreadUserLogin   string `json:"thread_user_login,omitempty"`
+	ThreadUserName    string `json:"thread_user_name,omitempty"`
+}
+
+type ChannelChatMessageEvent struct {
+	BroadcasterUserId           string       `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin        string       `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName         string       `json:"broadcaster_user_name,omitempty"`
+	ChatterUserId               string       `json:"chatter_user_id,omitempty"`
+	ChatterUserLogin            string       `json:"chatter_user_login,omitempty"`
+	ChatterUserName             string       `json:"chatter_user_name,omitempty"`
+	MessageId                   string       `json:"message_id,omitempty"`
+	Message                     ChatMessage  `json:"message,omitempty"`
+	MessageType                 string       `json:"message_type,omitempty"`
+	Badges                      []*ChatBadge `json:"badges,omitempty"`
+	Cheer                       *ChatCheer   `json:"cheer,omitempty"`
+	Color                       string       `json:"color,omitempty"`
+	Reply                       *ChatReply   `json:"reply,omitempty"`
+	ChannelPointsCustomRewardId string       `json:"channel_points_custom_reward_id,omitempty"`
+}
+
+type ChatNotificationSub struct {
+	SubTier        SubscriptionTier `json:"sub_tier,omitempty"`
+	IsPrime        bool             `json:"is_prime,omitempty"`
+	DurationMonths int              `json:"duration_months,omitempty"`
+}
+
+type ChatNotificationResub struct {
+	CumulativeMonths  int              `json:"cumulative_months,omitempty"`
+	DurationMonths    int              `json:"duration_months,omitempty"`
+	StreakMonths      int              `json:"streak_months,omitempty"`
+	SubTier           SubscriptionTier `json:"sub_tier,omitempty"`
+	IsPrime           bool             `json:"is_prime,omitempty"`
+	IsGift            bool             `json:"is_gift,omitempty"`
+	GifterIsAnonymous bool             `json:"gifter_is_anonymous,omitempty"`
+	GifterUserId      string           `json:"gifter_user_id,omitempty"`
+	GifterUserLogin   string           `json:"gifter_user_login,omitempty"`
+	GifterUserName    string           `json:"gifter_user_name,omitempty"`
+}
+
+type ChatNotificationSubGift struct {
+	DurationMonths     int              `json:"duration_months,omitempty"`
+	CumulativeTotal    int              `json:"cumulative_total,omitempty"`
+	RecipientUserId    string           `json:"recipient_user_id,omitempty"`
+	RecipientUserLogin string           `json:"recipient_user_login,omitempty"`
+	RecipientUserName  string           `json:"recipient_user_name,omitempty"`
+	SubTier            SubscriptionTier `json:"sub_tier,omitempty"`
+	CommunityGiftId    string           `json:"community_gift_id,omitempty"`
+}
+
+type ChatNotificationCommunitySubGift struct {
+	Id              string           `json:"id,omitempty"`
+	Total           int              `json:"total,omitempty"`
+	SubTier         SubscriptionTier `json:"sub_tier,omitempty"`
+	CumulativeTotal int              `json:"cumulative_total,omitempty"`
+}
+
+// ChatNotificationGifter is used by gift_paid_upgrade and pay_it_forward notices.
+type ChatNotificationGifter struct {
+	GifterIsAnonymous bool   `json:"gifter_is_anonymous,omitempty"`
+	GifterUserId      string `json:"gifter_user_id,omitempty"`
+	GifterUserLogin   string `json:"gifter_user_login,omitempty"`
+	GifterUserName    string `json:"gifter_user_name,omitempty"`
+}
+
+type ChatNotificationPrimePaidUpgrade struct {
+	SubTier SubscriptionTier `json:"sub_tier,omitempty"`
+}
+
+type ChatNotificationRaid struct {
+	UserId          string `json:"user_id,omitempty"`
+	UserLogin       string `json:"user_login,omitempty"`
+	Username        string `json:"user_name,omitempty"`
+	ViewerCount     int    `json:"viewer_count,omitempty"`
+	ProfileImageURL string `json:"profile_image_url,omitempty"`
+}
+
+type ChatNotificationAnnouncement struct {
+	Color string `json:"color,omitempty"`
+}
+
+type ChatNotificationBitsBadgeTier struct {
+	Tier int `json:"tier,omitempty"`
+}
+
+type ChatNotificationCharityDonation struct {
+	CharityName string `json:"charity_name,omitempty"`
+	Amount      Amount `json:"amount,omitempty"`
+}
+
+// ChannelChatNotificationEvent carries one notice-specific object,
+// which one is set depends on NoticeType.
+type ChannelChatNotificationEvent struct {
+	BroadcasterUserId    string                            `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string                            `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string                            `json:"broadcaster_user_name,omitempty"`
+	ChatterUserId        string                            `json:"chatter_user_id,omitempty"`
+	ChatterUserLogin     string                            `json:"chatter_user_login,omitempty"`
+	ChatterUserName      string                            `json:"chatter_user_name,omitempty"`
+	ChatterIsAnonymous   bool                              `json:"chatter_is_anonymous,omitempty"`
+	Color                string                            `json:"color,omitempty"`
+	Badges               []*ChatBadge                      `json:"badges,omitempty"`
+	SystemMessage        string                            `json:"system_message,omitempty"`
+	MessageId            string                            `json:"message_id,omitempty"`
+	Message              ChatMessage                       `json:"message,omitempty"`
+	NoticeType           string                            `json:"notice_type,omitempty"`
+	Sub                  *ChatNotificationSub              `json:"sub,omitempty"`
+	Resub                *ChatNotificationResub            `json:"resub,omitempty"`
+	SubGift              *ChatNotificationSubGift          `json:"sub_gift,omitempty"`
+	CommunitySubGift     *ChatNotificationCommunitySubGift `json:"community_sub_gift,omitempty"`
+	GiftPaidUpgrade      *ChatNotificationGifter           `json:"gift_paid_upgrade,omitempty"`
+	PrimePaidUpgrade     *ChatNotificationPrimePaidUpgrade `json:"prime_paid_upgrade,omitempty"`
+	Raid                 *ChatNotificationRaid             `json:"raid,omitempty"`
+	Unraid               *struct{}                         `json:"unraid,omitempty"`
+	PayItForward         *ChatNotificationGifter           `json:"pay_it_forward,omitempty"`
+	Announcement         *ChatNotificationAnnouncement     `json:"announcement,omitempty"`
+	CharityDonation      *ChatNotificationCharityDonation  `json:"charity_donation,omitempty"`
+	BitsBadgeTier        *ChatNotificationBitsBadgeTier    `json:"bits_badge_tier,omitempty"`
+}
+
+type ChannelChatMessageDeleteEvent struct {
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	TargetUserId         string `json:"target_user_id,omitempty"`
+	TargetUserLogin      string `json:"target_user_login,omitempty"`
+	TargetUserName       string `json:"target_user_name,omitempty"`
+	MessageId            string `json:"message_id,omitempty"`
+}
+
+type ChannelChatClearEvent struct {
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+}
+
+type ChannelChatClearUserMessagesEvent struct {
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	TargetUserId         string `json:"target_user_id,omitempty"`
+	TargetUserLogin      string `json:"target_user_login,omitempty"`
+	TargetUserName       string `json:"target_user_name,omitempty"`
+}
+
+type ChannelChatSettingsUpdateEvent struct {
+	BroadcasterUserId           string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin        string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName         string `json:"broadcaster_user_name,omitempty"`
+	EmoteMode                   bool   `json:"emote_mode,omitempty"`
+	FollowerMode                bool   `json:"follower_mode,omitempty"`
+	FollowerModeDurationMinutes int    `json:"follower_mode_duration_minutes,omitempty"`
+	SlowMode                    bool   `json:"slow_mode,omitempty"`
+	SlowModeWaitTimeSeconds     int    `json:"slow_mode_wait_time_seconds,omitempty"`
+	SubscriberMode              bool   `json:"subscriber_mode,omitempty"`
+	UniqueChatMode              bool   `json:"unique_chat_mode,omitempty"`
+}
+
+// SubscribeChannelChatSettingsUpdate requires BroadcasterUserId and
+// UserId of the user reading chat on behalf of the app.
+func (s *EventSubService) SubscribeChannelChatSettingsUpdate(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	condition = s.withBroadcaster(condition)
+	if condition.BroadcasterUserId == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: condition, Message: broadcasterUserIdIsRequired}
+	}
+
+	if condition.UserId == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: condition, Message: userIdIsRequired}
+	}
+
+	return s.subscribe(ctx, EventSubChannelChatSettingsUpdate, "1", condition, transport)
+}
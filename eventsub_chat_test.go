@@ -0,0 +1,157 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestChannelChatMessageEvent(t *testing.T) {
+	data := `{
+		"broadcaster_user_id": "1971641",
+		"broadcaster_user_login": "streamer",
+		"broadcaster_user_name": "streamer",
+		"chatter_user_id": "4145994",
+		"chatter_user_login": "viewer32",
+		"chatter_user_name": "viewer32",
+		"message_id": "cc106a89-1814-919d-454c-f4f2f970aae7",
+		"message": {
+			"text": "Hi chat cheer100 @streamer Kappa",
+			"fragments": [
+				{"type": "text", "text": "Hi chat ", "cheermote": null, "emote": null, "mention": null},
+				{"type": "cheermote", "text": "cheer100", "cheermote": {"prefix": "cheer", "bits": 100, "tier": 1}},
+				{"type": "mention", "text": "@streamer", "mention": {"user_id": "1971641", "user_login": "streamer", "user_name": "streamer"}},
+				{"type": "emote", "text": "Kappa", "emote": {"id": "25", "emote_set_id": "0", "owner_id": "0", "format": ["static"]}}
+			]
+		},
+		"color": "#00FF7F",
+		"badges": [{"set_id": "moderator", "id": "1", "info": ""}],
+		"message_type": "text",
+		"cheer": {"bits": 100},
+		"reply": null,
+		"channel_points_custom_reward_id": null
+	}`
+
+	want := &ChannelChatMessageEvent{
+		BroadcasterUserId:    "1971641",
+		BroadcasterUserLogin: "streamer",
+		BroadcasterUserName:  "streamer",
+		ChatterUserId:        "4145994",
+		ChatterUserLogin:     "viewer32",
+		ChatterUserName:      "viewer32",
+		MessageId:            "cc106a89-1814-919d-454c-f4f2f970aae7",
+		Message: ChatMessage{
+			Text: "Hi chat cheer100 @streamer Kappa",
+			Fragments: []*ChatFragment{
+				{Type: ChatFragmentText, Text: "Hi chat "},
+				{Type: ChatFragmentCheermote, Text: "cheer100", Cheermote: &ChatCheermote{Prefix: "cheer", Bits: 100, Tier: 1}},
+				{Type: ChatFragmentMention, Text: "@streamer", Mention: &ChatMention{UserId: "1971641", UserLogin: "streamer", Username: "streamer"}},
+				{Type: ChatFragmentEmote, Text: "Kappa", Emote: &ChatEmote{Id: "25", EmoteSetId: "0", OwnerId: "0", Format: []string{"static"}}},
+			},
+		},
+		Color:       "#00FF7F",
+		Badges:      []*ChatBadge{{SetId: "moderator", Id: "1"}},
+		MessageType: "text",
+		Cheer:       &ChatCheer{Bits: 100},
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelChatMessageEvent), want)
+}
+
+func TestChannelChatNotificationEvent(t *testing.T) {
+	data := `{
+		"broadcaster_user_id": "1971641",
+		"chatter_user_id": "49912639",
+		"chatter_is_anonymous": false,
+		"system_message": "viewer23 subscribed at Tier 1. They've subscribed for 10 months!",
+		"message_id": "d62235c8-47ff-a4f4--84e8-5a29a65a9c03",
+		"message": {"text": "", "fragments": []},
+		"notice_type": "resub",
+		"sub": null,
+		"resub": {"cumulative_months": 10, "duration_months": 0, "streak_months": null, "sub_tier": "1000", "is_prime": false, "is_gift": false}
+	}`
+
+	want := &ChannelChatNotificationEvent{
+		BroadcasterUserId: "1971641",
+		ChatterUserId:     "49912639",
+		SystemMessage:     "viewer23 subscribed at Tier 1. They've subscribed for 10 months!",
+		MessageId:         "d62235c8-47ff-a4f4--84e8-5a29a65a9c03",
+		Message:           ChatMessage{Fragments: []*ChatFragment{}},
+		NoticeType:        "resub",
+		Resub:             &ChatNotificationResub{CumulativeMonths: 10, SubTier: "1000"},
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelChatNotificationEvent), want)
+}
+
+func TestChannelChatMessageDeleteEvent(t *testing.T) {
+	data := `{"broadcaster_user_id": "1337", "target_user_id": "7734", "target_user_login": "uncool_viewer", "target_user_name": "uncool_viewer", "message_id": "ab24e0b0"}`
+
+	want := &ChannelChatMessageDeleteEvent{
+		BroadcasterUserId: "1337",
+		TargetUserId:      "7734",
+		TargetUserLogin:   "uncool_viewer",
+		TargetUserName:    "uncool_viewer",
+		MessageId:         "ab24e0b0",
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelChatMessageDeleteEvent), want)
+}
+
+func TestChannelChatSettingsUpdateEvent(t *testing.T) {
+	data := `{
+		"broadcaster_user_id": "1337",
+		"emote_mode": true,
+		"follower_mode": false,
+		"follower_mode_duration_minutes": null,
+		"slow_mode": true,
+		"slow_mode_wait_time_seconds": 10,
+		"subscriber_mode": false,
+		"unique_chat_mode": false
+	}`
+
+	want := &ChannelChatSettingsUpdateEvent{
+		BroadcasterUserId:       "1337",
+		EmoteMode:               true,
+		SlowMode:                true,
+		SlowModeWaitTimeSeconds: 10,
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelChatSettingsUpdateEvent), want)
+}
+
+func TestSubscribeChannelChatSettingsUpdate(t *testing.T) {
+	t.Run("tests condition to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(EventSubSubscriptionOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+
+			want := EventSubCondition{BroadcasterUserId: "1337", UserId: "9001"}
+			if opts.Type != EventSubChannelChatSettingsUpdate || opts.Condition != want {
+				t.Errorf("bad subscription options: %+v", opts)
+			}
+
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		ctx := context.Background()
+		_, _, err := c.EventSub.SubscribeChannelChatSettingsUpdate(ctx,
+			&EventSubCondition{BroadcasterUserId: "1337", UserId: "9001"},
+			&EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "1"},
+		)
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error, when user_id is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+		_, _, err := client.EventSub.SubscribeChannelChatSettingsUpdate(ctx, &EventSubCondition{BroadcasterUserId: "1337"}, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, userIdIsRequired)
+	})
+}
@@ -0,0 +1,287 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConduitReconnectDelay = time.Second
+	maxConduitReconnectDelay     = time.Minute
+	maxConduitGrows              = 3
+)
+
+var (
+	ErrConduitNotFound = errors.New("conduit is not found")
+	ErrConduitShrunk   = errors.New("conduit keeps shrinking below the shard")
+)
+
+// ConduitShardManager runs one shard of a conduit over the WebSocket transport,
+// so EventSub consumption can be scaled by starting a process per shard.
+//
+// The shard is bound to every new WebSocket session. If the conduit has fewer
+// shards than ShardId requires, the shard count is increased first. Twitch
+// can't update a conduit conditionally, so managers growing it at the same
+// time may shrink it below the shard of one another: the conduit is read again
+// after growing it and grown again when the shard is missing. This narrows
+// the window, but a manager growing the conduit after the check can still
+// remove the shard. If the conduit doesn't exist anymore, it is created again
+// and ConduitId is replaced, see OnConduitCreated.
+//
+// The manager subscribes the conduit to conduit.shard.disabled. Twitch sends
+// these notifications to any enabled shard, usually not the disabled one:
+// OnShardDisabled is called for every shard of the conduit, and the shard of
+// the manager is bound again when it is the disabled one. When the connection
+// of a shard drops, its manager connects again and binds the shard to the
+// new session.
+type ConduitShardManager struct {
+	ConduitId string
+	ShardId   string
+	WebSocket *EventSubWebSocket
+
+	// ReconnectDelay is the delay before connecting again after the
+	// connection failed, it doubles with every failure up to a minute.
+	// It defaults to 1 second.
+	ReconnectDelay time.Duration
+
+	OnNotification  func(notification *EventSubNotification)
+	OnShardDisabled func(event *ConduitShardDisabledEvent)
+	// OnConduitCreated is called when the conduit was created again, the
+	// other shards must be moved to the new conduit by their processes.
+	OnConduitCreated func(conduit *Conduit)
+	// OnError is called when the shard could not be bound or the connection
+	// failed, the manager keeps running and tries again.
+	OnError func(err error)
+
+	client *Client
+
+	mu sync.Mutex
+	// subscribed is the conduit subscribed to conduit.shard.disabled.
+	subscribed string
+}
+
+func NewConduitShardManager(client *Client, conduitId, shardId string) *ConduitShardManager {
+	return &ConduitShardManager{
+		ConduitId: conduitId,
+		ShardId:   shardId,
+		WebSocket: NewEventSubWebSocket(client),
+		client:    client,
+	}
+}
+
+// Run connects the WebSocket and blocks until ctx is done, connecting again
+// when the connection fails. It replaces OnWelcome and OnNotification hooks
+// of the WebSocket.
+func (m *ConduitShardManager) Run(ctx context.Context) error {
+	if ctx == nil {
+		return errNonNilContext
+	}
+
+	welcomed := false
+	m.WebSocket.OnWelcome = func(session *EventSubSession) {
+		welcomed = true
+		m.bind(ctx)
+	}
+
+	m.WebSocket.OnNotification = func(notification *EventSubNotification) {
+		m.handleNotification(ctx, notification)
+	}
+
+	base := m.ReconnectDelay
+	if base <= 0 {
+		base = defaultConduitReconnectDelay
+	}
+
+	for delay := base; ; {
+		welcomed = false
+		err := m.WebSocket.Connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if m.OnError != nil {
+			m.OnError(err)
+		}
+
+		// Failures of established sessions are retried right away,
+		// repeated failures to connect back off.
+		if welcomed {
+			delay = base
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if !welcomed && delay < maxConduitReconnectDelay {
+			delay *= 2
+		}
+		m.WebSocket.observeReconnect(ReconnectConnectionLost)
+	}
+}
+
+// Bind binds the shard to the current WebSocket session, increasing the
+// shard count of the conduit or creating it again when needed.
+func (m *ConduitShardManager) Bind(ctx context.Context) error {
+	if m.WebSocket.Session() == nil {
+		return ErrEventSubNotConnected
+	}
+
+	if err := m.ensureShard(ctx); err != nil {
+		return err
+	}
+
+	conduitId := m.conduitId()
+	shards, _, err := m.client.EventSub.UpdateConduitShards(ctx, &UpdateConduitShardsOptions{
+		ConduitId: conduitId,
+		Shards:    []*ConduitShard{{Id: m.ShardId, Transport: *m.WebSocket.Transport()}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(shards.Errors) != 0 {
+		return shards.Errors[0]
+	}
+
+	return m.subscribeShardDisabled(ctx, conduitId)
+}
+
+func (m *ConduitShardManager) bind(ctx context.Context) {
+	if err := m.Bind(ctx); err != nil && m.OnError != nil {
+		m.OnError(err)
+	}
+}
+
+func (m *ConduitShardManager) conduitId() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.ConduitId
+}
+
+// subscribeShardDisabled subscribes the conduit to conduit.shard.disabled
+// once. Every shard of the conduit tries it, Twitch answers the others
+// with 409 Conflict.
+func (m *ConduitShardManager) subscribeShardDisabled(ctx context.Context, conduitId string) error {
+	m.mu.Lock()
+	subscribed := m.subscribed == conduitId
+	m.mu.Unlock()
+
+	if subscribed {
+		return nil
+	}
+
+	_, _, err := m.client.EventSub.SubscribeConduitShardDisabled(ctx,
+		&EventSubCondition{ClientId: m.client.credentials.ClientId, ConduitId: conduitId},
+		&EventSubTransport{Method: EventSubTransportConduit, ConduitId: conduitId},
+	)
+
+	var errResp *ErrorResponse
+	if err != nil && !(errors.As(err, &errResp) && errResp.StatusCode == http.StatusConflict) {
+		return err
+	}
+
+	m.mu.Lock()
+	m.subscribed = conduitId
+	m.mu.Unlock()
+
+	return nil
+}
+
+// getConduit returns the conduit of the manager or ErrConduitNotFound.
+func (m *ConduitShardManager) getConduit(ctx context.Context) (*Conduit, error) {
+	conduits, _, err := m.client.EventSub.GetConduits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conduitId := m.conduitId()
+	for _, conduit := range conduits {
+		if conduit.Id == conduitId {
+			return conduit, nil
+		}
+	}
+
+	return nil, ErrConduitNotFound
+}
+
+func (m *ConduitShardManager) ensureShard(ctx context.Context) error {
+	shardId, err := strconv.Atoi(m.ShardId)
+	if err != nil {
+		return err
+	}
+
+	for grows := 0; ; grows++ {
+		// The conduit is read again right before growing it, another shard
+		// may have grown it further meanwhile and must not lose its shard.
+		for read := 0; read < 2; read++ {
+			conduit, err := m.getConduit(ctx)
+			if err == ErrConduitNotFound {
+				return m.createConduit(ctx, shardId+1)
+			}
+			if err != nil {
+				return err
+			}
+
+			if conduit.ShardCount > shardId {
+				return nil
+			}
+		}
+
+		if grows == maxConduitGrows {
+			return ErrConduitShrunk
+		}
+
+		_, _, err = m.client.EventSub.UpdateConduit(ctx, &ConduitOptions{Id: m.conduitId(), ShardCount: shardId + 1})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (m *ConduitShardManager) createConduit(ctx context.Context, shardCount int) error {
+	conduit, _, err := m.client.EventSub.CreateConduit(ctx, &ConduitOptions{ShardCount: shardCount})
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.ConduitId = conduit.Id
+	m.mu.Unlock()
+
+	if m.OnConduitCreated != nil {
+		m.OnConduitCreated(conduit)
+	}
+
+	return nil
+}
+
+func (m *ConduitShardManager) handleNotification(ctx context.Context, notification *EventSubNotification) {
+	if notification.Subscription == nil || notification.Subscription.Type != EventSubConduitShardDisabled {
+		if m.OnNotification != nil {
+			m.OnNotification(notification)
+		}
+		return
+	}
+
+	event := new(ConduitShardDisabledEvent)
+	if err := notification.Decode(event); err != nil || event.ConduitId != m.conduitId() {
+		return
+	}
+
+	if m.OnShardDisabled != nil {
+		m.OnShardDisabled(event)
+	}
+
+	// Other shards are bound again by their managers once they reconnect.
+	if event.ShardId == m.ShardId && m.WebSocket.Session() != nil {
+		m.bind(ctx)
+	}
+}
@@ -0,0 +1,312 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestConduitShardManager(t *testing.T) {
+	t.Run("must bind shard again, when its socket drops and a sibling is notified", func(t *testing.T) {
+		c, mux, serverURL, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubConduitsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("the conduit must not be changed, got: %s", r.Method)
+			}
+			fmt.Fprint(w, `{"data":[{"id":"conduit","shard_count":2}]}`)
+		})
+
+		var mu sync.Mutex
+		bound := make(chan string, 10)
+		mux.HandleFunc("/"+eventSubConduitShardsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(UpdateConduitShardsOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+			bound <- opts.Shards[0].Id + ":" + opts.Shards[0].Transport.SessionId
+			fmt.Fprintf(w, `{"data":[{"id":"%s","status":"enabled"}]}`, opts.Shards[0].Id)
+		})
+
+		var subscriptions []*EventSubSubscriptionOptions
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(EventSubSubscriptionOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+
+			mu.Lock()
+			subscriptions = append(subscriptions, opts)
+			first := len(subscriptions) == 1
+			mu.Unlock()
+
+			// The subscription of the conduit is created by the first shard.
+			if !first {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"error":"Conflict","status":409,"message":"subscription already exists"}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"id":"sub","type":"conduit.shard.disabled"}]}`)
+		})
+
+		dropped := make(chan struct{})
+		mux.HandleFunc("/ws0", func(w http.ResponseWriter, r *http.Request) {
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			defer conn.Close()
+
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("s0", 10)))
+
+			// Twitch notifies an enabled shard about the disabled one.
+			<-dropped
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"metadata":{"message_id":"d1","message_type":"notification"},"payload":{"subscription":{"type":"conduit.shard.disabled"},"event":{"conduit_id":"conduit","shard_id":"1","status":"websocket_disconnected"}}}`))
+			conn.ReadMessage()
+		})
+
+		connects := 0
+		mux.HandleFunc("/ws1", func(w http.ResponseWriter, r *http.Request) {
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			defer conn.Close()
+
+			mu.Lock()
+			connects++
+			first := connects == 1
+			mu.Unlock()
+
+			if first {
+				conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("s1a", 10)))
+				// The connection drops once the shard is bound.
+				time.Sleep(50 * time.Millisecond)
+				return
+			}
+
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("s1b", 10)))
+			conn.ReadMessage()
+		})
+
+		wsURL := "ws" + strings.TrimPrefix(serverURL, "http")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		disabled := make(chan *ConduitShardDisabledEvent, 1)
+		m0 := NewConduitShardManager(c, "conduit", "0")
+		m0.WebSocket.URL = wsURL + "/ws0"
+		m0.OnShardDisabled = func(event *ConduitShardDisabledEvent) {
+			disabled <- event
+		}
+
+		m1 := NewConduitShardManager(c, "conduit", "1")
+		m1.WebSocket.URL = wsURL + "/ws1"
+		m1.ReconnectDelay = time.Millisecond
+
+		var once sync.Once
+		m1.OnError = func(err error) {
+			once.Do(func() { close(dropped) })
+		}
+
+		errs := make(chan error, 2)
+		for _, m := range []*ConduitShardManager{m0, m1} {
+			go func(m *ConduitShardManager) {
+				errs <- m.Run(ctx)
+			}(m)
+		}
+
+		want := map[string]bool{"0:s0": true, "1:s1a": true, "1:s1b": true}
+		for len(want) > 0 {
+			select {
+			case b := <-bound:
+				if !want[b] {
+					t.Errorf("unexpected binding %s", b)
+				}
+				delete(want, b)
+			case <-ctx.Done():
+				t.Fatalf("missing bindings %v", want)
+			}
+		}
+
+		select {
+		case event := <-disabled:
+			if event.ShardId != "1" {
+				t.Errorf("bad disabled shard %s", event.ShardId)
+			}
+		case <-ctx.Done():
+			t.Fatal("the sibling shard must be notified")
+		}
+
+		cancel()
+		for i := 0; i < 2; i++ {
+			if err := <-errs; err != context.Canceled {
+				t.Errorf("expected context cancellation, got: %v", err)
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(subscriptions) != 2 {
+			t.Fatalf("expected a subscription by each shard, got: %d", len(subscriptions))
+		}
+
+		sub := subscriptions[0]
+		if sub.Type != EventSubConduitShardDisabled || sub.Condition.ConduitId != "conduit" || sub.Condition.ClientId != "ClientId" ||
+			sub.Transport.Method != EventSubTransportConduit || sub.Transport.ConduitId != "conduit" {
+			t.Errorf("bad subscription %+v", sub)
+		}
+	})
+
+	t.Run("must only grow the conduit", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		// Another shard grows the conduit between the reads.
+		reads := 0
+		mux.HandleFunc("/"+eventSubConduitsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				t.Error("the conduit must not be shrunk")
+			}
+
+			reads++
+			if reads == 1 {
+				fmt.Fprint(w, `{"data":[{"id":"conduit","shard_count":1}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"id":"conduit","shard_count":4}]}`)
+		})
+
+		m := NewConduitShardManager(c, "conduit", "2")
+		if err := m.ensureShard(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if reads != 2 {
+			t.Errorf("the conduit must be read again, got %d reads", reads)
+		}
+	})
+
+	t.Run("must grow the conduit, when it has too few shards", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var shardCount int
+		mux.HandleFunc("/"+eventSubConduitsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				opts := new(ConduitOptions)
+				json.NewDecoder(r.Body).Decode(opts)
+				shardCount = opts.ShardCount
+			}
+			fmt.Fprintf(w, `{"data":[{"id":"conduit","shard_count":%d}]}`, max(shardCount, 2))
+		})
+
+		m := NewConduitShardManager(c, "conduit", "2")
+		if err := m.ensureShard(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if shardCount != 3 {
+			t.Errorf("expected 3 shards, got: %d", shardCount)
+		}
+	})
+
+	t.Run("must grow the conduit again, when another shard shrinks it", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		// Another shard read the conduit before it was grown and shrinks it
+		// right after, removing the shard.
+		shardCount, updates := 2, 0
+		mux.HandleFunc("/"+eventSubConduitsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				opts := new(ConduitOptions)
+				json.NewDecoder(r.Body).Decode(opts)
+				shardCount = opts.ShardCount
+
+				updates++
+				if updates == 1 {
+					shardCount = 2
+				}
+			}
+			fmt.Fprintf(w, `{"data":[{"id":"conduit","shard_count":%d}]}`, shardCount)
+		})
+
+		m := NewConduitShardManager(c, "conduit", "2")
+		if err := m.ensureShard(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if shardCount != 3 || updates != 2 {
+			t.Errorf("expected 3 shards after 2 updates, got: %d shards after %d updates", shardCount, updates)
+		}
+	})
+
+	t.Run("must return error, when the conduit keeps shrinking", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		updates := 0
+		mux.HandleFunc("/"+eventSubConduitsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				updates++
+			}
+			fmt.Fprint(w, `{"data":[{"id":"conduit","shard_count":2}]}`)
+		})
+
+		m := NewConduitShardManager(c, "conduit", "2")
+		if err := m.ensureShard(context.Background()); err != ErrConduitShrunk {
+			t.Errorf("expected ErrConduitShrunk, got: %v", err)
+		}
+
+		if updates != maxConduitGrows {
+			t.Errorf("expected %d updates, got: %d", maxConduitGrows, updates)
+		}
+	})
+
+	t.Run("must create the conduit again, when it does not exist", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var shardCount int
+		mux.HandleFunc("/"+eventSubConduitsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				opts := new(ConduitOptions)
+				json.NewDecoder(r.Body).Decode(opts)
+				shardCount = opts.ShardCount
+				fmt.Fprintf(w, `{"data":[{"id":"new","shard_count":%d}]}`, opts.ShardCount)
+				return
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		mux.HandleFunc("/"+eventSubConduitShardsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(UpdateConduitShardsOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+			if opts.ConduitId != "new" {
+				t.Errorf("the shard must be bound to the new conduit, got: %s", opts.ConduitId)
+			}
+			fmt.Fprint(w, `{"data":[{"id":"1","status":"enabled"}]}`)
+		})
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[{"id":"sub","type":"conduit.shard.disabled"}]}`)
+		})
+
+		m := NewConduitShardManager(c, "conduit", "1")
+		m.WebSocket.setSession(&EventSubSession{Id: "s1"})
+
+		var created *Conduit
+		m.OnConduitCreated = func(conduit *Conduit) {
+			created = conduit
+		}
+
+		if err := m.Bind(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if shardCount != 2 || created == nil || created.Id != "new" || m.ConduitId != "new" {
+			t.Errorf("bad conduit %+v, shard count %d", created, shardCount)
+		}
+	})
+}
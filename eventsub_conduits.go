@@ -0,0 +1,189 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	eventSubConduitsPath      = "eventsub/conduits"
+	eventSubConduitShardsPath = "eventsub/conduits/shards"
+
+	EventSubConduitShardDisabled = "conduit.shard.disabled"
+
+	ConduitShardStatusEnabled = "enabled"
+
+	conduitIdIsRequired = "id is required"
+	clientIdIsRequired  = "client_id is required"
+)
+
+type Conduit struct {
+	Id         string `json:"id,omitempty"`
+	ShardCount int    `json:"shard_count,omitempty"`
+}
+
+type ConduitsResponse = DataResponse[*Conduit]
+
+type ConduitOptions struct {
+	Id         string `json:"id,omitempty"`
+	ShardCount int    `json:"shard_count,omitempty" validate:"required"`
+}
+
+type ConduitId struct {
+	Id string `url:"id,omitempty" validate:"required"`
+}
+
+type ConduitShard struct {
+	Id        string            `json:"id,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Transport EventSubTransport `json:"transport,omitempty"`
+}
+
+type ConduitShardError struct {
+	Id      string `json:"id,omitempty"`
+	Message string `json:"message,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+func (e *ConduitShardError) Error() string {
+	return fmt.Sprintf("Message: shard %s: %s", e.Id, e.Message)
+}
+
+type ConduitShardsResponse struct {
+	DataResponse[*ConduitShard]
+	Errors []*ConduitShardError `json:"errors,omitempty"`
+}
+
+type ConduitShardsOptions struct {
+	ConduitId string `url:"conduit_id,omitempty" validate:"required"`
+	Status    string `url:"status,omitempty"`
+	After     string `url:"after,omitempty"`
+}
+
+type UpdateConduitShardsOptions struct {
+	ConduitId string          `json:"conduit_id,omitempty" validate:"required"`
+	Shards    []*ConduitShard `json:"shards,omitempty" validate:"required"`
+}
+
+type ConduitShardDisabledEvent struct {
+	ConduitId string            `json:"conduit_id,omitempty"`
+	ShardId   string            `json:"shard_id,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Transport EventSubTransport `json:"transport,omitempty"`
+}
+
+func (s *EventSubService) GetConduits(ctx context.Context) ([]*Conduit, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, eventSubConduitsPath, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.doConduits(ctx, req)
+}
+
+func (s *EventSubService) CreateConduit(ctx context.Context, opts *ConduitOptions) (*Conduit, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, eventSubConduitsPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.doConduit(ctx, req)
+}
+
+func (s *EventSubService) UpdateConduit(ctx context.Context, opts *ConduitOptions) (*Conduit, *Response, error) {
+	if opts == nil || opts.Id == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: opts, Message: conduitIdIsRequired}
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, eventSubConduitsPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.doConduit(ctx, req)
+}
+
+func (s *EventSubService) DeleteConduit(ctx context.Context, opts *ConduitId) (*Response, error) {
+	u, err := addParams(eventSubConduitsPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(preferAppToken(ctx), req, nil)
+}
+
+func (s *EventSubService) GetConduitShards(ctx context.Context, opts *ConduitShardsOptions) (*ConduitShardsResponse, *Response, error) {
+	u, err := addParams(eventSubConduitShardsPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shards := new(ConduitShardsResponse)
+	resp, err := s.client.Do(preferAppToken(ctx), req, shards)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return shards, resp, nil
+}
+
+// UpdateConduitShards binds shards to transports. Shards that could not be
+// updated are listed in Errors of the response, it is not an error itself.
+func (s *EventSubService) UpdateConduitShards(ctx context.Context, opts *UpdateConduitShardsOptions) (*ConduitShardsResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPatch, eventSubConduitShardsPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shards := new(ConduitShardsResponse)
+	resp, err := s.client.Do(preferAppToken(ctx), req, shards)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return shards, resp, nil
+}
+
+// SubscribeConduitShardDisabled requires ClientId of the condition,
+// ConduitId limits notifications to a single conduit.
+func (s *EventSubService) SubscribeConduitShardDisabled(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	if condition == nil || condition.ClientId == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: condition, Message: clientIdIsRequired}
+	}
+
+	return s.subscribe(ctx, EventSubConduitShardDisabled, "1", condition, transport)
+}
+
+func (s *EventSubService) doConduits(ctx context.Context, req *http.Request) ([]*Conduit, *Response, error) {
+	conduits := new(ConduitsResponse)
+	resp, err := s.client.Do(preferAppToken(ctx), req, conduits)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return conduits.Data, resp, nil
+}
+
+func (s *EventSubService) doConduit(ctx context.Context, req *http.Request) (*Conduit, *Response, error) {
+	conduits, resp, err := s.doConduits(ctx, req)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(conduits) == 0 {
+		return nil, resp, ErrNoData
+	}
+
+	return conduits[0], resp, nil
+}
@@ -0,0 +1,156 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestConduitShardDisabledEvent(t *testing.T) {
+	data := `{
+		"conduit_id": "bfcfc993-26b1-b876-44d9-afe75a379dac",
+		"shard_id": "4",
+		"status": "websocket_disconnected",
+		"transport": {
+			"method": "websocket",
+			"session_id": "ad1c9fc3-0d99-4eb7-8a04-8608e8ff9ec9"
+		}
+	}`
+
+	want := &ConduitShardDisabledEvent{
+		ConduitId: "bfcfc993-26b1-b876-44d9-afe75a379dac",
+		ShardId:   "4",
+		Status:    EventSubStatusWebSocketDisconnected,
+		Transport: EventSubTransport{
+			Method:    EventSubTransportWebSocket,
+			SessionId: "ad1c9fc3-0d99-4eb7-8a04-8608e8ff9ec9",
+		},
+	}
+
+	assertJSONUnmarshal(t, data, new(ConduitShardDisabledEvent), want)
+}
+
+func TestConduits(t *testing.T) {
+	t.Run("tests methods and bodies to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubConduitsPath, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+
+			switch r.Method {
+			case http.MethodGet:
+			case http.MethodPost:
+				if got, want := string(body), `{"shard_count":5}`+"\n"; got != want {
+					t.Errorf("bad body\ngot: %s\nwant: %s", got, want)
+				}
+			case http.MethodPatch:
+				if got, want := string(body), `{"id":"bfcfc993","shard_count":5}`+"\n"; got != want {
+					t.Errorf("bad body\ngot: %s\nwant: %s", got, want)
+				}
+			case http.MethodDelete:
+				assertQuery(t, r, params{"id": "bfcfc993"})
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			fmt.Fprint(w, `{"data":[{"id":"bfcfc993","shard_count":5}]}`)
+		})
+
+		ctx := context.Background()
+		want := &Conduit{Id: "bfcfc993", ShardCount: 5}
+
+		conduits, _, err := c.EventSub.GetConduits(ctx)
+		assertNoError(t, err)
+		if !reflect.DeepEqual(conduits, []*Conduit{want}) {
+			t.Errorf("\ngot: %v\nwant: %v", conduits, want)
+		}
+
+		conduit, _, err := c.EventSub.CreateConduit(ctx, &ConduitOptions{ShardCount: 5})
+		assertNoError(t, err)
+		if !reflect.DeepEqual(conduit, want) {
+			t.Errorf("\ngot: %v\nwant: %v", conduit, want)
+		}
+
+		_, _, err = c.EventSub.UpdateConduit(ctx, &ConduitOptions{Id: "bfcfc993", ShardCount: 5})
+		assertNoError(t, err)
+
+		_, err = c.EventSub.DeleteConduit(ctx, &ConduitId{"bfcfc993"})
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error, when required fields are not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+
+		_, _, err := client.EventSub.CreateConduit(ctx, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "shard_count is required")
+
+		_, _, err = client.EventSub.UpdateConduit(ctx, &ConduitOptions{ShardCount: 1})
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, conduitIdIsRequired)
+
+		_, err = client.EventSub.DeleteConduit(ctx, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, conduitIdIsRequired)
+
+		_, _, err = client.EventSub.UpdateConduitShards(ctx, &UpdateConduitShardsOptions{ConduitId: "1"})
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "shards is required")
+
+		_, _, err = client.EventSub.SubscribeConduitShardDisabled(ctx, nil, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, clientIdIsRequired)
+	})
+}
+
+func TestConduitShards(t *testing.T) {
+	t.Run("tests methods and parameters to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubConduitShardsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				assertQuery(t, r, params{"conduit_id": "bfcfc993", "status": "enabled"})
+				fmt.Fprint(w, `{"data":[{"id":"0","status":"enabled","transport":{"method":"websocket","session_id":"s1"}}],"pagination":{}}`)
+				return
+			}
+
+			assertMethod(t, r, http.MethodPatch)
+			body, _ := ioutil.ReadAll(r.Body)
+			want := `{"conduit_id":"bfcfc993","shards":[{"id":"0","transport":{"method":"websocket","session_id":"s1"}}]}` + "\n"
+			if got := string(body); got != want {
+				t.Errorf("bad body\ngot: %s\nwant: %s", got, want)
+			}
+
+			fmt.Fprint(w, `{"data":[],"errors":[{"id":"0","message":"The websocket session is not connected.","code":"websocket_not_connected"}]}`)
+		})
+
+		ctx := context.Background()
+		shards, _, err := c.EventSub.GetConduitShards(ctx, &ConduitShardsOptions{ConduitId: "bfcfc993", Status: ConduitShardStatusEnabled})
+		assertNoError(t, err)
+
+		want := []*ConduitShard{{
+			Id:        "0",
+			Status:    ConduitShardStatusEnabled,
+			Transport: EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "s1"},
+		}}
+		if !reflect.DeepEqual(shards.Data, want) {
+			t.Errorf("\ngot: %v\nwant: %v", shards.Data, want)
+		}
+
+		updated, _, err := c.EventSub.UpdateConduitShards(ctx, &UpdateConduitShardsOptions{
+			ConduitId: "bfcfc993",
+			Shards:    []*ConduitShard{{Id: "0", Transport: EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "s1"}}},
+		})
+		assertNoError(t, err)
+
+		if len(updated.Errors) != 1 || updated.Errors[0].Code != "websocket_not_connected" {
+			t.Errorf("bad shard errors: %v", updated.Errors)
+		}
+	})
+}
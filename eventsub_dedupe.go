@@ -0,0 +1,69 @@
+package twitch
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+const defaultDedupeStoreSize = 10000
+
+// DedupeStore remembers EventSub message ids, so redelivered
+// notifications are passed to handlers only once.
+// Implementations backed by a shared storage like Redis (SET NX with a TTL)
+// allow several processes to deduplicate the same subscriptions.
+type DedupeStore interface {
+	// Seen records id and reports whether it has been recorded before.
+	Seen(ctx context.Context, id string) (bool, error)
+}
+
+// MemoryDedupeStore is a DedupeStore keeping the last size ids in memory.
+type MemoryDedupeStore struct {
+	size int
+
+	mu    sync.Mutex
+	ids   map[string]*list.Element
+	order *list.List
+}
+
+func NewMemoryDedupeStore(size int) *MemoryDedupeStore {
+	if size <= 0 {
+		size = defaultDedupeStoreSize
+	}
+
+	return &MemoryDedupeStore{
+		size:  size,
+		ids:   make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (s *MemoryDedupeStore) Seen(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.ids[id]; ok {
+		s.order.MoveToFront(e)
+		return true, nil
+	}
+
+	s.ids[id] = s.order.PushFront(id)
+	if s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.ids, oldest.Value.(string))
+	}
+
+	return false, nil
+}
+
+// isDuplicate treats store failures as unseen messages,
+// delivering twice is safer than losing an event.
+func isDuplicate(ctx context.Context, store DedupeStore, id string) bool {
+	if store == nil || id == "" {
+		return false
+	}
+
+	seen, err := store.Seen(ctx, id)
+	return err == nil && seen
+}
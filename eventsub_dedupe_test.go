@@ -0,0 +1,58 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryDedupeStore(t *testing.T) {
+	t.Run("must report seen ids", func(t *testing.T) {
+		s := NewMemoryDedupeStore(10)
+		ctx := context.Background()
+
+		if seen, _ := s.Seen(ctx, "1"); seen {
+			t.Error("new id must not be seen")
+		}
+
+		if seen, _ := s.Seen(ctx, "1"); !seen {
+			t.Error("recorded id must be seen")
+		}
+	})
+
+	t.Run("must evict least recently seen ids", func(t *testing.T) {
+		s := NewMemoryDedupeStore(2)
+		ctx := context.Background()
+
+		s.Seen(ctx, "1")
+		s.Seen(ctx, "2")
+		s.Seen(ctx, "1")
+		s.Seen(ctx, "3")
+
+		if seen, _ := s.Seen(ctx, "1"); !seen {
+			t.Error("recently seen id must be kept")
+		}
+
+		if seen, _ := s.Seen(ctx, "2"); seen {
+			t.Error("least recently seen id must be evicted")
+		}
+	})
+}
+
+type failingDedupeStore struct{}
+
+func (failingDedupeStore) Seen(ctx context.Context, id string) (bool, error) {
+	return true, errors.New("store is down")
+}
+
+func TestIsDuplicate(t *testing.T) {
+	ctx := context.Background()
+
+	if isDuplicate(ctx, nil, "1") {
+		t.Error("nil store must not report duplicates")
+	}
+
+	if isDuplicate(ctx, failingDedupeStore{}, "1") {
+		t.Error("failing store must not report duplicates")
+	}
+}
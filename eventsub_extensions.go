@@ -0,0 +1,47 @@
+package twitch
+
+const (
+	EventSubDropEntitlementGrant           = "drop.entitlement.grant"
+	EventSubExtensionBitsTransactionCreate = "extension.bits_transaction.create"
+)
+
+type DropEntitlement struct {
+	OrganizationId string    `json:"organization_id,omitempty"`
+	CategoryId     string    `json:"category_id,omitempty"`
+	CategoryName   string    `json:"category_name,omitempty"`
+	CampaignId     string    `json:"campaign_id,omitempty"`
+	UserId         string    `json:"user_id,omitempty"`
+	UserLogin      string    `json:"user_login,omitempty"`
+	Username       string    `json:"user_name,omitempty"`
+	EntitlementId  string    `json:"entitlement_id,omitempty"`
+	BenefitId      string    `json:"benefit_id,omitempty"`
+	CreatedAt      Timestamp `json:"created_at,omitempty,omitzero"`
+}
+
+type DropEntitlementGrantEvent struct {
+	Id   string          `json:"id,omitempty"`
+	Data DropEntitlement `json:"data,omitempty"`
+}
+
+// DropEntitlementGrantEvents is the batched payload of drop.entitlement.grant,
+// Twitch sends it in the "events" field of a notification instead of "event".
+type DropEntitlementGrantEvents []*DropEntitlementGrantEvent
+
+type ExtensionProduct struct {
+	Name          string `json:"name,omitempty"`
+	Sku           string `json:"sku,omitempty"`
+	Bits          int    `json:"bits,omitempty"`
+	InDevelopment bool   `json:"in_development,omitempty"`
+}
+
+type ExtensionBitsTransactionCreateEvent struct {
+	Id                   string           `json:"id,omitempty"`
+	ExtensionClientId    string           `json:"extension_client_id,omitempty"`
+	BroadcasterUserId    string           `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string           `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string           `json:"broadcaster_user_name,omitempty"`
+	UserId               string           `json:"user_id,omitempty"`
+	UserLogin            string           `json:"user_login,omitempty"`
+	Username             string           `json:"user_name,omitempty"`
+	Product              ExtensionProduct `json:"product,omitempty"`
+}
@@ -0,0 +1,59 @@
+package twitch
+
+import "testing"
+
+func TestDropEntitlementGrantEvents(t *testing.T) {
+	data := `[{
+		"id": "bf7c8577-e3e2-4ab3-9d94-d1f8ad4b2d13",
+		"data": {
+			"organization_id": "9001",
+			"category_id": "9002",
+			"category_name": "Fortnite",
+			"campaign_id": "9003",
+			"user_id": "1234",
+			"user_name": "Cool_User",
+			"user_login": "cool_user",
+			"entitlement_id": "fb78259e-fb81-4d1b-8333-34a06ffc24c0",
+			"benefit_id": "74c52265-e214-48a6-91b9-23b6014e8041",
+			"created_at": ` + referenceTimeStr + `
+		}
+	}]`
+
+	want := &DropEntitlementGrantEvents{{
+		Id: "bf7c8577-e3e2-4ab3-9d94-d1f8ad4b2d13",
+		Data: DropEntitlement{
+			OrganizationId: "9001",
+			CategoryId:     "9002",
+			CategoryName:   "Fortnite",
+			CampaignId:     "9003",
+			UserId:         "1234",
+			UserLogin:      "cool_user",
+			Username:       "Cool_User",
+			EntitlementId:  "fb78259e-fb81-4d1b-8333-34a06ffc24c0",
+			BenefitId:      "74c52265-e214-48a6-91b9-23b6014e8041",
+			CreatedAt:      Timestamp{referenceTime},
+		},
+	}}
+
+	assertJSONUnmarshal(t, data, new(DropEntitlementGrantEvents), want)
+}
+
+func TestExtensionBitsTransactionCreateEvent(t *testing.T) {
+	data := `{
+		"extension_client_id": "deadbeef",
+		"broadcaster_user_id": "1337",
+		"user_id": "1236",
+		"id": "bits-tx-id",
+		"product": {"name": "great_product", "sku": "skuskusku", "bits": 1234, "in_development": false}
+	}`
+
+	want := &ExtensionBitsTransactionCreateEvent{
+		Id:                "bits-tx-id",
+		ExtensionClientId: "deadbeef",
+		BroadcasterUserId: "1337",
+		UserId:            "1236",
+		Product:           ExtensionProduct{Name: "great_product", Sku: "skuskusku", Bits: 1234},
+	}
+
+	assertJSONUnmarshal(t, data, new(ExtensionBitsTransactionCreateEvent), want)
+}
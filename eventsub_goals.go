@@ -0,0 +1,86 @@
+package twitch
+
+import "math"
+
+const (
+	EventSubChannelGoalBegin               = "channel.goal.begin"
+	EventSubChannelGoalProgress            = "channel.goal.progress"
+	EventSubChannelGoalEnd                 = "channel.goal.end"
+	EventSubChannelCharityCampaignDonate   = "channel.charity_campaign.donate"
+	EventSubChannelCharityCampaignStart    = "channel.charity_campaign.start"
+	EventSubChannelCharityCampaignProgress = "channel.charity_campaign.progress"
+	EventSubChannelCharityCampaignStop     = "channel.charity_campaign.stop"
+)
+
+// Amount is a monetary value in the currency's minor units,
+// e.g. 1050 with 2 decimal places is 10.50.
+type Amount struct {
+	Value         int    `json:"value,omitempty"`
+	DecimalPlaces int    `json:"decimal_places,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+}
+
+func (a Amount) Float64() float64 {
+	return float64(a.Value) / math.Pow10(a.DecimalPlaces)
+}
+
+type ChannelGoalBeginEvent struct {
+	Id                   string    `json:"id,omitempty"`
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	Type                 string    `json:"type,omitempty"`
+	Description          string    `json:"description,omitempty"`
+	CurrentAmount        int       `json:"current_amount,omitempty"`
+	TargetAmount         int       `json:"target_amount,omitempty"`
+	StartedAt            Timestamp `json:"started_at,omitempty,omitzero"`
+}
+
+type ChannelGoalProgressEvent ChannelGoalBeginEvent
+
+type ChannelGoalEndEvent struct {
+	Id                   string    `json:"id,omitempty"`
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	Type                 string    `json:"type,omitempty"`
+	Description          string    `json:"description,omitempty"`
+	IsAchieved           bool      `json:"is_achieved,omitempty"`
+	CurrentAmount        int       `json:"current_amount,omitempty"`
+	TargetAmount         int       `json:"target_amount,omitempty"`
+	StartedAt            Timestamp `json:"started_at,omitempty,omitzero"`
+	EndedAt              Timestamp `json:"ended_at,omitempty,omitzero"`
+}
+
+type ChannelCharityDonationEvent struct {
+	Id                   string `json:"id,omitempty"`
+	CampaignId           string `json:"campaign_id,omitempty"`
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	UserId               string `json:"user_id,omitempty"`
+	UserLogin            string `json:"user_login,omitempty"`
+	Username             string `json:"user_name,omitempty"`
+	CharityName          string `json:"charity_name,omitempty"`
+	CharityDescription   string `json:"charity_description,omitempty"`
+	CharityLogo          string `json:"charity_logo,omitempty"`
+	CharityWebsite       string `json:"charity_website,omitempty"`
+	Amount               Amount `json:"amount,omitempty"`
+}
+
+// ChannelCharityCampaignEvent is sent for charity campaign start, progress
+// and stop events. StartedAt is only set on start, StoppedAt only on stop.
+type ChannelCharityCampaignEvent struct {
+	Id                   string    `json:"id,omitempty"`
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	CharityName          string    `json:"charity_name,omitempty"`
+	CharityDescription   string    `json:"charity_description,omitempty"`
+	CharityLogo          string    `json:"charity_logo,omitempty"`
+	CharityWebsite       string    `json:"charity_website,omitempty"`
+	CurrentAmount        Amount    `json:"current_amount,omitempty"`
+	TargetAmount         Amount    `json:"target_amount,omitempty"`
+	StartedAt            Timestamp `json:"started_at,omitempty,omitzero"`
+	StoppedAt            Timestamp `json:"stopped_at,omitempty,omitzero"`
+}
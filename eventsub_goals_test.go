@@ -0,0 +1,82 @@
+package twitch
+
+import "testing"
+
+func TestChannelGoalEndEvent(t *testing.T) {
+	data := `{
+		"id": "12345-abc-678-defgh",
+		"broadcaster_user_id": "141981764",
+		"type": "subscription",
+		"description": "Help me get partner!",
+		"is_achieved": false,
+		"current_amount": 180,
+		"target_amount": 220,
+		"started_at": ` + referenceTimeStr + `,
+		"ended_at": ` + referenceTimeStr + `
+	}`
+
+	want := &ChannelGoalEndEvent{
+		Id:                "12345-abc-678-defgh",
+		BroadcasterUserId: "141981764",
+		Type:              "subscription",
+		Description:       "Help me get partner!",
+		CurrentAmount:     180,
+		TargetAmount:      220,
+		StartedAt:         Timestamp{referenceTime},
+		EndedAt:           Timestamp{referenceTime},
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelGoalEndEvent), want)
+}
+
+func TestChannelCharityEvents(t *testing.T) {
+	t.Run("donation must contain amount", func(t *testing.T) {
+		data := `{
+			"id": "a1b2c3-aabb-4455-d1e2f3",
+			"campaign_id": "123-abc-456-def",
+			"broadcaster_user_id": "123456",
+			"user_id": "654321",
+			"charity_name": "Example name",
+			"amount": {"value": 10000, "decimal_places": 2, "currency": "USD"}
+		}`
+
+		want := &ChannelCharityDonationEvent{
+			Id:                "a1b2c3-aabb-4455-d1e2f3",
+			CampaignId:        "123-abc-456-def",
+			BroadcasterUserId: "123456",
+			UserId:            "654321",
+			CharityName:       "Example name",
+			Amount:            Amount{Value: 10000, DecimalPlaces: 2, Currency: "USD"},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelCharityDonationEvent), want)
+	})
+
+	t.Run("campaign must contain current and target amounts", func(t *testing.T) {
+		data := `{
+			"id": "123-abc-456-def",
+			"current_amount": {"value": 260000, "decimal_places": 2, "currency": "USD"},
+			"target_amount": {"value": 1500000, "decimal_places": 2, "currency": "USD"},
+			"stopped_at": ` + referenceTimeStr + `
+		}`
+
+		want := &ChannelCharityCampaignEvent{
+			Id:            "123-abc-456-def",
+			CurrentAmount: Amount{Value: 260000, DecimalPlaces: 2, Currency: "USD"},
+			TargetAmount:  Amount{Value: 1500000, DecimalPlaces: 2, Currency: "USD"},
+			StoppedAt:     Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelCharityCampaignEvent), want)
+	})
+}
+
+func TestAmountFloat64(t *testing.T) {
+	if got, want := (Amount{Value: 1050, DecimalPlaces: 2}).Float64(), 10.5; got != want {
+		t.Errorf("\ngot: %v\nwant: %v", got, want)
+	}
+
+	if got, want := (Amount{Value: 7}).Float64(), 7.0; got != want {
+		t.Errorf("\ngot: %v\nwant: %v", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package twitch
+
+const (
+	EventSubChannelGuestStarSessionBegin   = "channel.guest_star_session.begin"
+	EventSubChannelGuestStarSessionEnd     = "channel.guest_star_session.end"
+	EventSubChannelGuestStarGuestUpdate    = "channel.guest_star_guest.update"
+	EventSubChannelGuestStarSlotUpdate     = "channel.guest_star_slot.update"
+	EventSubChannelGuestStarSettingsUpdate = "channel.guest_star_settings.update"
+
+	GuestStarStateInvited   = "invited"
+	GuestStarStateAccepted  = "accepted"
+	GuestStarStateReady     = "ready"
+	GuestStarStateBackstage = "backstage"
+	GuestStarStateLive      = "live"
+	GuestStarStateRemoved   = "removed"
+)
+
+type ChannelGuestStarSessionBeginEvent struct {
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	SessionId            string    `json:"session_id,omitempty"`
+	StartedAt            Timestamp `json:"started_at,omitempty,omitzero"`
+}
+
+type ChannelGuestStarSessionEndEvent struct {
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	SessionId            string    `json:"session_id,omitempty"`
+	StartedAt            Timestamp `json:"started_at,omitempty,omitzero"`
+	EndedAt              Timestamp `json:"ended_at,omitempty,omitzero"`
+	HostUserId           string    `json:"host_user_id,omitempty"`
+	HostUserLogin        string    `json:"host_user_login,omitempty"`
+	HostUserName         string    `json:"host_user_name,omitempty"`
+}
+
+type ChannelGuestStarGuestUpdateEvent struct {
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	SessionId            string `json:"session_id,omitempty"`
+	ModeratorUserId      string `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string `json:"moderator_user_name,omitempty"`
+	GuestUserId          string `json:"guest_user_id,omitempty"`
+	GuestUserLogin       string `json:"guest_user_login,omitempty"`
+	GuestUserName        string `json:"guest_user_name,omitempty"`
+	SlotId               string `json:"slot_id,omitempty"`
+	State                string `json:"state,omitempty"`
+	HostUserId           string `json:"host_user_id,omitempty"`
+	HostUserLogin        string `json:"host_user_login,omitempty"`
+	HostUserName         string `json:"host_user_name,omitempty"`
+	HostVideoEnabled     bool   `json:"host_video_enabled,omitempty"`
+	HostAudioEnabled     bool   `json:"host_audio_enabled,omitempty"`
+	HostVolume           int    `json:"host_volume,omitempty"`
+}
+
+type ChannelGuestStarSlotUpdateEvent struct {
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	SessionId            string `json:"session_id,omitempty"`
+	ModeratorUserId      string `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string `json:"moderator_user_name,omitempty"`
+	GuestUserId          string `json:"guest_user_id,omitempty"`
+	GuestUserLogin       string `json:"guest_user_login,omitempty"`
+	GuestUserName        string `json:"guest_user_name,omitempty"`
+	SlotId               string `json:"slot_id,omitempty"`
+	HostVideoEnabled     bool   `json:"host_video_enabled,omitempty"`
+	HostAudioEnabled     bool   `json:"host_audio_enabled,omitempty"`
+	HostVolume           int    `json:"host_volume,omitempty"`
+}
+
+type ChannelGuestStarSettingsUpdateEvent struct {
+	BroadcasterUserId           string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin        string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName         string `json:"broadcaster_user_name,omitempty"`
+	IsModeratorSendLiveEnabled  bool   `json:"is_moderator_send_live_enabled,omitempty"`
+	SlotCount                   int    `json:"slot_count,omitempty"`
+	IsBrowserSourceAudioEnabled bool   `json:"is_browser_source_audio_enabled,omitempty"`
+	GroupLayout                 string `json:"group_layout,omitempty"`
+}
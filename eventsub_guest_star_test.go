@@ -0,0 +1,55 @@
+package twitch
+
+import "testing"
+
+func TestChannelGuestStarEvents(t *testing.T) {
+	t.Run("session end event must contain host", func(t *testing.T) {
+		data := `{
+			"broadcaster_user_id": "1337",
+			"session_id": "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+			"started_at": ` + referenceTimeStr + `,
+			"ended_at": ` + referenceTimeStr + `,
+			"host_user_id": "1337",
+			"host_user_login": "cool_user",
+			"host_user_name": "Cool_User"
+		}`
+
+		want := &ChannelGuestStarSessionEndEvent{
+			BroadcasterUserId: "1337",
+			SessionId:         "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+			StartedAt:         Timestamp{referenceTime},
+			EndedAt:           Timestamp{referenceTime},
+			HostUserId:        "1337",
+			HostUserLogin:     "cool_user",
+			HostUserName:      "Cool_User",
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelGuestStarSessionEndEvent), want)
+	})
+
+	t.Run("guest update event must contain slot state", func(t *testing.T) {
+		data := `{
+			"broadcaster_user_id": "1337",
+			"session_id": "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+			"guest_user_id": "1234",
+			"slot_id": "1",
+			"state": "live",
+			"host_video_enabled": true,
+			"host_audio_enabled": true,
+			"host_volume": 100
+		}`
+
+		want := &ChannelGuestStarGuestUpdateEvent{
+			BroadcasterUserId: "1337",
+			SessionId:         "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+			GuestUserId:       "1234",
+			SlotId:            "1",
+			State:             GuestStarStateLive,
+			HostVideoEnabled:  true,
+			HostAudioEnabled:  true,
+			HostVolume:        100,
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelGuestStarGuestUpdateEvent), want)
+	})
+}
@@ -0,0 +1,73 @@
+package twitch
+
+const (
+	EventSubChannelHypeTrainBegin    = "channel.hype_train.begin"
+	EventSubChannelHypeTrainProgress = "channel.hype_train.progress"
+	EventSubChannelHypeTrainEnd      = "channel.hype_train.end"
+)
+
+// HypeTrainContributionType is the type of a contribution to a hype train.
+type HypeTrainContributionType string
+
+const (
+	HypeTrainContributionBits         HypeTrainContributionType = "bits"
+	HypeTrainContributionSubscription HypeTrainContributionType = "subscription"
+	HypeTrainContributionOther        HypeTrainContributionType = "other"
+)
+
+func (t HypeTrainContributionType) Valid() bool {
+	switch t {
+	case HypeTrainContributionBits, HypeTrainContributionSubscription, HypeTrainContributionOther:
+		return true
+	default:
+		return false
+	}
+}
+
+type HypeTrainContribution struct {
+	UserId    string                    `json:"user_id,omitempty"`
+	UserLogin string                    `json:"user_login,omitempty"`
+	Username  string                    `json:"user_name,omitempty"`
+	Type      HypeTrainContributionType `json:"type,omitempty"`
+	Total     int                       `json:"total,omitempty"`
+}
+
+type ChannelHypeTrainBeginEvent struct {
+	Id                   string                   `json:"id,omitempty"`
+	BroadcasterUserId    string                   `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string                   `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string                   `json:"broadcaster_user_name,omitempty"`
+	Level                int                      `json:"level,omitempty"`
+	Total                int                      `json:"total,omitempty"`
+	Progress             int                      `json:"progress,omitempty"`
+	Goal                 int                      `json:"goal,omitempty"`
+	TopContributions     []*HypeTrainContribution `json:"top_contributions,omitempty"`
+	LastContribution     *HypeTrainContribution   `json:"last_contribution,omitempty"`
+	StartedAt            Timestamp                `json:"started_at,omitempty,omitzero"`
+	ExpiresAt            Timestamp                `json:"expires_at,omitempty,omitzero"`
+}
+
+type ChannelHypeTrainProgressEvent ChannelHypeTrainBeginEvent
+
+type ChannelHypeTrainEndEvent struct {
+	Id                   string                   `json:"id,omitempty"`
+	BroadcasterUserId    string                   `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string                   `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string                   `json:"broadcaster_user_name,omitempty"`
+	Level                int                      `json:"level,omitempty"`
+	Total                int                      `json:"total,omitempty"`
+	TopContributions     []*HypeTrainContribution `json:"top_contributions,omitempty"`
+	StartedAt            Timestamp                `json:"started_at,omitempty,omitzero"`
+	EndedAt              Timestamp                `json:"ended_at,omitempty,omitzero"`
+	CooldownEndsAt       Timestamp                `json:"cooldown_ends_at,omitempty,omitzero"`
+}
+
+// ContributionsByType sums top contributions per contribution type.
+func ContributionsByType(contributions []*HypeTrainContribution) map[HypeTrainContributionType]int {
+	totals := make(map[HypeTrainContributionType]int)
+	for _, c := range contributions {
+		totals[c.Type] += c.Total
+	}
+
+	return totals
+}
@@ -0,0 +1,75 @@
+package twitch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChannelHypeTrainEvents(t *testing.T) {
+	t.Run("progress event must contain contributions", func(t *testing.T) {
+		data := `{
+			"id": "1b0AsbInCHZW2SQFQkCzqN07Ib2",
+			"broadcaster_user_id": "1337",
+			"level": 2,
+			"total": 700,
+			"progress": 200,
+			"goal": 1000,
+			"top_contributions": [
+				{"user_id": "123", "user_login": "pogchamp", "user_name": "PogChamp", "type": "bits", "total": 50},
+				{"user_id": "456", "user_login": "kappa", "user_name": "Kappa", "type": "subscription", "total": 45}
+			],
+			"last_contribution": {"user_id": "123", "user_login": "pogchamp", "user_name": "PogChamp", "type": "bits", "total": 50},
+			"started_at": ` + referenceTimeStr + `,
+			"expires_at": ` + referenceTimeStr + `
+		}`
+
+		bits := &HypeTrainContribution{UserId: "123", UserLogin: "pogchamp", Username: "PogChamp", Type: HypeTrainContributionBits, Total: 50}
+		want := &ChannelHypeTrainProgressEvent{
+			Id:                "1b0AsbInCHZW2SQFQkCzqN07Ib2",
+			BroadcasterUserId: "1337",
+			Level:             2,
+			Total:             700,
+			Progress:          200,
+			Goal:              1000,
+			TopContributions: []*HypeTrainContribution{
+				bits,
+				{UserId: "456", UserLogin: "kappa", Username: "Kappa", Type: HypeTrainContributionSubscription, Total: 45},
+			},
+			LastContribution: bits,
+			StartedAt:        Timestamp{referenceTime},
+			ExpiresAt:        Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelHypeTrainProgressEvent), want)
+	})
+
+	t.Run("end event must contain cooldown", func(t *testing.T) {
+		data := `{"id": "1", "level": 5, "ended_at": ` + referenceTimeStr + `, "cooldown_ends_at": ` + referenceTimeStr + `}`
+
+		want := &ChannelHypeTrainEndEvent{
+			Id:             "1",
+			Level:          5,
+			EndedAt:        Timestamp{referenceTime},
+			CooldownEndsAt: Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelHypeTrainEndEvent), want)
+	})
+}
+
+func TestContributionsByType(t *testing.T) {
+	got := ContributionsByType([]*HypeTrainContribution{
+		{Type: HypeTrainContributionBits, Total: 50},
+		{Type: HypeTrainContributionBits, Total: 25},
+		{Type: HypeTrainContributionSubscription, Total: 500},
+	})
+
+	want := map[HypeTrainContributionType]int{
+		HypeTrainContributionBits:         75,
+		HypeTrainContributionSubscription: 500,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot: %v\nwant: %v", got, want)
+	}
+}
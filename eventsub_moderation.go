@@ -0,0 +1,271 @@
+package twitch
+
+const (
+	EventSubChannelBan      = "channel.ban"
+	EventSubChannelUnban    = "channel.unban"
+	EventSubChannelModerate = "channel.moderate"
+
+	EventSubChannelShieldModeBegin = "channel.shield_mode.begin"
+	EventSubChannelShieldModeEnd   = "channel.shield_mode.end"
+
+	EventSubChannelWarningSend        = "channel.warning.send"
+	EventSubChannelWarningAcknowledge = "channel.warning.acknowledge"
+
+	EventSubChannelSuspiciousUserMessage = "channel.suspicious_user.message"
+	EventSubChannelSuspiciousUserUpdate  = "channel.suspicious_user.update"
+	EventSubChannelUnbanRequestCreate    = "channel.unban_request.create"
+	EventSubChannelUnbanRequestResolve   = "channel.unban_request.resolve"
+
+	ModerateActionBan                 = "ban"
+	ModerateActionTimeout             = "timeout"
+	ModerateActionUnban               = "unban"
+	ModerateActionUntimeout           = "untimeout"
+	ModerateActionClear               = "clear"
+	ModerateActionEmoteOnly           = "emoteonly"
+	ModerateActionEmoteOnlyOff        = "emoteonlyoff"
+	ModerateActionFollowers           = "followers"
+	ModerateActionFollowersOff        = "followersoff"
+	ModerateActionUniqueChat          = "uniquechat"
+	ModerateActionUniqueChatOff       = "uniquechatoff"
+	ModerateActionSlow                = "slow"
+	ModerateActionSlowOff             = "slowoff"
+	ModerateActionSubscribers         = "subscribers"
+	ModerateActionSubscribersOff      = "subscribersoff"
+	ModerateActionRaid                = "raid"
+	ModerateActionUnraid              = "unraid"
+	ModerateActionDelete              = "delete"
+	ModerateActionVip                 = "vip"
+	ModerateActionUnvip               = "unvip"
+	ModerateActionMod                 = "mod"
+	ModerateActionUnmod               = "unmod"
+	ModerateActionAddBlockedTerm      = "add_blocked_term"
+	ModerateActionAddPermittedTerm    = "add_permitted_term"
+	ModerateActionRemoveBlockedTerm   = "remove_blocked_term"
+	ModerateActionRemovePermittedTerm = "remove_permitted_term"
+	ModerateActionApproveUnbanRequest = "approve_unban_request"
+	ModerateActionDenyUnbanRequest    = "deny_unban_request"
+	ModerateActionWarn                = "warn"
+)
+
+type ChannelBanEvent struct {
+	UserId               string    `json:"user_id,omitempty"`
+	UserLogin            string    `json:"user_login,omitempty"`
+	Username             string    `json:"user_name,omitempty"`
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string    `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string    `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string    `json:"moderator_user_name,omitempty"`
+	Reason               string    `json:"reason,omitempty"`
+	BannedAt             Timestamp `json:"banned_at,omitempty,omitzero"`
+	// EndsAt is nil for permanent bans.
+	EndsAt      *Timestamp `json:"ends_at,omitempty"`
+	IsPermanent bool       `json:"is_permanent,omitempty"`
+}
+
+type ChannelUnbanEvent struct {
+	UserId               string `json:"user_id,omitempty"`
+	UserLogin            string `json:"user_login,omitempty"`
+	Username             string `json:"user_name,omitempty"`
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string `json:"moderator_user_name,omitempty"`
+}
+
+// ModerateUser is the target of vip, unvip, mod, unmod, unban,
+// untimeout and unraid actions.
+type ModerateUser struct {
+	UserId    string `json:"user_id,omitempty"`
+	UserLogin string `json:"user_login,omitempty"`
+	Username  string `json:"user_name,omitempty"`
+}
+
+type ModerateFollowers struct {
+	FollowDurationMinutes int `json:"follow_duration_minutes,omitempty"`
+}
+
+type ModerateSlow struct {
+	WaitTimeSeconds int `json:"wait_time_seconds,omitempty"`
+}
+
+type ModerateBan struct {
+	UserId    string `json:"user_id,omitempty"`
+	UserLogin string `json:"user_login,omitempty"`
+	Username  string `json:"user_name,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type ModerateTimeout struct {
+	UserId    string    `json:"user_id,omitempty"`
+	UserLogin string    `json:"user_login,omitempty"`
+	Username  string    `json:"user_name,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	ExpiresAt Timestamp `json:"expires_at,omitempty,omitzero"`
+}
+
+type ModerateRaid struct {
+	UserId      string `json:"user_id,omitempty"`
+	UserLogin   string `json:"user_login,omitempty"`
+	Username    string `json:"user_name,omitempty"`
+	ViewerCount int    `json:"viewer_count,omitempty"`
+}
+
+type ModerateDelete struct {
+	UserId      string `json:"user_id,omitempty"`
+	UserLogin   string `json:"user_login,omitempty"`
+	Username    string `json:"user_name,omitempty"`
+	MessageId   string `json:"message_id,omitempty"`
+	MessageBody string `json:"message_body,omitempty"`
+}
+
+type ModerateAutoModTerms struct {
+	Action      string   `json:"action,omitempty"`
+	List        string   `json:"list,omitempty"`
+	Terms       []string `json:"terms,omitempty"`
+	FromAutoMod bool     `json:"from_automod,omitempty"`
+}
+
+type ModerateUnbanRequest struct {
+	IsApproved       bool   `json:"is_approved,omitempty"`
+	UserId           string `json:"user_id,omitempty"`
+	UserLogin        string `json:"user_login,omitempty"`
+	Username         string `json:"user_name,omitempty"`
+	ModeratorMessage string `json:"moderator_message,omitempty"`
+}
+
+type ModerateWarn struct {
+	UserId         string   `json:"user_id,omitempty"`
+	UserLogin      string   `json:"user_login,omitempty"`
+	Username       string   `json:"user_name,omitempty"`
+	Reason         string   `json:"reason,omitempty"`
+	ChatRulesCited []string `json:"chat_rules_cited,omitempty"`
+}
+
+// ChannelModerateEvent carries one action-specific object,
+// which one is set depends on Action.
+type ChannelModerateEvent struct {
+	BroadcasterUserId    string                `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string                `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string                `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string                `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string                `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string                `json:"moderator_user_name,omitempty"`
+	Action               string                `json:"action,omitempty"`
+	Followers            *ModerateFollowers    `json:"followers,omitempty"`
+	Slow                 *ModerateSlow         `json:"slow,omitempty"`
+	Vip                  *ModerateUser         `json:"vip,omitempty"`
+	Unvip                *ModerateUser         `json:"unvip,omitempty"`
+	Mod                  *ModerateUser         `json:"mod,omitempty"`
+	Unmod                *ModerateUser         `json:"unmod,omitempty"`
+	Ban                  *ModerateBan          `json:"ban,omitempty"`
+	Unban                *ModerateUser         `json:"unban,omitempty"`
+	Timeout              *ModerateTimeout      `json:"timeout,omitempty"`
+	Untimeout            *ModerateUser         `json:"untimeout,omitempty"`
+	Raid                 *ModerateRaid         `json:"raid,omitempty"`
+	Unraid               *ModerateUser         `json:"unraid,omitempty"`
+	Delete               *ModerateDelete       `json:"delete,omitempty"`
+	AutoModTerms         *ModerateAutoModTerms `json:"automod_terms,omitempty"`
+	UnbanRequest         *ModerateUnbanRequest `json:"unban_request,omitempty"`
+	Warn                 *ModerateWarn         `json:"warn,omitempty"`
+}
+
+type ChannelShieldModeBeginEvent struct {
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string    `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string    `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string    `json:"moderator_user_name,omitempty"`
+	StartedAt            Timestamp `json:"started_at,omitempty,omitzero"`
+}
+
+type ChannelShieldModeEndEvent struct {
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string    `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string    `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string    `json:"moderator_user_name,omitempty"`
+	EndedAt              Timestamp `json:"ended_at,omitempty,omitzero"`
+}
+
+type ChannelWarningSendEvent struct {
+	BroadcasterUserId    string   `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string   `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string   `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string   `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string   `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string   `json:"moderator_user_name,omitempty"`
+	UserId               string   `json:"user_id,omitempty"`
+	UserLogin            string   `json:"user_login,omitempty"`
+	Username             string   `json:"user_name,omitempty"`
+	Reason               string   `json:"reason,omitempty"`
+	ChatRulesCited       []string `json:"chat_rules_cited,omitempty"`
+}
+
+type ChannelWarningAcknowledgeEvent struct {
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	UserId               string `json:"user_id,omitempty"`
+	UserLogin            string `json:"user_login,omitempty"`
+	Username             string `json:"user_name,omitempty"`
+}
+
+type ChannelSuspiciousUserMessageEvent struct {
+	BroadcasterUserId    string      `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string      `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string      `json:"broadcaster_user_name,omitempty"`
+	UserId               string      `json:"user_id,omitempty"`
+	UserLogin            string      `json:"user_login,omitempty"`
+	Username             string      `json:"user_name,omitempty"`
+	LowTrustStatus       string      `json:"low_trust_status,omitempty"`
+	SharedBanChannelIds  []string    `json:"shared_ban_channel_ids,omitempty"`
+	Types                []string    `json:"types,omitempty"`
+	BanEvasionEvaluation string      `json:"ban_evasion_evaluation,omitempty"`
+	Message              ChatMessage `json:"message,omitempty"`
+}
+
+type ChannelSuspiciousUserUpdateEvent struct {
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string `json:"moderator_user_name,omitempty"`
+	UserId               string `json:"user_id,omitempty"`
+	UserLogin            string `json:"user_login,omitempty"`
+	Username             string `json:"user_name,omitempty"`
+	LowTrustStatus       string `json:"low_trust_status,omitempty"`
+}
+
+type ChannelUnbanRequestCreateEvent struct {
+	Id                   string    `json:"id,omitempty"`
+	BroadcasterUserId    string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name,omitempty"`
+	UserId               string    `json:"user_id,omitempty"`
+	UserLogin            string    `json:"user_login,omitempty"`
+	Username             string    `json:"user_name,omitempty"`
+	Text                 string    `json:"text,omitempty"`
+	CreatedAt            Timestamp `json:"created_at,omitempty,omitzero"`
+}
+
+type ChannelUnbanRequestResolveEvent struct {
+	Id                   string `json:"id,omitempty"`
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId      string `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin   string `json:"moderator_user_login,omitempty"`
+	ModeratorUserName    string `json:"moderator_user_name,omitempty"`
+	UserId               string `json:"user_id,omitempty"`
+	UserLogin            string `json:"user_login,omitempty"`
+	Username             string `json:"user_name,omitempty"`
+	ResolutionText       string `json:"resolution_text,omitempty"`
+	Status               string `json:"status,omitempty"`
+}
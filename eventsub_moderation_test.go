@@ -0,0 +1,226 @@
+package twitch
+
+import "testing"
+
+func TestChannelBanEvent(t *testing.T) {
+	t.Run("timeout must contain ends_at", func(t *testing.T) {
+		data := `{
+			"user_id": "1234",
+			"user_login": "cool_user",
+			"user_name": "Cool_User",
+			"broadcaster_user_id": "1337",
+			"moderator_user_id": "1339",
+			"reason": "Offensive language",
+			"banned_at": ` + referenceTimeStr + `,
+			"ends_at": ` + referenceTimeStr + `,
+			"is_permanent": false
+		}`
+
+		want := &ChannelBanEvent{
+			UserId:            "1234",
+			UserLogin:         "cool_user",
+			Username:          "Cool_User",
+			BroadcasterUserId: "1337",
+			ModeratorUserId:   "1339",
+			Reason:            "Offensive language",
+			BannedAt:          Timestamp{referenceTime},
+			EndsAt:            &Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelBanEvent), want)
+	})
+
+	t.Run("permanent ban must have nil ends_at", func(t *testing.T) {
+		data := `{"user_id": "1234", "ends_at": null, "is_permanent": true}`
+
+		want := &ChannelBanEvent{UserId: "1234", IsPermanent: true}
+
+		assertJSONUnmarshal(t, data, new(ChannelBanEvent), want)
+	})
+}
+
+func TestChannelModerateEvent(t *testing.T) {
+	t.Run("timeout action must be decoded", func(t *testing.T) {
+		data := `{
+			"broadcaster_user_id": "1337",
+			"moderator_user_id": "1339",
+			"action": "timeout",
+			"followers": null,
+			"slow": null,
+			"timeout": {
+				"user_id": "1234",
+				"user_login": "cool_user",
+				"user_name": "Cool_User",
+				"reason": "spam",
+				"expires_at": ` + referenceTimeStr + `
+			}
+		}`
+
+		want := &ChannelModerateEvent{
+			BroadcasterUserId: "1337",
+			ModeratorUserId:   "1339",
+			Action:            ModerateActionTimeout,
+			Timeout: &ModerateTimeout{
+				UserId:    "1234",
+				UserLogin: "cool_user",
+				Username:  "Cool_User",
+				Reason:    "spam",
+				ExpiresAt: Timestamp{referenceTime},
+			},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelModerateEvent), want)
+	})
+
+	t.Run("automod terms action must be decoded", func(t *testing.T) {
+		data := `{
+			"action": "add_blocked_term",
+			"automod_terms": {"action": "add", "list": "blocked", "terms": ["kek"], "from_automod": true}
+		}`
+
+		want := &ChannelModerateEvent{
+			Action: ModerateActionAddBlockedTerm,
+			AutoModTerms: &ModerateAutoModTerms{
+				Action:      "add",
+				List:        "blocked",
+				Terms:       []string{"kek"},
+				FromAutoMod: true,
+			},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelModerateEvent), want)
+	})
+}
+
+func TestChannelShieldModeEvents(t *testing.T) {
+	t.Run("begin event must contain moderator", func(t *testing.T) {
+		data := `{
+			"broadcaster_user_id": "12345",
+			"broadcaster_user_login": "simplysimple",
+			"broadcaster_user_name": "SimplySimple",
+			"moderator_user_id": "98765",
+			"moderator_user_login": "particularlyparticular123",
+			"moderator_user_name": "ParticularlyParticular123",
+			"started_at": ` + referenceTimeStr + `
+		}`
+
+		want := &ChannelShieldModeBeginEvent{
+			BroadcasterUserId:    "12345",
+			BroadcasterUserLogin: "simplysimple",
+			BroadcasterUserName:  "SimplySimple",
+			ModeratorUserId:      "98765",
+			ModeratorUserLogin:   "particularlyparticular123",
+			ModeratorUserName:    "ParticularlyParticular123",
+			StartedAt:            Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelShieldModeBeginEvent), want)
+	})
+
+	t.Run("end event must contain end time", func(t *testing.T) {
+		data := `{"broadcaster_user_id": "12345", "moderator_user_id": "98765", "ended_at": ` + referenceTimeStr + `}`
+
+		want := &ChannelShieldModeEndEvent{
+			BroadcasterUserId: "12345",
+			ModeratorUserId:   "98765",
+			EndedAt:           Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelShieldModeEndEvent), want)
+	})
+}
+
+func TestChannelWarningEvents(t *testing.T) {
+	t.Run("send event must contain reason and rules", func(t *testing.T) {
+		data := `{
+			"broadcaster_user_id": "423374343",
+			"moderator_user_id": "141981764",
+			"user_id": "9876",
+			"user_login": "spammer",
+			"user_name": "Spammer",
+			"reason": "Stop doing that!",
+			"chat_rules_cited": ["Rule 1", "Rule 2"]
+		}`
+
+		want := &ChannelWarningSendEvent{
+			BroadcasterUserId: "423374343",
+			ModeratorUserId:   "141981764",
+			UserId:            "9876",
+			UserLogin:         "spammer",
+			Username:          "Spammer",
+			Reason:            "Stop doing that!",
+			ChatRulesCited:    []string{"Rule 1", "Rule 2"},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelWarningSendEvent), want)
+	})
+
+	t.Run("send event without reason must be decoded", func(t *testing.T) {
+		data := `{"user_id": "9876", "reason": null, "chat_rules_cited": null}`
+
+		want := &ChannelWarningSendEvent{UserId: "9876"}
+
+		assertJSONUnmarshal(t, data, new(ChannelWarningSendEvent), want)
+	})
+}
+
+func TestChannelSuspiciousUserEvents(t *testing.T) {
+	data := `{
+		"broadcaster_user_id": "1050263432",
+		"user_id": "1050263434",
+		"user_login": "dcf9d0d2",
+		"user_name": "dcf9d0d2",
+		"low_trust_status": "active_monitoring",
+		"shared_ban_channel_ids": ["100", "200"],
+		"types": ["ban_evader"],
+		"ban_evasion_evaluation": "likely",
+		"message": {"text": "bad stuff pogchamp", "fragments": [{"type": "text", "text": "bad stuff "}]}
+	}`
+
+	want := &ChannelSuspiciousUserMessageEvent{
+		BroadcasterUserId:    "1050263432",
+		UserId:               "1050263434",
+		UserLogin:            "dcf9d0d2",
+		Username:             "dcf9d0d2",
+		LowTrustStatus:       "active_monitoring",
+		SharedBanChannelIds:  []string{"100", "200"},
+		Types:                []string{"ban_evader"},
+		BanEvasionEvaluation: "likely",
+		Message: ChatMessage{
+			Text:      "bad stuff pogchamp",
+			Fragments: []*ChatFragment{{Type: ChatFragmentText, Text: "bad stuff "}},
+		},
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelSuspiciousUserMessageEvent), want)
+}
+
+func TestChannelUnbanRequestEvents(t *testing.T) {
+	t.Run("create event must contain text", func(t *testing.T) {
+		data := `{"id": "60", "broadcaster_user_id": "1337", "user_id": "1339", "text": "unban me", "created_at": ` + referenceTimeStr + `}`
+
+		want := &ChannelUnbanRequestCreateEvent{
+			Id:                "60",
+			BroadcasterUserId: "1337",
+			UserId:            "1339",
+			Text:              "unban me",
+			CreatedAt:         Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelUnbanRequestCreateEvent), want)
+	})
+
+	t.Run("resolve event must contain status", func(t *testing.T) {
+		data := `{"id": "60", "moderator_user_id": "1337", "user_id": "1339", "resolution_text": "no", "status": "denied"}`
+
+		want := &ChannelUnbanRequestResolveEvent{
+			Id:              "60",
+			ModeratorUserId: "1337",
+			UserId:          "1339",
+			ResolutionText:  "no",
+			Status:          "denied",
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelUnbanRequestResolveEvent), want)
+	})
+}
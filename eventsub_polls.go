@@ -0,0 +1,134 @@
+package twitch
+
+const (
+	EventSubChannelPollBegin          = "channel.poll.begin"
+	EventSubChannelPollProgress       = "channel.poll.progress"
+	EventSubChannelPollEnd            = "channel.poll.end"
+	EventSubChannelPredictionBegin    = "channel.prediction.begin"
+	EventSubChannelPredictionProgress = "channel.prediction.progress"
+	EventSubChannelPredictionLock     = "channel.prediction.lock"
+	EventSubChannelPredictionEnd      = "channel.prediction.end"
+)
+
+// PollStatus is the status of an ended poll.
+type PollStatus string
+
+const (
+	PollStatusCompleted  PollStatus = "completed"
+	PollStatusArchived   PollStatus = "archived"
+	PollStatusTerminated PollStatus = "terminated"
+)
+
+func (s PollStatus) Valid() bool {
+	return s == PollStatusCompleted || s == PollStatusArchived || s == PollStatusTerminated
+}
+
+// PredictionStatus is the status of an ended prediction.
+type PredictionStatus string
+
+const (
+	PredictionStatusResolved PredictionStatus = "resolved"
+	PredictionStatusCanceled PredictionStatus = "canceled"
+)
+
+func (s PredictionStatus) Valid() bool {
+	return s == PredictionStatusResolved || s == PredictionStatusCanceled
+}
+
+type PollChoice struct {
+	Id                 string `json:"id,omitempty"`
+	Title              string `json:"title,omitempty"`
+	BitsVotes          int    `json:"bits_votes,omitempty"`
+	ChannelPointsVotes int    `json:"channel_points_votes,omitempty"`
+	Votes              int    `json:"votes,omitempty"`
+}
+
+type PollVoting struct {
+	IsEnabled     bool `json:"is_enabled,omitempty"`
+	AmountPerVote int  `json:"amount_per_vote,omitempty"`
+}
+
+type ChannelPollBeginEvent struct {
+	Id                   string        `json:"id,omitempty"`
+	BroadcasterUserId    string        `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string        `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string        `json:"broadcaster_user_name,omitempty"`
+	Title                string        `json:"title,omitempty"`
+	Choices              []*PollChoice `json:"choices,omitempty"`
+	BitsVoting           PollVoting    `json:"bits_voting,omitempty"`
+	ChannelPointsVoting  PollVoting    `json:"channel_points_voting,omitempty"`
+	StartedAt            Timestamp     `json:"started_at,omitempty,omitzero"`
+	EndsAt               Timestamp     `json:"ends_at,omitempty,omitzero"`
+}
+
+// ChannelPollProgressEvent has the same shape as the begin event,
+// but its choices carry the current vote counts.
+type ChannelPollProgressEvent ChannelPollBeginEvent
+
+type ChannelPollEndEvent struct {
+	Id                   string        `json:"id,omitempty"`
+	BroadcasterUserId    string        `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string        `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string        `json:"broadcaster_user_name,omitempty"`
+	Title                string        `json:"title,omitempty"`
+	Choices              []*PollChoice `json:"choices,omitempty"`
+	BitsVoting           PollVoting    `json:"bits_voting,omitempty"`
+	ChannelPointsVoting  PollVoting    `json:"channel_points_voting,omitempty"`
+	Status               PollStatus    `json:"status,omitempty"`
+	StartedAt            Timestamp     `json:"started_at,omitempty,omitzero"`
+	EndedAt              Timestamp     `json:"ended_at,omitempty,omitzero"`
+}
+
+type PredictionPredictor struct {
+	UserId            string `json:"user_id,omitempty"`
+	UserLogin         string `json:"user_login,omitempty"`
+	Username          string `json:"user_name,omitempty"`
+	ChannelPointsWon  int    `json:"channel_points_won,omitempty"`
+	ChannelPointsUsed int    `json:"channel_points_used,omitempty"`
+}
+
+type PredictionOutcome struct {
+	Id            string                 `json:"id,omitempty"`
+	Title         string                 `json:"title,omitempty"`
+	Color         string                 `json:"color,omitempty"`
+	Users         int                    `json:"users,omitempty"`
+	ChannelPoints int                    `json:"channel_points,omitempty"`
+	TopPredictors []*PredictionPredictor `json:"top_predictors,omitempty"`
+}
+
+type ChannelPredictionBeginEvent struct {
+	Id                   string               `json:"id,omitempty"`
+	BroadcasterUserId    string               `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string               `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string               `json:"broadcaster_user_name,omitempty"`
+	Title                string               `json:"title,omitempty"`
+	Outcomes             []*PredictionOutcome `json:"outcomes,omitempty"`
+	StartedAt            Timestamp            `json:"started_at,omitempty,omitzero"`
+	LocksAt              Timestamp            `json:"locks_at,omitempty,omitzero"`
+}
+
+type ChannelPredictionProgressEvent ChannelPredictionBeginEvent
+
+type ChannelPredictionLockEvent struct {
+	Id                   string               `json:"id,omitempty"`
+	BroadcasterUserId    string               `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string               `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string               `json:"broadcaster_user_name,omitempty"`
+	Title                string               `json:"title,omitempty"`
+	Outcomes             []*PredictionOutcome `json:"outcomes,omitempty"`
+	StartedAt            Timestamp            `json:"started_at,omitempty,omitzero"`
+	LockedAt             Timestamp            `json:"locked_at,omitempty,omitzero"`
+}
+
+type ChannelPredictionEndEvent struct {
+	Id                   string               `json:"id,omitempty"`
+	BroadcasterUserId    string               `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string               `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string               `json:"broadcaster_user_name,omitempty"`
+	Title                string               `json:"title,omitempty"`
+	WinningOutcomeId     string               `json:"winning_outcome_id,omitempty"`
+	Outcomes             []*PredictionOutcome `json:"outcomes,omitempty"`
+	Status               PredictionStatus     `json:"status,omitempty"`
+	StartedAt            Timestamp            `json:"started_at,omitempty,omitzero"`
+	EndedAt              Timestamp            `json:"ended_at,omitempty,omitzero"`
+}
@@ -0,0 +1,115 @@
+package twitch
+
+import "testing"
+
+func TestChannelPollEvents(t *testing.T) {
+	t.Run("progress event must contain votes", func(t *testing.T) {
+		data := `{
+			"id": "1243456",
+			"broadcaster_user_id": "1337",
+			"broadcaster_user_login": "cool_user",
+			"broadcaster_user_name": "Cool_User",
+			"title": "Aren't shoes just really hard socks?",
+			"choices": [
+				{"id": "123", "title": "Blue", "bits_votes": 5, "channel_points_votes": 7, "votes": 12},
+				{"id": "124", "title": "Yellow", "bits_votes": 0, "channel_points_votes": 1, "votes": 1}
+			],
+			"bits_voting": {"is_enabled": true, "amount_per_vote": 10},
+			"channel_points_voting": {"is_enabled": true, "amount_per_vote": 10},
+			"started_at": ` + referenceTimeStr + `,
+			"ends_at": ` + referenceTimeStr + `
+		}`
+
+		want := &ChannelPollProgressEvent{
+			Id:                   "1243456",
+			BroadcasterUserId:    "1337",
+			BroadcasterUserLogin: "cool_user",
+			BroadcasterUserName:  "Cool_User",
+			Title:                "Aren't shoes just really hard socks?",
+			Choices: []*PollChoice{
+				{Id: "123", Title: "Blue", BitsVotes: 5, ChannelPointsVotes: 7, Votes: 12},
+				{Id: "124", Title: "Yellow", ChannelPointsVotes: 1, Votes: 1},
+			},
+			BitsVoting:          PollVoting{IsEnabled: true, AmountPerVote: 10},
+			ChannelPointsVoting: PollVoting{IsEnabled: true, AmountPerVote: 10},
+			StartedAt:           Timestamp{referenceTime},
+			EndsAt:              Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelPollProgressEvent), want)
+	})
+
+	t.Run("end event must contain status", func(t *testing.T) {
+		data := `{"id": "1243456", "status": "completed", "ended_at": ` + referenceTimeStr + `}`
+
+		want := &ChannelPollEndEvent{
+			Id:      "1243456",
+			Status:  "completed",
+			EndedAt: Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelPollEndEvent), want)
+	})
+}
+
+func TestChannelPredictionEvents(t *testing.T) {
+	t.Run("top predictors must be decoded", func(t *testing.T) {
+		data := `{
+			"id": "1243456",
+			"broadcaster_user_id": "1337",
+			"title": "Aren't shoes just really hard socks?",
+			"outcomes": [{
+				"id": "1243456",
+				"title": "Yeah!",
+				"color": "blue",
+				"users": 10,
+				"channel_points": 15000,
+				"top_predictors": [{
+					"user_name": "Cool_User",
+					"user_login": "cool_user",
+					"user_id": "1234",
+					"channel_points_won": null,
+					"channel_points_used": 500
+				}]
+			}],
+			"started_at": ` + referenceTimeStr + `,
+			"locks_at": ` + referenceTimeStr + `
+		}`
+
+		want := &ChannelPredictionProgressEvent{
+			Id:                "1243456",
+			BroadcasterUserId: "1337",
+			Title:             "Aren't shoes just really hard socks?",
+			Outcomes: []*PredictionOutcome{{
+				Id:            "1243456",
+				Title:         "Yeah!",
+				Color:         "blue",
+				Users:         10,
+				ChannelPoints: 15000,
+				TopPredictors: []*PredictionPredictor{{
+					UserId:            "1234",
+					UserLogin:         "cool_user",
+					Username:          "Cool_User",
+					ChannelPointsUsed: 500,
+				}},
+			}},
+			StartedAt: Timestamp{referenceTime},
+			LocksAt:   Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelPredictionProgressEvent), want)
+	})
+
+	t.Run("end event must contain winning outcome", func(t *testing.T) {
+		data := `{"id": "1243456", "winning_outcome_id": "12", "status": "resolved", "ended_at": ` + referenceTimeStr + `}`
+
+		want := &ChannelPredictionEndEvent{
+			Id:               "1243456",
+			WinningOutcomeId: "12",
+			Status:           "resolved",
+			EndedAt:          Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelPredictionEndEvent), want)
+	})
+}
@@ -0,0 +1,29 @@
+package twitch
+
+import "context"
+
+const (
+	EventSubChannelRaid = "channel.raid"
+
+	raidDirectionIsRequired = "exactly one of from_broadcaster_user_id or to_broadcaster_user_id is required"
+)
+
+type ChannelRaidEvent struct {
+	FromBroadcasterUserId    string `json:"from_broadcaster_user_id,omitempty"`
+	FromBroadcasterUserLogin string `json:"from_broadcaster_user_login,omitempty"`
+	FromBroadcasterUserName  string `json:"from_broadcaster_user_name,omitempty"`
+	ToBroadcasterUserId      string `json:"to_broadcaster_user_id,omitempty"`
+	ToBroadcasterUserLogin   string `json:"to_broadcaster_user_login,omitempty"`
+	ToBroadcasterUserName    string `json:"to_broadcaster_user_name,omitempty"`
+	Viewers                  int    `json:"viewers,omitempty"`
+}
+
+// SubscribeChannelRaid subscribes to raids coming to ToBroadcasterUserId
+// or raids going from FromBroadcasterUserId, depending on which one is set.
+func (s *EventSubService) SubscribeChannelRaid(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	if condition == nil || (condition.FromBroadcasterUserId == "") == (condition.ToBroadcasterUserId == "") {
+		return nil, nil, &ErrorInvalidOptions{Options: condition, Message: raidDirectionIsRequired}
+	}
+
+	return s.subscribe(ctx, EventSubChannelRaid, "1", condition, transport)
+}
@@ -0,0 +1,75 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestChannelRaidEvent(t *testing.T) {
+	data := `{
+		"from_broadcaster_user_id": "1234",
+		"from_broadcaster_user_login": "cool_user",
+		"from_broadcaster_user_name": "Cool_User",
+		"to_broadcaster_user_id": "1337",
+		"to_broadcaster_user_login": "cooler_user",
+		"to_broadcaster_user_name": "Cooler_User",
+		"viewers": 9001
+	}`
+
+	want := &ChannelRaidEvent{
+		FromBroadcasterUserId:    "1234",
+		FromBroadcasterUserLogin: "cool_user",
+		FromBroadcasterUserName:  "Cool_User",
+		ToBroadcasterUserId:      "1337",
+		ToBroadcasterUserLogin:   "cooler_user",
+		ToBroadcasterUserName:    "Cooler_User",
+		Viewers:                  9001,
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelRaidEvent), want)
+}
+
+func TestSubscribeChannelRaid(t *testing.T) {
+	t.Run("condition direction must be passed as is", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(EventSubSubscriptionOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+
+			if opts.Type != EventSubChannelRaid || opts.Condition.ToBroadcasterUserId != "1337" {
+				t.Errorf("bad subscription options: %+v", opts)
+			}
+
+			fmt.Fprint(w, `{"data":[{"id":"1","type":"channel.raid"}]}`)
+		})
+
+		ctx := context.Background()
+		_, _, err := c.EventSub.SubscribeChannelRaid(ctx,
+			&EventSubCondition{ToBroadcasterUserId: "1337"},
+			&EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "1"},
+		)
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error, when direction is ambiguous", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+		transport := &EventSubTransport{Method: EventSubTransportWebSocket}
+
+		_, _, err := client.EventSub.SubscribeChannelRaid(ctx, nil, transport)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, raidDirectionIsRequired)
+
+		_, _, err = client.EventSub.SubscribeChannelRaid(ctx, &EventSubCondition{
+			FromBroadcasterUserId: "1",
+			ToBroadcasterUserId:   "2",
+		}, transport)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, raidDirectionIsRequired)
+	})
+}
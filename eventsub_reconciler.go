@@ -0,0 +1,148 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type eventSubKey struct {
+	typ       string
+	version   string
+	condition EventSubCondition
+}
+
+type EventSubReconcileResult struct {
+	Created []*EventSubSubscription
+	Deleted []*EventSubSubscription
+}
+
+// EventSubReconciler keeps the subscriptions of one transport equal to the
+// declared set: missing subscriptions are created, subscriptions that are
+// not declared are deleted, and revoked or failed ones are created again.
+// Subscriptions of other transports are left untouched.
+type EventSubReconciler struct {
+	Transport EventSubTransport
+
+	client *Client
+
+	mu      sync.Mutex
+	desired map[eventSubKey]*EventSubSubscriptionOptions
+}
+
+func NewEventSubReconciler(client *Client, transport *EventSubTransport) *EventSubReconciler {
+	return &EventSubReconciler{
+		Transport: *transport,
+		client:    client,
+		desired:   make(map[eventSubKey]*EventSubSubscriptionOptions),
+	}
+}
+
+func (r *EventSubReconciler) Add(typ, version string, condition *EventSubCondition) {
+	opts := &EventSubSubscriptionOptions{Type: typ, Version: version}
+	if condition != nil {
+		opts.Condition = *condition
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.desired[eventSubKey{typ, version, opts.Condition}] = opts
+}
+
+func (r *EventSubReconciler) Remove(typ, version string, condition *EventSubCondition) {
+	key := eventSubKey{typ: typ, version: version}
+	if condition != nil {
+		key.condition = *condition
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.desired, key)
+}
+
+func (r *EventSubReconciler) Reconcile(ctx context.Context) (*EventSubReconcileResult, error) {
+	existing, _, err := r.client.EventSub.GetAllSubscriptions(ctx, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	missing := make(map[eventSubKey]*EventSubSubscriptionOptions, len(r.desired))
+	for key, opts := range r.desired {
+		missing[key] = opts
+	}
+	r.mu.Unlock()
+
+	result := new(EventSubReconcileResult)
+	for _, sub := range existing {
+		if !sameEventSubTransport(sub.Transport, r.Transport) {
+			continue
+		}
+
+		key := eventSubKey{sub.Type, sub.Version, sub.Condition}
+		if _, ok := missing[key]; ok && isActiveEventSubStatus(sub.Status) {
+			delete(missing, key)
+			continue
+		}
+
+		if _, err := r.client.EventSub.DeleteSubscription(ctx, &EventSubSubscriptionId{sub.Id}); err != nil {
+			return result, err
+		}
+		result.Deleted = append(result.Deleted, sub)
+	}
+
+	for _, opts := range missing {
+		create := *opts
+		create.Transport = r.Transport
+
+		subs, _, err := r.client.EventSub.CreateSubscription(ctx, &create)
+		if err != nil {
+			return result, err
+		}
+		result.Created = append(result.Created, subs.Data...)
+	}
+
+	return result, nil
+}
+
+// Run reconciles every interval until ctx is done. Errors are passed to
+// onError, if it is not nil, and do not stop the loop.
+func (r *EventSubReconciler) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Reconcile(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func isActiveEventSubStatus(status string) bool {
+	return status == EventSubStatusEnabled || status == EventSubStatusWebhookCallbackVerificationPending
+}
+
+func sameEventSubTransport(a, b EventSubTransport) bool {
+	if a.Method != b.Method {
+		return false
+	}
+
+	switch a.Method {
+	case EventSubTransportWebhook:
+		return a.Callback == b.Callback
+	case EventSubTransportWebSocket:
+		return a.SessionId == b.SessionId
+	case EventSubTransportConduit:
+		return a.ConduitId == b.ConduitId
+	}
+
+	return true
+}
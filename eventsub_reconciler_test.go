@@ -0,0 +1,88 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+)
+
+func TestEventSubReconciler(t *testing.T) {
+	t.Run("must create missing, delete orphaned and recreate revoked subscriptions", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var created, deleted []string
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				if r.URL.Query().Get("after") == "" {
+					fmt.Fprint(w, `{"data":[
+						{"id":"keep","status":"enabled","type":"channel.raid","version":"1","condition":{"to_broadcaster_user_id":"1"},"transport":{"method":"webhook","callback":"https://example.com/hook"}},
+						{"id":"orphan","status":"enabled","type":"channel.ban","version":"1","condition":{"broadcaster_user_id":"1"},"transport":{"method":"webhook","callback":"https://example.com/hook"}}
+					],"pagination":{"cursor":"next"}}`)
+					return
+				}
+				fmt.Fprint(w, `{"data":[
+					{"id":"revoked","status":"authorization_revoked","type":"channel.raid","version":"1","condition":{"to_broadcaster_user_id":"2"},"transport":{"method":"webhook","callback":"https://example.com/hook"}},
+					{"id":"foreign","status":"enabled","type":"channel.ban","version":"1","condition":{"broadcaster_user_id":"1"},"transport":{"method":"webhook","callback":"https://example.com/other"}}
+				]}`)
+			case http.MethodDelete:
+				deleted = append(deleted, r.URL.Query().Get("id"))
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodPost:
+				opts := new(EventSubSubscriptionOptions)
+				json.NewDecoder(r.Body).Decode(opts)
+				if opts.Transport.Callback != "https://example.com/hook" {
+					t.Errorf("bad transport: %+v", opts.Transport)
+				}
+				id := opts.Type + ":" + opts.Condition.ToBroadcasterUserId + opts.Condition.BroadcasterUserId
+				created = append(created, id)
+				fmt.Fprintf(w, `{"data":[{"id":"%s","status":"enabled"}]}`, id)
+			}
+		})
+
+		r := NewEventSubReconciler(c, &EventSubTransport{Method: EventSubTransportWebhook, Callback: "https://example.com/hook", Secret: "secret"})
+		r.Add(EventSubChannelRaid, "1", &EventSubCondition{ToBroadcasterUserId: "1"})
+		r.Add(EventSubChannelRaid, "1", &EventSubCondition{ToBroadcasterUserId: "2"})
+		r.Add(EventSubChannelRaid, "1", &EventSubCondition{ToBroadcasterUserId: "3"})
+
+		result, err := r.Reconcile(context.Background())
+		assertNoError(t, err)
+
+		sort.Strings(created)
+		if fmt.Sprint(created) != "[channel.raid:2 channel.raid:3]" {
+			t.Errorf("bad created subscriptions: %v", created)
+		}
+
+		if fmt.Sprint(deleted) != "[orphan revoked]" {
+			t.Errorf("bad deleted subscriptions: %v", deleted)
+		}
+
+		if len(result.Created) != 2 || len(result.Deleted) != 2 {
+			t.Errorf("bad result: %d created, %d deleted", len(result.Created), len(result.Deleted))
+		}
+	})
+
+	t.Run("must not touch subscriptions when state matches", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodGet)
+			fmt.Fprint(w, `{"data":[{"id":"keep","status":"enabled","type":"channel.raid","version":"1","condition":{"to_broadcaster_user_id":"1"},"transport":{"method":"websocket","session_id":"s1"}}]}`)
+		})
+
+		r := NewEventSubReconciler(c, &EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "s1"})
+		r.Add(EventSubChannelRaid, "1", &EventSubCondition{ToBroadcasterUserId: "1"})
+
+		result, err := r.Reconcile(context.Background())
+		assertNoError(t, err)
+
+		if len(result.Created) != 0 || len(result.Deleted) != 0 {
+			t.Errorf("expected no changes, got: %+v", result)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package twitch
+
+import "context"
+
+const (
+	EventSubChannelModeratorAdd    = "channel.moderator.add"
+	EventSubChannelModeratorRemove = "channel.moderator.remove"
+	EventSubChannelVipAdd          = "channel.vip.add"
+	EventSubChannelVipRemove       = "channel.vip.remove"
+)
+
+// ChannelRoleEvent is sent for moderator and VIP add/remove events,
+// all of them share the same payload.
+type ChannelRoleEvent struct {
+	UserId               string `json:"user_id,omitempty"`
+	UserLogin            string `json:"user_login,omitempty"`
+	Username             string `json:"user_name,omitempty"`
+	BroadcasterUserId    string `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName  string `json:"broadcaster_user_name,omitempty"`
+}
+
+type ChannelModeratorAddEvent = ChannelRoleEvent
+type ChannelModeratorRemoveEvent = ChannelRoleEvent
+type ChannelVipAddEvent = ChannelRoleEvent
+type ChannelVipRemoveEvent = ChannelRoleEvent
+
+func (s *EventSubService) SubscribeChannelModeratorAdd(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	return s.subscribeBroadcaster(ctx, EventSubChannelModeratorAdd, condition, transport)
+}
+
+func (s *EventSubService) SubscribeChannelModeratorRemove(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	return s.subscribeBroadcaster(ctx, EventSubChannelModeratorRemove, condition, transport)
+}
+
+func (s *EventSubService) SubscribeChannelVipAdd(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	return s.subscribeBroadcaster(ctx, EventSubChannelVipAdd, condition, transport)
+}
+
+func (s *EventSubService) SubscribeChannelVipRemove(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	return s.subscribeBroadcaster(ctx, EventSubChannelVipRemove, condition, transport)
+}
@@ -0,0 +1,74 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestChannelRoleEvent(t *testing.T) {
+	data := `{
+		"user_id": "1234",
+		"user_login": "mod_user",
+		"user_name": "Mod_User",
+		"broadcaster_user_id": "1337",
+		"broadcaster_user_login": "cooler_user",
+		"broadcaster_user_name": "Cooler_User"
+	}`
+
+	want := &ChannelModeratorAddEvent{
+		UserId:               "1234",
+		UserLogin:            "mod_user",
+		Username:             "Mod_User",
+		BroadcasterUserId:    "1337",
+		BroadcasterUserLogin: "cooler_user",
+		BroadcasterUserName:  "Cooler_User",
+	}
+
+	assertJSONUnmarshal(t, data, new(ChannelModeratorAddEvent), want)
+}
+
+func TestSubscribeChannelRoles(t *testing.T) {
+	t.Run("every helper must subscribe to its type", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var got []string
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(EventSubSubscriptionOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+			got = append(got, opts.Type)
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		ctx := context.Background()
+		cond := &EventSubCondition{BroadcasterUserId: "1337"}
+		transport := &EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "1"}
+
+		c.EventSub.SubscribeChannelModeratorAdd(ctx, cond, transport)
+		c.EventSub.SubscribeChannelModeratorRemove(ctx, cond, transport)
+		c.EventSub.SubscribeChannelVipAdd(ctx, cond, transport)
+		c.EventSub.SubscribeChannelVipRemove(ctx, cond, transport)
+
+		want := fmt.Sprint([]string{
+			EventSubChannelModeratorAdd,
+			EventSubChannelModeratorRemove,
+			EventSubChannelVipAdd,
+			EventSubChannelVipRemove,
+		})
+
+		if fmt.Sprint(got) != want {
+			t.Errorf("\ngot: %v\nwant: %v", got, want)
+		}
+	})
+
+	t.Run("must return error, when broadcaster_user_id is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+		_, _, err := client.EventSub.SubscribeChannelVipAdd(ctx, &EventSubCondition{}, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, broadcasterUserIdIsRequired)
+	})
+}
@@ -0,0 +1,75 @@
+package twitch
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+)
+
+// LambdaRequest holds the fields of an AWS Lambda HTTP event used by the webhook.
+// It matches API Gateway REST and HTTP API payloads as well as function URLs,
+// so it can be the request type of a handler passed to lambda.Start.
+type LambdaRequest struct {
+	HTTPMethod      string                `json:"httpMethod,omitempty"`
+	RequestContext  *LambdaRequestContext `json:"requestContext,omitempty"`
+	Headers         map[string]string     `json:"headers,omitempty"`
+	Body            string                `json:"body,omitempty"`
+	IsBase64Encoded bool                  `json:"isBase64Encoded,omitempty"`
+}
+
+type LambdaRequestContext struct {
+	HTTP *LambdaRequestHTTP `json:"http,omitempty"`
+}
+
+type LambdaRequestHTTP struct {
+	Method string `json:"method,omitempty"`
+}
+
+type LambdaResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body"`
+}
+
+func (r *LambdaRequest) method() string {
+	if r.HTTPMethod != "" {
+		return r.HTTPMethod
+	}
+
+	if r.RequestContext != nil && r.RequestContext.HTTP != nil {
+		return r.RequestContext.HTTP.Method
+	}
+
+	return ""
+}
+
+// HandleLambda verifies and dispatches a webhook message received by AWS Lambda:
+//
+//	lambda.Start(webhook.HandleLambda)
+//
+// Google Cloud Functions get an http.ResponseWriter and *http.Request,
+// the webhook itself can be used there as it is an http.Handler.
+func (h *EventSubWebhook) HandleLambda(ctx context.Context, req *LambdaRequest) (*LambdaResponse, error) {
+	body := []byte(req.Body)
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(req.Body)
+		if err != nil {
+			return &LambdaResponse{StatusCode: http.StatusBadRequest}, nil
+		}
+		body = decoded
+	}
+
+	header := make(http.Header, len(req.Headers))
+	for k, v := range req.Headers {
+		header.Set(k, v)
+	}
+
+	resp := h.Handle(ctx, req.method(), header, body)
+
+	lambdaResp := &LambdaResponse{StatusCode: resp.StatusCode, Body: string(resp.Body)}
+	if resp.ContentType != "" {
+		lambdaResp.Headers = map[string]string{"Content-Type": resp.ContentType}
+	}
+
+	return lambdaResp, nil
+}
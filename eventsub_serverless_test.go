@@ -0,0 +1,77 @@
+package twitch
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLambdaRequest(t testing.TB, id, typ, body string) *LambdaRequest {
+	t.Helper()
+
+	r := newWebhookRequest(t, id, typ, body, time.Now())
+	req := &LambdaRequest{HTTPMethod: http.MethodPost, Body: body, Headers: map[string]string{}}
+	for k := range r.Header {
+		req.Headers[strings.ToLower(k)] = r.Header.Get(k)
+	}
+
+	return req
+}
+
+func TestEventSubWebhookHandleLambda(t *testing.T) {
+	t.Run("must answer verification challenge", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+		req := newLambdaRequest(t, "1", EventSubMessageWebhookCallbackVerification, `{"challenge":"pogchamp-kappa"}`)
+
+		resp, err := h.HandleLambda(context.Background(), req)
+		assertNoError(t, err)
+
+		if resp.StatusCode != http.StatusOK || resp.Body != "pogchamp-kappa" {
+			t.Errorf("bad challenge response: %+v", resp)
+		}
+	})
+
+	t.Run("must deliver base64 encoded notifications of http api", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+
+		var got *ChannelRaidEvent
+		h.OnNotification = func(n *EventSubNotification) {
+			got = new(ChannelRaidEvent)
+			n.Decode(got)
+		}
+
+		body := `{"subscription":{"type":"channel.raid"},"event":{"viewers":9001}}`
+		req := newLambdaRequest(t, "1", EventSubMessageNotification, body)
+		req.HTTPMethod = ""
+		req.RequestContext = &LambdaRequestContext{HTTP: &LambdaRequestHTTP{Method: http.MethodPost}}
+		req.Body = base64.StdEncoding.EncodeToString([]byte(body))
+		req.IsBase64Encoded = true
+
+		resp, err := h.HandleLambda(context.Background(), req)
+		assertNoError(t, err)
+
+		if resp.StatusCode != http.StatusNoContent || got == nil || got.Viewers != 9001 {
+			t.Errorf("notification was not delivered: %+v, %v", resp, got)
+		}
+	})
+
+	t.Run("must reject invalid signatures and methods", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+
+		req := newLambdaRequest(t, "1", EventSubMessageNotification, `{}`)
+		req.Body = `{"event":{}}`
+		resp, _ := h.HandleLambda(context.Background(), req)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("expected 403, got: %d", resp.StatusCode)
+		}
+
+		req.HTTPMethod = http.MethodGet
+		resp, _ = h.HandleLambda(context.Background(), req)
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("expected 405, got: %d", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package twitch
+
+const (
+	EventSubChannelShoutoutCreate  = "channel.shoutout.create"
+	EventSubChannelShoutoutReceive = "channel.shoutout.receive"
+)
+
+type ChannelShoutoutCreateEvent struct {
+	BroadcasterUserId      string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin   string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName    string    `json:"broadcaster_user_name,omitempty"`
+	ModeratorUserId        string    `json:"moderator_user_id,omitempty"`
+	ModeratorUserLogin     string    `json:"moderator_user_login,omitempty"`
+	ModeratorUserName      string    `json:"moderator_user_name,omitempty"`
+	ToBroadcasterUserId    string    `json:"to_broadcaster_user_id,omitempty"`
+	ToBroadcasterUserLogin string    `json:"to_broadcaster_user_login,omitempty"`
+	ToBroadcasterUserName  string    `json:"to_broadcaster_user_name,omitempty"`
+	ViewerCount            int       `json:"viewer_count,omitempty"`
+	StartedAt              Timestamp `json:"started_at,omitempty,omitzero"`
+	// CooldownEndsAt is when the broadcaster may send any shoutout again.
+	CooldownEndsAt Timestamp `json:"cooldown_ends_at,omitempty,omitzero"`
+	// TargetCooldownEndsAt is when the broadcaster may shout out
+	// the same target again.
+	TargetCooldownEndsAt Timestamp `json:"target_cooldown_ends_at,omitempty,omitzero"`
+}
+
+type ChannelShoutoutReceiveEvent struct {
+	BroadcasterUserId        string    `json:"broadcaster_user_id,omitempty"`
+	BroadcasterUserLogin     string    `json:"broadcaster_user_login,omitempty"`
+	BroadcasterUserName      string    `json:"broadcaster_user_name,omitempty"`
+	FromBroadcasterUserId    string    `json:"from_broadcaster_user_id,omitempty"`
+	FromBroadcasterUserLogin string    `json:"from_broadcaster_user_login,omitempty"`
+	FromBroadcasterUserName  string    `json:"from_broadcaster_user_name,omitempty"`
+	ViewerCount              int       `json:"viewer_count,omitempty"`
+	StartedAt                Timestamp `json:"started_at,omitempty,omitzero"`
+}
@@ -0,0 +1,45 @@
+package twitch
+
+import "testing"
+
+func TestChannelShoutoutEvents(t *testing.T) {
+	t.Run("create event must contain cooldowns", func(t *testing.T) {
+		data := `{
+			"broadcaster_user_id": "12345",
+			"moderator_user_id": "98765",
+			"to_broadcaster_user_id": "626262",
+			"to_broadcaster_user_login": "sandysanderman",
+			"to_broadcaster_user_name": "SandySanderman",
+			"started_at": ` + referenceTimeStr + `,
+			"viewer_count": 860,
+			"cooldown_ends_at": ` + referenceTimeStr + `,
+			"target_cooldown_ends_at": ` + referenceTimeStr + `
+		}`
+
+		want := &ChannelShoutoutCreateEvent{
+			BroadcasterUserId:      "12345",
+			ModeratorUserId:        "98765",
+			ToBroadcasterUserId:    "626262",
+			ToBroadcasterUserLogin: "sandysanderman",
+			ToBroadcasterUserName:  "SandySanderman",
+			ViewerCount:            860,
+			StartedAt:              Timestamp{referenceTime},
+			CooldownEndsAt:         Timestamp{referenceTime},
+			TargetCooldownEndsAt:   Timestamp{referenceTime},
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelShoutoutCreateEvent), want)
+	})
+
+	t.Run("receive event must contain sender", func(t *testing.T) {
+		data := `{"broadcaster_user_id": "626262", "from_broadcaster_user_id": "12345", "viewer_count": 860}`
+
+		want := &ChannelShoutoutReceiveEvent{
+			BroadcasterUserId:     "626262",
+			FromBroadcasterUserId: "12345",
+			ViewerCount:           860,
+		}
+
+		assertJSONUnmarshal(t, data, new(ChannelShoutoutReceiveEvent), want)
+	})
+}
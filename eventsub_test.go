@@ -0,0 +1,165 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	t.Run("tests method and body to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodPost)
+
+			body, _ := ioutil.ReadAll(r.Body)
+			want := `{"type":"channel.poll.begin","version":"1","condition":{"broadcaster_user_id":"12"},"transport":{"method":"websocket","session_id":"AQoQ"}}` + "\n"
+			if got := string(body); got != want {
+				t.Errorf("bad body\ngot: %s\nwant: %s", got, want)
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"data":[{"id":"26b1c993","status":"enabled","type":"channel.poll.begin","version":"1","cost":0}],"total":1}`)
+		})
+
+		ctx := context.Background()
+		subs, _, err := c.EventSub.CreateSubscription(ctx, &EventSubSubscriptionOptions{
+			Type:      EventSubChannelPollBegin,
+			Version:   "1",
+			Condition: EventSubCondition{BroadcasterUserId: "12"},
+			Transport: EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "AQoQ"},
+		})
+		assertNoError(t, err)
+
+		want := []*EventSubSubscription{{
+			Id:      "26b1c993",
+			Status:  "enabled",
+			Type:    EventSubChannelPollBegin,
+			Version: "1",
+		}}
+
+		if !reflect.DeepEqual(subs.Data, want) {
+			t.Errorf("\ngot: %v\nwant: %v", subs.Data, want)
+		}
+	})
+
+	t.Run("must return error, when required fields are not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+		_, _, err := client.EventSub.CreateSubscription(ctx, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "type is required")
+
+		_, _, err = client.EventSub.CreateSubscription(ctx, &EventSubSubscriptionOptions{Type: EventSubChannelRaid})
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "version is required")
+
+		_, _, err = client.EventSub.CreateSubscription(ctx, &EventSubSubscriptionOptions{Type: EventSubChannelRaid, Version: "1"})
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "transport.method is required")
+	})
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	t.Run("tests method and parameters to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodDelete)
+			assertQuery(t, r, params{"id": "26b1c993"})
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		ctx := context.Background()
+		_, err := c.EventSub.DeleteSubscription(ctx, &EventSubSubscriptionId{"26b1c993"})
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error, when id is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+		_, err := client.EventSub.DeleteSubscription(ctx, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, "id is required")
+	})
+}
+
+func TestDeleteAllWithStatus(t *testing.T) {
+	t.Run("must delete every subscription with status", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var deleted []string
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete {
+				deleted = append(deleted, r.URL.Query().Get("id"))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if r.URL.Query().Get("after") == "" {
+				assertQuery(t, r, params{"status": EventSubStatusWebhookCallbackVerificationFailed})
+				fmt.Fprint(w, `{"data":[{"id":"1","status":"webhook_callback_verification_failed"}],"pagination":{"cursor":"next"}}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"data":[{"id":"2","status":"webhook_callback_verification_failed"}]}`)
+		})
+
+		subs, _, err := c.EventSub.DeleteAllWithStatus(context.Background(), EventSubStatusWebhookCallbackVerificationFailed)
+		assertNoError(t, err)
+
+		if fmt.Sprint(deleted) != "[1 2]" || len(subs) != 2 {
+			t.Errorf("bad deleted subscriptions: %v", deleted)
+		}
+	})
+
+	t.Run("must return error, when status is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		_, _, err := client.EventSub.DeleteAllWithStatus(context.Background(), "")
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, eventSubStatusIsRequired)
+	})
+}
+
+func TestDeleteAllForSession(t *testing.T) {
+	t.Run("must delete only subscriptions of the session", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var deleted []string
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete {
+				deleted = append(deleted, r.URL.Query().Get("id"))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			fmt.Fprint(w, `{"data":[
+				{"id":"1","transport":{"method":"websocket","session_id":"s1"}},
+				{"id":"2","transport":{"method":"websocket","session_id":"s2"}},
+				{"id":"3","transport":{"method":"webhook","callback":"https://example.com"}}
+			]}`)
+		})
+
+		_, _, err := c.EventSub.DeleteAllForSession(context.Background(), "s1")
+		assertNoError(t, err)
+
+		if fmt.Sprint(deleted) != "[1]" {
+			t.Errorf("bad deleted subscriptions\ngot: %v\nwant: [1]", deleted)
+		}
+	})
+
+	t.Run("must return error, when session id is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		_, _, err := client.EventSub.DeleteAllForSession(context.Background(), "")
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, sessionIdIsRequired)
+	})
+}
@@ -0,0 +1,55 @@
+package twitch
+
+import "context"
+
+const (
+	EventSubUserWhisperMessage      = "user.whisper.message"
+	EventSubUserAuthorizationGrant  = "user.authorization.grant"
+	EventSubUserAuthorizationRevoke = "user.authorization.revoke"
+	EventSubUserUpdate              = "user.update"
+)
+
+type Whisper struct {
+	Text string `json:"text,omitempty"`
+}
+
+type UserWhisperMessageEvent struct {
+	FromUserId    string  `json:"from_user_id,omitempty"`
+	FromUserLogin string  `json:"from_user_login,omitempty"`
+	FromUserName  string  `json:"from_user_name,omitempty"`
+	ToUserId      string  `json:"to_user_id,omitempty"`
+	ToUserLogin   string  `json:"to_user_login,omitempty"`
+	ToUserName    string  `json:"to_user_name,omitempty"`
+	WhisperId     string  `json:"whisper_id,omitempty"`
+	Whisper       Whisper `json:"whisper,omitempty"`
+}
+
+// SubscribeUserWhisperMessage subscribes to whispers received by UserId.
+func (s *EventSubService) SubscribeUserWhisperMessage(ctx context.Context, condition *EventSubCondition, transport *EventSubTransport) (*EventSubSubscriptionsResponse, *Response, error) {
+	if condition == nil || condition.UserId == "" {
+		return nil, nil, &ErrorInvalidOptions{Options: condition, Message: userIdIsRequired}
+	}
+
+	return s.subscribe(ctx, EventSubUserWhisperMessage, "1", condition, transport)
+}
+
+// UserAuthorizationEvent is sent for both authorization grant and revoke
+// events. On revoke UserLogin and Username are empty if the user was deleted.
+type UserAuthorizationEvent struct {
+	ClientId  string `json:"client_id,omitempty"`
+	UserId    string `json:"user_id,omitempty"`
+	UserLogin string `json:"user_login,omitempty"`
+	Username  string `json:"user_name,omitempty"`
+}
+
+type UserAuthorizationGrantEvent = UserAuthorizationEvent
+type UserAuthorizationRevokeEvent = UserAuthorizationEvent
+
+type UserUpdateEvent struct {
+	UserId        string `json:"user_id,omitempty"`
+	UserLogin     string `json:"user_login,omitempty"`
+	Username      string `json:"user_name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
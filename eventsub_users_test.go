@@ -0,0 +1,103 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUserWhisperMessageEvent(t *testing.T) {
+	data := `{
+		"from_user_id": "423374343",
+		"from_user_login": "glowillig",
+		"from_user_name": "glowillig",
+		"to_user_id": "424596340",
+		"to_user_login": "quotrok",
+		"to_user_name": "quotrok",
+		"whisper_id": "some-whisper-id",
+		"whisper": {"text": "a secret"}
+	}`
+
+	want := &UserWhisperMessageEvent{
+		FromUserId:    "423374343",
+		FromUserLogin: "glowillig",
+		FromUserName:  "glowillig",
+		ToUserId:      "424596340",
+		ToUserLogin:   "quotrok",
+		ToUserName:    "quotrok",
+		WhisperId:     "some-whisper-id",
+		Whisper:       Whisper{Text: "a secret"},
+	}
+
+	assertJSONUnmarshal(t, data, new(UserWhisperMessageEvent), want)
+}
+
+func TestSubscribeUserWhisperMessage(t *testing.T) {
+	t.Run("tests condition to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(EventSubSubscriptionOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+
+			if opts.Type != EventSubUserWhisperMessage || opts.Condition.UserId != "424596340" {
+				t.Errorf("bad subscription options: %+v", opts)
+			}
+
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		ctx := context.Background()
+		_, _, err := c.EventSub.SubscribeUserWhisperMessage(ctx,
+			&EventSubCondition{UserId: "424596340"},
+			&EventSubTransport{Method: EventSubTransportWebSocket, SessionId: "1"},
+		)
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error, when user_id is not provided", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		ctx := context.Background()
+		_, _, err := client.EventSub.SubscribeUserWhisperMessage(ctx, nil, nil)
+		assertErrorPresence(t, err)
+		assertErrorMessage(t, err, userIdIsRequired)
+	})
+}
+
+func TestUserAuthorizationEvents(t *testing.T) {
+	t.Run("revoke event of deleted user must be decoded", func(t *testing.T) {
+		data := `{"client_id": "crq72vsaoijkc83xx42hz6i37", "user_id": "1337", "user_login": null, "user_name": null}`
+
+		want := &UserAuthorizationRevokeEvent{
+			ClientId: "crq72vsaoijkc83xx42hz6i37",
+			UserId:   "1337",
+		}
+
+		assertJSONUnmarshal(t, data, new(UserAuthorizationRevokeEvent), want)
+	})
+
+	t.Run("user update event must be decoded", func(t *testing.T) {
+		data := `{
+			"user_id": "1337",
+			"user_login": "cool_user",
+			"user_name": "Cool_User",
+			"email": "user@email.com",
+			"email_verified": true,
+			"description": "cool description"
+		}`
+
+		want := &UserUpdateEvent{
+			UserId:        "1337",
+			UserLogin:     "cool_user",
+			Username:      "Cool_User",
+			Email:         "user@email.com",
+			EmailVerified: true,
+			Description:   "cool description",
+		}
+
+		assertJSONUnmarshal(t, data, new(UserUpdateEvent), want)
+	})
+}
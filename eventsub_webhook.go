@@ -0,0 +1,149 @@
+package twitch
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	headerEventSubMessageId        = "Twitch-Eventsub-Message-Id"
+	headerEventSubMessageType      = "Twitch-Eventsub-Message-Type"
+	headerEventSubMessageSignature = "Twitch-Eventsub-Message-Signature"
+	headerEventSubMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+
+	EventSubMessageWebhookCallbackVerification = "webhook_callback_verification"
+
+	eventSubSignaturePrefix = "sha256="
+	eventSubMaxMessageAge   = 10 * time.Minute
+)
+
+type eventSubWebhookBody struct {
+	Challenge    string                `json:"challenge,omitempty"`
+	Subscription *EventSubSubscription `json:"subscription,omitempty"`
+	Event        json.RawMessage       `json:"event,omitempty"`
+	Events       json.RawMessage       `json:"events,omitempty"`
+}
+
+// EventSubWebhook is an http.Handler for the EventSub webhook transport.
+// It verifies message signatures with Secret, answers callback verification
+// challenges and passes every notification to OnNotification once.
+type EventSubWebhook struct {
+	Secret string
+	Dedupe DedupeStore
+
+	OnNotification func(notification *EventSubNotification)
+	OnRevocation   func(subscription *EventSubSubscription)
+}
+
+func NewEventSubWebhook(secret string) *EventSubWebhook {
+	return &EventSubWebhook{
+		Secret: secret,
+		Dedupe: NewMemoryDedupeStore(defaultDedupeStoreSize),
+	}
+}
+
+func (h *EventSubWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	resp := h.Handle(r.Context(), r.Method, r.Header, body)
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	w.Write(resp.Body)
+}
+
+// EventSubWebhookResponse is the response Handle wants to be sent to Twitch.
+type EventSubWebhookResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Handle verifies and dispatches a webhook message given the raw request body.
+// It is meant for frameworks not built on http.Handler, the body must be
+// passed exactly as received. Frameworks wrapping http.Handler use the
+// webhook itself, it reads the body once, e.g. for gin and echo:
+//
+//	router.POST("/webhook", gin.WrapH(webhook))
+//	e.POST("/webhook", echo.WrapHandler(webhook))
+//
+// A framework reading the body first must hand it over with Handle:
+//
+//	body := readRawBody(request)
+//	resp := webhook.Handle(ctx, method, header, body)
+//	writeResponse(resp.StatusCode, resp.ContentType, resp.Body)
+func (h *EventSubWebhook) Handle(ctx context.Context, method string, header http.Header, body []byte) *EventSubWebhookResponse {
+	if method != http.MethodPost {
+		return &EventSubWebhookResponse{StatusCode: http.StatusMethodNotAllowed}
+	}
+
+	if !h.verify(header, body) {
+		return &EventSubWebhookResponse{StatusCode: http.StatusForbidden}
+	}
+
+	msg := new(eventSubWebhookBody)
+	if err := json.Unmarshal(body, msg); err != nil {
+		return &EventSubWebhookResponse{StatusCode: http.StatusBadRequest}
+	}
+
+	switch header.Get(headerEventSubMessageType) {
+	case EventSubMessageWebhookCallbackVerification:
+		return &EventSubWebhookResponse{
+			StatusCode:  http.StatusOK,
+			ContentType: "text/plain",
+			Body:        []byte(msg.Challenge),
+		}
+	case EventSubMessageNotification:
+		id := header.Get(headerEventSubMessageId)
+		if !isDuplicate(ctx, h.Dedupe, id) && h.OnNotification != nil {
+			timestamp, _ := time.Parse(time.RFC3339, header.Get(headerEventSubMessageTimestamp))
+			h.OnNotification(&EventSubNotification{
+				MessageId:    id,
+				Timestamp:    Timestamp{timestamp},
+				Subscription: msg.Subscription,
+				Event:        msg.Event,
+				Events:       msg.Events,
+			})
+		}
+	case EventSubMessageRevocation:
+		if h.OnRevocation != nil {
+			h.OnRevocation(msg.Subscription)
+		}
+	}
+
+	return &EventSubWebhookResponse{StatusCode: http.StatusNoContent}
+}
+
+func (h *EventSubWebhook) verify(header http.Header, body []byte) bool {
+	id := header.Get(headerEventSubMessageId)
+	timestamp := header.Get(headerEventSubMessageTimestamp)
+
+	sent, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil || time.Since(sent) > eventSubMaxMessageAge {
+		return false
+	}
+
+	want := eventSubSignature(h.Secret, id, timestamp, body)
+	return hmac.Equal([]byte(header.Get(headerEventSubMessageSignature)), []byte(want))
+}
+
+func eventSubSignature(secret, id, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+
+	return eventSubSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,166 @@
+package twitch
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const webhookSecret = "s3cre7-s3cre7-s3cre7"
+
+func newWebhookRequest(t testing.TB, id, typ, body string, sent time.Time) *http.Request {
+	t.Helper()
+
+	timestamp := sent.UTC().Format(time.RFC3339)
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	r.Header.Set(headerEventSubMessageId, id)
+	r.Header.Set(headerEventSubMessageType, typ)
+	r.Header.Set(headerEventSubMessageTimestamp, timestamp)
+	r.Header.Set(headerEventSubMessageSignature, eventSubSignature(webhookSecret, id, timestamp, []byte(body)))
+
+	return r
+}
+
+func TestEventSubWebhook(t *testing.T) {
+	t.Run("must answer verification challenge", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+		body := `{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{"id":"1","status":"webhook_callback_verification_pending"}}`
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newWebhookRequest(t, "1", EventSubMessageWebhookCallbackVerification, body, time.Now()))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("bad status\ngot: %d\nwant: %d", w.Code, http.StatusOK)
+		}
+
+		if got, _ := ioutil.ReadAll(w.Body); string(got) != "pogchamp-kappa-360noscope-vohiyo" {
+			t.Errorf("bad challenge response: %s", got)
+		}
+	})
+
+	t.Run("must deliver notification once", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+		body := `{"subscription":{"id":"1","type":"channel.raid"},"event":{"viewers":10}}`
+
+		var got []string
+		h.OnNotification = func(n *EventSubNotification) {
+			event := new(ChannelRaidEvent)
+			n.Decode(event)
+			got = append(got, fmt.Sprintf("%s:%s:%d", n.MessageId, n.Subscription.Type, event.Viewers))
+		}
+
+		for _, id := range []string{"a", "a", "b"} {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, newWebhookRequest(t, id, EventSubMessageNotification, body, time.Now()))
+
+			if w.Code != http.StatusNoContent {
+				t.Errorf("bad status\ngot: %d\nwant: %d", w.Code, http.StatusNoContent)
+			}
+		}
+
+		if want := "[a:channel.raid:10 b:channel.raid:10]"; fmt.Sprint(got) != want {
+			t.Errorf("\ngot: %v\nwant: %v", got, want)
+		}
+	})
+
+	t.Run("must pass revocations", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+		body := `{"subscription":{"id":"1","status":"authorization_revoked"}}`
+
+		var got string
+		h.OnRevocation = func(sub *EventSubSubscription) {
+			got = sub.Status
+		}
+
+		h.ServeHTTP(httptest.NewRecorder(), newWebhookRequest(t, "1", EventSubMessageRevocation, body, time.Now()))
+
+		if got != "authorization_revoked" {
+			t.Errorf("revocation was not delivered, got: %q", got)
+		}
+	})
+
+	t.Run("must reject bad signatures and old messages", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+		h.OnNotification = func(n *EventSubNotification) {
+			t.Error("notification must not be delivered")
+		}
+
+		r := newWebhookRequest(t, "1", EventSubMessageNotification, `{}`, time.Now())
+		r.Header.Set(headerEventSubMessageSignature, "sha256=deadbeef")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("bad status\ngot: %d\nwant: %d", w.Code, http.StatusForbidden)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, newWebhookRequest(t, "1", EventSubMessageNotification, `{}`, time.Now().Add(-time.Hour)))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("bad status\ngot: %d\nwant: %d", w.Code, http.StatusForbidden)
+		}
+	})
+}
+
+func TestEventSubWebhookHandle(t *testing.T) {
+	t.Run("must verify raw bodies passed by frameworks", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+
+		body := `{"challenge":"pogchamp-kappa"}`
+		r := newWebhookRequest(t, "1", EventSubMessageWebhookCallbackVerification, body, time.Now())
+
+		resp := h.Handle(r.Context(), r.Method, r.Header, []byte(body))
+		if resp.StatusCode != http.StatusOK || resp.ContentType != "text/plain" || string(resp.Body) != "pogchamp-kappa" {
+			t.Errorf("bad challenge response: %+v", resp)
+		}
+
+		// A body already consumed by a middleware must not pass verification.
+		resp = h.Handle(r.Context(), r.Method, r.Header, nil)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("expected 403, got: %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestEventSubWebhookWrapped(t *testing.T) {
+	t.Run("must verify, when served by a router wrapping the handler", func(t *testing.T) {
+		h := NewEventSubWebhook(webhookSecret)
+
+		notifications := 0
+		h.OnNotification = func(notification *EventSubNotification) { notifications++ }
+
+		// Routers such as gin.WrapH and echo.WrapHandler pass the request on
+		// with its body unread.
+		mux := http.NewServeMux()
+		mux.Handle("/webhook", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}))
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		body := `{"subscription":{"id":"1","type":"channel.raid"},"event":{"viewers":1}}`
+		for i := 0; i < 2; i++ {
+			r := newWebhookRequest(t, "1", EventSubMessageNotification, body, time.Now())
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/webhook", strings.NewReader(body))
+			req.Header = r.Header
+
+			resp, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusNoContent {
+				t.Errorf("expected 204, got: %d", resp.StatusCode)
+			}
+		}
+
+		if notifications != 1 {
+			t.Errorf("expected the notification once, got: %d", notifications)
+		}
+	})
+}
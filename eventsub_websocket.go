@@ -0,0 +1,388 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultEventSubWebSocketURL = "wss://eventsub.wss.twitch.tv/ws"
+
+	EventSubMessageSessionWelcome   = "session_welcome"
+	EventSubMessageSessionKeepalive = "session_keepalive"
+	EventSubMessageSessionReconnect = "session_reconnect"
+	EventSubMessageNotification     = "notification"
+	EventSubMessageRevocation       = "revocation"
+
+	defaultEventSubKeepaliveGrace = 5 * time.Second
+)
+
+var (
+	ErrEventSubNotConnected     = errors.New("eventsub websocket session is not established")
+	ErrEventSubKeepaliveTimeout = errors.New("eventsub websocket keepalive timeout")
+)
+
+type EventSubSession struct {
+	Id                      string    `json:"id,omitempty"`
+	Status                  string    `json:"status,omitempty"`
+	ConnectedAt             Timestamp `json:"connected_at,omitempty,omitzero"`
+	KeepaliveTimeoutSeconds int       `json:"keepalive_timeout_seconds,omitempty"`
+	ReconnectURL            string    `json:"reconnect_url,omitempty"`
+}
+
+type EventSubMessageMetadata struct {
+	MessageId           string    `json:"message_id,omitempty"`
+	MessageType         string    `json:"message_type,omitempty"`
+	MessageTimestamp    Timestamp `json:"message_timestamp,omitempty,omitzero"`
+	SubscriptionType    string    `json:"subscription_type,omitempty"`
+	SubscriptionVersion string    `json:"subscription_version,omitempty"`
+}
+
+type EventSubMessagePayload struct {
+	Session      *EventSubSession      `json:"session,omitempty"`
+	Subscription *EventSubSubscription `json:"subscription,omitempty"`
+	Event        json.RawMessage       `json:"event,omitempty"`
+	Events       json.RawMessage       `json:"events,omitempty"`
+}
+
+type EventSubMessage struct {
+	Metadata EventSubMessageMetadata `json:"metadata,omitempty"`
+	Payload  EventSubMessagePayload  `json:"payload,omitempty"`
+}
+
+// EventSubWebSocket receives EventSub notifications over a WebSocket session.
+// session_reconnect messages are handled transparently: the old connection is
+// kept open and read until the new one receives its welcome message, so no
+// notifications are lost and subscriptions stay bound to the same session.
+//
+// If nothing arrives within the negotiated keepalive timeout plus KeepaliveGrace,
+// the connection is considered dead: a new session is opened and every
+// subscription created with Subscribe is created again for it.
+type EventSubWebSocket struct {
+	URL    string
+	Dialer *websocket.Dialer
+
+	// KeepaliveTimeoutSeconds asks Twitch for a keepalive interval
+	// between 10 and 600 seconds, zero keeps the server default.
+	KeepaliveTimeoutSeconds int
+	KeepaliveGrace          time.Duration
+
+	// Dedupe drops notifications already delivered, e.g. when the same
+	// message arrives on both connections during a reconnect.
+	Dedupe DedupeStore
+
+	// OnWelcome is called when a new session is established,
+	// it is not called again when Twitch moves the session to another server.
+	OnWelcome      func(session *EventSubSession)
+	OnNotification func(notification *EventSubNotification)
+	OnRevocation   func(subscription *EventSubSubscription)
+	// OnKeepaliveTimeout is called before reconnecting a silently dropped session.
+	OnKeepaliveTimeout func(session *EventSubSession, silence time.Duration)
+
+	client *Client
+
+	mu            sync.Mutex
+	session       *EventSubSession
+	subscriptions []*EventSubSubscriptionOptions
+}
+
+type eventSubFrame struct {
+	conn *websocket.Conn
+	msg  *EventSubMessage
+	err  error
+}
+
+func NewEventSubWebSocket(client *Client) *EventSubWebSocket {
+	return &EventSubWebSocket{
+		URL:            defaultEventSubWebSocketURL,
+		Dialer:         websocket.DefaultDialer,
+		KeepaliveGrace: defaultEventSubKeepaliveGrace,
+		Dedupe:         NewMemoryDedupeStore(defaultDedupeStoreSize),
+		client:         client,
+	}
+}
+
+func (ws *EventSubWebSocket) Session() *EventSubSession {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	return ws.session
+}
+
+// Transport returns the transport to create subscriptions for the current session.
+func (ws *EventSubWebSocket) Transport() *EventSubTransport {
+	transport := &EventSubTransport{Method: EventSubTransportWebSocket}
+	if session := ws.Session(); session != nil {
+		transport.SessionId = session.Id
+	}
+
+	return transport
+}
+
+func (ws *EventSubWebSocket) setSession(session *EventSubSession) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.session = session
+}
+
+// Subscribe creates a subscription for the current session and remembers it,
+// so it is created again when the session has to be replaced.
+func (ws *EventSubWebSocket) Subscribe(ctx context.Context, typ, version string, condition *EventSubCondition) (*EventSubSubscription, error) {
+	if ws.Session() == nil {
+		return nil, ErrEventSubNotConnected
+	}
+
+	opts := &EventSubSubscriptionOptions{Type: typ, Version: version}
+	if condition != nil {
+		opts.Condition = *condition
+	}
+
+	sub, err := ws.subscribe(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.mu.Lock()
+	ws.subscriptions = append(ws.subscriptions, opts)
+	ws.mu.Unlock()
+
+	return sub, nil
+}
+
+func (ws *EventSubWebSocket) subscribe(ctx context.Context, opts *EventSubSubscriptionOptions) (*EventSubSubscription, error) {
+	opts.Transport = *ws.Transport()
+	subs, _, err := ws.client.EventSub.CreateSubscription(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subs.Data) == 0 {
+		return nil, ErrNoData
+	}
+
+	return subs.Data[0], nil
+}
+
+func (ws *EventSubWebSocket) resubscribe(ctx context.Context) error {
+	ws.mu.Lock()
+	subscriptions := make([]*EventSubSubscriptionOptions, len(ws.subscriptions))
+	copy(subscriptions, ws.subscriptions)
+	ws.mu.Unlock()
+
+	for _, opts := range subscriptions {
+		if _, err := ws.subscribe(ctx, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ws *EventSubWebSocket) dialURL() (string, error) {
+	if ws.KeepaliveTimeoutSeconds == 0 {
+		return ws.URL, nil
+	}
+
+	u, err := url.Parse(ws.URL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("keepalive_timeout_seconds", strconv.Itoa(ws.KeepaliveTimeoutSeconds))
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Connect dials the EventSub server and blocks reading messages
+// until ctx is done or the connection fails.
+func (ws *EventSubWebSocket) Connect(ctx context.Context) error {
+	if ctx == nil {
+		return errNonNilContext
+	}
+
+	for reconnect := false; ; reconnect = true {
+		err := ws.run(ctx, reconnect)
+		if err != ErrEventSubKeepaliveTimeout {
+			return err
+		}
+
+		ws.observeReconnect(ReconnectKeepaliveTimeout)
+	}
+}
+
+func (ws *EventSubWebSocket) observeReconnect(reason string) {
+	if ws.client != nil && ws.client.Metrics != nil {
+		ws.client.Metrics.IncReconnect(reason)
+	}
+}
+
+func (ws *EventSubWebSocket) run(ctx context.Context, reconnect bool) error {
+	u, err := ws.dialURL()
+	if err != nil {
+		return err
+	}
+
+	conn, _, err := ws.Dialer.DialContext(ctx, u, nil)
+	if err != nil {
+		return err
+	}
+
+	frames := make(chan eventSubFrame)
+	done := make(chan struct{})
+	defer close(done)
+
+	var current, pending *websocket.Conn = conn, nil
+	defer func() {
+		current.Close()
+		if pending != nil {
+			pending.Close()
+		}
+	}()
+
+	go readEventSubFrames(current, frames, done)
+
+	// The watchdog is armed once the welcome message
+	// tells the negotiated keepalive timeout.
+	var timeout time.Duration
+	watchdog := time.NewTimer(time.Hour)
+	watchdog.Stop()
+	defer watchdog.Stop()
+	lastMessage := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-watchdog.C:
+			if ws.OnKeepaliveTimeout != nil {
+				ws.OnKeepaliveTimeout(ws.Session(), time.Since(lastMessage))
+			}
+
+			return ErrEventSubKeepaliveTimeout
+		case f := <-frames:
+			if f.conn == current && f.err == nil {
+				lastMessage = time.Now()
+				resetTimer(watchdog, timeout)
+			}
+
+			if f.err != nil {
+				switch f.conn {
+				case current:
+					return f.err
+				case pending:
+					pending = nil
+				}
+				continue
+			}
+
+			switch f.msg.Metadata.MessageType {
+			case EventSubMessageSessionWelcome:
+				session := f.msg.Payload.Session
+				if session == nil {
+					continue
+				}
+
+				ws.setSession(session)
+				timeout = time.Duration(session.KeepaliveTimeoutSeconds)*time.Second + ws.KeepaliveGrace
+				resetTimer(watchdog, timeout)
+
+				if f.conn == pending {
+					current.Close()
+					current, pending = pending, nil
+					continue
+				}
+
+				if reconnect {
+					if err := ws.resubscribe(ctx); err != nil {
+						return err
+					}
+				}
+
+				if ws.OnWelcome != nil {
+					ws.OnWelcome(session)
+				}
+			case EventSubMessageSessionReconnect:
+				if f.msg.Payload.Session == nil {
+					continue
+				}
+
+				if pending != nil {
+					pending.Close()
+				}
+
+				pending, _, err = ws.Dialer.DialContext(ctx, f.msg.Payload.Session.ReconnectURL, nil)
+				if err != nil {
+					return err
+				}
+				ws.observeReconnect(ReconnectSessionReconnect)
+
+				go readEventSubFrames(pending, frames, done)
+			case EventSubMessageNotification:
+				if isDuplicate(ctx, ws.Dedupe, f.msg.Metadata.MessageId) {
+					continue
+				}
+
+				if ws.OnNotification != nil {
+					ws.OnNotification(&EventSubNotification{
+						MessageId:    f.msg.Metadata.MessageId,
+						Timestamp:    f.msg.Metadata.MessageTimestamp,
+						Subscription: f.msg.Payload.Subscription,
+						Event:        f.msg.Payload.Event,
+						Events:       f.msg.Payload.Events,
+					})
+				}
+			case EventSubMessageRevocation:
+				if ws.OnRevocation != nil {
+					ws.OnRevocation(f.msg.Payload.Subscription)
+				}
+			}
+		}
+	}
+}
+
+func readEventSubFrames(conn *websocket.Conn, frames chan<- eventSubFrame, done <-chan struct{}) {
+	for {
+		_, data, err := conn.ReadMessage()
+
+		f := eventSubFrame{conn: conn, err: err}
+		if err == nil {
+			f.msg = new(EventSubMessage)
+			if json.Unmarshal(data, f.msg) != nil {
+				continue
+			}
+		}
+
+		select {
+		case frames <- f:
+		case <-done:
+			return
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+
+	if d > 0 {
+		t.Reset(d)
+	}
+}
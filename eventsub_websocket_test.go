@@ -0,0 +1,249 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{}
+
+func eventSubWelcome(id string, keepalive int) string {
+	return fmt.Sprintf(`{"metadata":{"message_id":"w-%s","message_type":"session_welcome"},"payload":{"session":{"id":"%s","status":"connected","keepalive_timeout_seconds":%d}}}`, id, id, keepalive)
+}
+
+func eventSubNotification(id string) string {
+	return `{"metadata":{"message_id":"` + id + `","message_type":"notification","subscription_type":"channel.raid"},"payload":{"subscription":{"id":"sub","type":"channel.raid"},"event":{"viewers":1}}}`
+}
+
+func TestEventSubWebSocket(t *testing.T) {
+	t.Run("must deliver notifications and handle reconnect", func(t *testing.T) {
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+		wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+		oldClosed := make(chan struct{})
+		mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			defer conn.Close()
+
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("session", 10)))
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubNotification("1")))
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"metadata":{"message_type":"session_reconnect"},"payload":{"session":{"id":"session","status":"reconnecting","reconnect_url":"`+wsURL+`/reconnect"}}}`))
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubNotification("2")))
+
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					close(oldClosed)
+					return
+				}
+			}
+		})
+
+		mux.HandleFunc("/reconnect", func(w http.ResponseWriter, r *http.Request) {
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			defer conn.Close()
+
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("session", 10)))
+			<-oldClosed
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubNotification("3")))
+			conn.ReadMessage()
+		})
+
+		c, _ := NewClient(creds, httpClient)
+		ws := NewEventSubWebSocket(c)
+		ws.URL = wsURL + "/ws"
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		welcomes := 0
+		ws.OnWelcome = func(session *EventSubSession) {
+			welcomes++
+		}
+
+		var got []string
+		ws.OnNotification = func(n *EventSubNotification) {
+			got = append(got, n.MessageId)
+
+			event := new(ChannelRaidEvent)
+			if err := n.Decode(event); err != nil || event.Viewers != 1 {
+				t.Errorf("bad event %v: %v", event, err)
+			}
+
+			if len(got) == 3 {
+				cancel()
+			}
+		}
+
+		err := ws.Connect(ctx)
+		if err != context.Canceled {
+			t.Fatalf("expected context cancellation, got: %v", err)
+		}
+
+		if fmt.Sprint(got) != "[1 2 3]" {
+			t.Errorf("notifications were lost\ngot: %v\nwant: [1 2 3]", got)
+		}
+
+		if welcomes != 1 {
+			t.Errorf("OnWelcome must be called once, got: %d", welcomes)
+		}
+
+		if got := ws.Transport().SessionId; got != "session" {
+			t.Errorf("bad session id\ngot: %s\nwant: %s", got, "session")
+		}
+	})
+
+	t.Run("must drop duplicated notifications", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			defer conn.Close()
+
+			conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("session", 10)))
+			for _, id := range []string{"1", "1", "2"} {
+				conn.WriteMessage(websocket.TextMessage, []byte(eventSubNotification(id)))
+			}
+			conn.ReadMessage()
+		}))
+		defer server.Close()
+
+		c, _ := NewClient(creds, httpClient)
+		ws := NewEventSubWebSocket(c)
+		ws.URL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var got []string
+		ws.OnNotification = func(n *EventSubNotification) {
+			got = append(got, n.MessageId)
+			if n.MessageId == "2" {
+				cancel()
+			}
+		}
+
+		ws.Connect(ctx)
+
+		if fmt.Sprint(got) != "[1 2]" {
+			t.Errorf("\ngot: %v\nwant: [1 2]", got)
+		}
+	})
+
+	t.Run("must return error when connection is closed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4003, "connection unused"))
+			conn.Close()
+		}))
+		defer server.Close()
+
+		c, _ := NewClient(creds, httpClient)
+		ws := NewEventSubWebSocket(c)
+		ws.URL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+		err := ws.Connect(context.Background())
+		if closeErr, ok := err.(*websocket.CloseError); !ok || closeErr.Code != 4003 {
+			t.Errorf("expected close error 4003, got: %v", err)
+		}
+	})
+}
+
+func TestEventSubWebSocketKeepalive(t *testing.T) {
+	t.Run("must reconnect and resubscribe on silence", func(t *testing.T) {
+		c, mux, serverURL, teardown := setup()
+		defer teardown()
+
+		var sessions []string
+		mux.HandleFunc("/"+eventSubSubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+			opts := new(EventSubSubscriptionOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+			sessions = append(sessions, opts.Transport.SessionId)
+			fmt.Fprint(w, `{"data":[{"id":"sub","status":"enabled","type":"channel.raid"}]}`)
+		})
+
+		connections := 0
+		mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			defer conn.Close()
+
+			connections++
+			if connections == 1 {
+				conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("s1", 0)))
+			} else {
+				conn.WriteMessage(websocket.TextMessage, []byte(eventSubWelcome("s2", 10)))
+				conn.WriteMessage(websocket.TextMessage, []byte(eventSubNotification("1")))
+			}
+			conn.ReadMessage()
+		})
+
+		ws := NewEventSubWebSocket(c)
+		ws.URL = "ws" + strings.TrimPrefix(serverURL, "http") + "/ws"
+		ws.KeepaliveGrace = 50 * time.Millisecond
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		welcomes := 0
+		ws.OnWelcome = func(session *EventSubSession) {
+			welcomes++
+			if welcomes == 1 {
+				_, err := ws.Subscribe(ctx, EventSubChannelRaid, "1", &EventSubCondition{ToBroadcasterUserId: "1337"})
+				assertNoError(t, err)
+			}
+		}
+
+		timeouts := 0
+		ws.OnKeepaliveTimeout = func(session *EventSubSession, silence time.Duration) {
+			timeouts++
+			if session.Id != "s1" || silence < ws.KeepaliveGrace {
+				t.Errorf("bad keepalive timeout hook arguments: %v, %v", session.Id, silence)
+			}
+		}
+
+		ws.OnNotification = func(n *EventSubNotification) {
+			cancel()
+		}
+
+		if err := ws.Connect(ctx); err != context.Canceled {
+			t.Fatalf("expected context cancellation, got: %v", err)
+		}
+
+		if timeouts != 1 || welcomes != 2 {
+			t.Errorf("expected one timeout and two sessions, got: %d, %d", timeouts, welcomes)
+		}
+
+		if fmt.Sprint(sessions) != "[s1 s2]" {
+			t.Errorf("subscription was not recreated\ngot: %v\nwant: [s1 s2]", sessions)
+		}
+	})
+
+	t.Run("subscribe must fail without session", func(t *testing.T) {
+		c, _ := NewClient(creds, httpClient)
+		ws := NewEventSubWebSocket(c)
+
+		_, err := ws.Subscribe(context.Background(), EventSubChannelRaid, "1", nil)
+		if err != ErrEventSubNotConnected {
+			t.Errorf("expected ErrEventSubNotConnected, got: %v", err)
+		}
+	})
+
+	t.Run("keepalive timeout must be requested in url", func(t *testing.T) {
+		ws := NewEventSubWebSocket(nil)
+		ws.KeepaliveTimeoutSeconds = 30
+
+		u, err := ws.dialURL()
+		assertNoError(t, err)
+
+		if want := defaultEventSubWebSocketURL + "?keepalive_timeout_seconds=30"; u != want {
+			t.Errorf("bad url\ngot: %s\nwant: %s", u, want)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type extraKey struct{}
+
+// extra are the header and query parameters added to requests with a context.
+type extra struct {
+	header http.Header
+	query  url.Values
+}
+
+// WithHeader makes requests with ctx send the header key with value, e.g. a
+// tracing header, replacing the value set by the client.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	e := extraOf(ctx)
+	e.header.Set(key, value)
+
+	return context.WithValue(ctx, extraKey{}, e)
+}
+
+// WithQuery makes requests with ctx send the query parameter key with value,
+// e.g. a parameter of Twitch the options of the call don't have yet,
+// replacing the value set by the options.
+func WithQuery(ctx context.Context, key, value string) context.Context {
+	e := extraOf(ctx)
+	e.query.Set(key, value)
+
+	return context.WithValue(ctx, extraKey{}, e)
+}
+
+// extraOf returns a copy of the extra of ctx, which may be extended.
+func extraOf(ctx context.Context) extra {
+	e, _ := ctx.Value(extraKey{}).(extra)
+
+	copied := extra{header: e.header.Clone(), query: make(url.Values, len(e.query))}
+	if copied.header == nil {
+		copied.header = make(http.Header)
+	}
+	for key, values := range e.query {
+		copied.query[key] = values
+	}
+
+	return copied
+}
+
+// withExtra returns a copy of req with the extra of its context.
+func withExtra(req *http.Request) *http.Request {
+	e, ok := req.Context().Value(extraKey{}).(extra)
+	if !ok {
+		return req
+	}
+
+	r := *req
+	r.Header = req.Header.Clone()
+	for key, values := range e.header {
+		r.Header[key] = values
+	}
+
+	if len(e.query) > 0 {
+		u := *req.URL
+		query := u.Query()
+		for key, values := range e.query {
+			query[key] = values
+		}
+		u.RawQuery = query.Encode()
+		r.URL = &u
+	}
+
+	return &r
+}
@@ -0,0 +1,48 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWithHeaderAndQuery(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	var got *http.Request
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	c.Header = http.Header{"X-Trace": {"client"}}
+
+	t.Run("must add header and query of the context", func(t *testing.T) {
+		ctx := WithHeader(context.Background(), "X-Trace", "call")
+		ctx = WithQuery(ctx, "experimental", "1")
+		ctx = WithQuery(ctx, "first", "5")
+
+		_, _, err := c.Streams.GetStreams(ctx, &StreamsOptions{First: 10, GameId: "1"})
+		assertNoError(t, err)
+
+		assertQuery(t, got, params{"experimental": "1", "first": "5", "game_id": "1"})
+		if h := got.Header.Get("X-Trace"); h != "call" {
+			t.Errorf("bad header %q", h)
+		}
+	})
+
+	t.Run("must not change the context it extends", func(t *testing.T) {
+		parent := WithQuery(context.Background(), "a", "1")
+		WithQuery(parent, "b", "2")
+
+		_, _, err := c.Streams.GetStreams(parent, nil)
+		assertNoError(t, err)
+
+		assertQuery(t, got, params{"a": "1"})
+		if h := got.Header.Get("X-Trace"); h != "client" {
+			t.Errorf("bad header %q", h)
+		}
+	})
+}
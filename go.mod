@@ -1,8 +1,16 @@
 module github.com/holypower777/go-twitch
 
-go 1.16
+go 1.24
 
 require (
 	github.com/google/go-querystring v1.1.0
+	github.com/gorilla/websocket v1.5.0
 	golang.org/x/oauth2 v0.0.0-20210514164344-f6687ab2804c
 )
+
+require (
+	github.com/golang/protobuf v1.4.2 // indirect
+	golang.org/x/net v0.0.0-20200822124328-c89045814202 // indirect
+	google.golang.org/appengine v1.6.6 // indirect
+	google.golang.org/protobuf v1.25.0 // indirect
+)
@@ -0,0 +1,162 @@
+package twitch
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ImageURL fills the size placeholders of an image URL template returned by
+// Twitch, e.g. the thumbnail of a stream or the box art of a game, which use
+// {width}x{height}, or the thumbnail of a video, which uses %{width}x%{height}.
+func ImageURL(template string, width, height int) string {
+	w, h := strconv.Itoa(width), strconv.Itoa(height)
+
+	return strings.NewReplacer(
+		"%{width}", w,
+		"%{height}", h,
+		"{width}", w,
+		"{height}", h,
+	).Replace(template)
+}
+
+// ThumbnailAt returns the URL of the thumbnail of the stream in the given size.
+func (s *Stream) ThumbnailAt(width, height int) string {
+	return ImageURL(s.ThumnailURL, width, height)
+}
+
+const (
+	// EmoteURLTemplate is the template of emote URLs returned by the emote endpoints.
+	EmoteURLTemplate = "https://static-cdn.jtvnw.net/emoticons/v2/{{id}}/{{format}}/{{theme_mode}}/{{scale}}"
+
+	EmoteFormatStatic   = "static"
+	EmoteFormatAnimated = "animated"
+
+	EmoteThemeLight = "light"
+	EmoteThemeDark  = "dark"
+
+	EmoteScaleSmall  = "1.0"
+	EmoteScaleMedium = "2.0"
+	EmoteScaleLarge  = "3.0"
+)
+
+// EmoteURL fills the emote URL template returned by the emote endpoints,
+// EmoteURLTemplate is used if template is empty.
+func EmoteURL(template, id, format, theme, scale string) string {
+	if template == "" {
+		template = EmoteURLTemplate
+	}
+
+	return strings.NewReplacer(
+		"{{id}}", id,
+		"{{format}}", format,
+		"{{theme_mode}}", theme,
+		"{{scale}}", scale,
+	).Replace(template)
+}
+
+// URL returns the CDN URL of the emote, animated if it is requested
+// and the emote has an animated format.
+func (e *ChatEmote) URL(theme, scale string, animated bool) string {
+	format := EmoteFormatStatic
+	if animated {
+		for _, f := range e.Format {
+			if f == EmoteFormatAnimated {
+				format = EmoteFormatAnimated
+			}
+		}
+	}
+
+	return EmoteURL("", e.Id, format, theme, scale)
+}
+
+// ChatBadgeVersion is a version of a chat badge set, e.g. the 12 months
+// version of the subscriber set, as returned by the chat badge endpoints.
+type ChatBadgeVersion struct {
+	Id          string `json:"id,omitempty"`
+	ImageURL1x  string `json:"image_url_1x,omitempty"`
+	ImageURL2x  string `json:"image_url_2x,omitempty"`
+	ImageURL4x  string `json:"image_url_4x,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ImageURL returns the URL of the smallest image of at least scale,
+// or the largest one, e.g. 2x for a scale of 1.5.
+func (v *ChatBadgeVersion) ImageURL(scale float64) string {
+	return closestImage(map[string]string{
+		"1": v.ImageURL1x,
+		"2": v.ImageURL2x,
+		"4": v.ImageURL4x,
+	}, scale)
+}
+
+// Cheermote is a cheermote with its tiers, as returned by the cheermotes endpoint.
+type Cheermote struct {
+	Prefix       string           `json:"prefix,omitempty"`
+	Tiers        []*CheermoteTier `json:"tiers,omitempty"`
+	Type         string           `json:"type,omitempty"`
+	Order        int              `json:"order,omitempty"`
+	LastUpdated  Timestamp        `json:"last_updated,omitempty,omitzero"`
+	IsCharitable bool             `json:"is_charitable,omitempty"`
+}
+
+// CheermoteTier is the tier of a cheermote shown for cheers of at least MinBits.
+type CheermoteTier struct {
+	MinBits        int    `json:"min_bits,omitempty"`
+	Id             string `json:"id,omitempty"`
+	Color          string `json:"color,omitempty"`
+	CanCheer       bool   `json:"can_cheer,omitempty"`
+	ShowInBitsCard bool   `json:"show_in_bits_card,omitempty"`
+	// Images are the URLs of the images by theme, format and scale,
+	// e.g. Images["dark"]["animated"]["1.5"].
+	Images map[string]map[string]map[string]string `json:"images,omitempty"`
+}
+
+// Tier returns the tier shown for a cheer of bits, or nil if bits is
+// less than the lowest tier.
+func (c *Cheermote) Tier(bits int) *CheermoteTier {
+	var tier *CheermoteTier
+	for _, t := range c.Tiers {
+		if t.MinBits <= bits && (tier == nil || t.MinBits > tier.MinBits) {
+			tier = t
+		}
+	}
+
+	return tier
+}
+
+// ImageURL returns the URL of the smallest image of the theme and format,
+// EmoteThemeDark and EmoteFormatAnimated e.g., of at least scale, or the
+// largest one. It is empty if the tier has no images of the theme and format.
+func (t *CheermoteTier) ImageURL(theme, format string, scale float64) string {
+	return closestImage(t.Images[theme][format], scale)
+}
+
+// closestImage returns the URL of the smallest scale of at least scale, or
+// the largest one, of the image URLs by scale, e.g. "1.5". Empty URLs are skipped.
+func closestImage(urls map[string]string, scale float64) string {
+	var (
+		best, largest           string
+		bestScale, largestScale float64
+	)
+
+	for key, u := range urls {
+		s, err := strconv.ParseFloat(key, 64)
+		if err != nil || u == "" {
+			continue
+		}
+
+		if s >= scale && (best == "" || s < bestScale) {
+			best, bestScale = u, s
+		}
+		if largest == "" || s > largestScale {
+			largest, largestScale = u, s
+		}
+	}
+
+	if best != "" {
+		return best
+	}
+
+	return largest
+}
@@ -0,0 +1,80 @@
+package twitch
+
+import "testing"
+
+func TestImageURL(t *testing.T) {
+	t.Run("must fill placeholders", func(t *testing.T) {
+		for template, want := range map[string]string{
+			"https://static-cdn.jtvnw.net/previews-ttv/live_user_aboba-{width}x{height}.jpg":    "https://static-cdn.jtvnw.net/previews-ttv/live_user_aboba-440x248.jpg",
+			"https://static-cdn.jtvnw.net/cf_vods/d2nvs31859zcd8/thumb0-%{width}x%{height}.jpg": "https://static-cdn.jtvnw.net/cf_vods/d2nvs31859zcd8/thumb0-440x248.jpg",
+			"https://static-cdn.jtvnw.net/jtv_user_pictures/aboba.png":                          "https://static-cdn.jtvnw.net/jtv_user_pictures/aboba.png",
+		} {
+			if got := ImageURL(template, 440, 248); got != want {
+				t.Errorf("\ngot: %s\nwant: %s", got, want)
+			}
+		}
+	})
+
+	t.Run("must return thumbnail of stream", func(t *testing.T) {
+		s := &Stream{ThumnailURL: "https://static-cdn.jtvnw.net/previews-ttv/live_user_aboba-{width}x{height}.jpg"}
+
+		if got, want := s.ThumbnailAt(1920, 1080), "https://static-cdn.jtvnw.net/previews-ttv/live_user_aboba-1920x1080.jpg"; got != want {
+			t.Errorf("\ngot: %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestEmoteURL(t *testing.T) {
+	t.Run("must fill emote template", func(t *testing.T) {
+		got := EmoteURL("", "25", EmoteFormatStatic, EmoteThemeDark, EmoteScaleLarge)
+		if want := "https://static-cdn.jtvnw.net/emoticons/v2/25/static/dark/3.0"; got != want {
+			t.Errorf("\ngot: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("must prefer animated format if available", func(t *testing.T) {
+		animated := &ChatEmote{Id: "emotesv2_1", Format: []string{EmoteFormatStatic, EmoteFormatAnimated}}
+		static := &ChatEmote{Id: "25", Format: []string{EmoteFormatStatic}}
+
+		if got, want := animated.URL(EmoteThemeLight, EmoteScaleSmall, true), "https://static-cdn.jtvnw.net/emoticons/v2/emotesv2_1/animated/light/1.0"; got != want {
+			t.Errorf("\ngot: %s\nwant: %s", got, want)
+		}
+		if got, want := static.URL(EmoteThemeLight, EmoteScaleSmall, true), "https://static-cdn.jtvnw.net/emoticons/v2/25/static/light/1.0"; got != want {
+			t.Errorf("\ngot: %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestBadgeAndCheermoteImages(t *testing.T) {
+	t.Run("must select badge image by scale", func(t *testing.T) {
+		v := &ChatBadgeVersion{ImageURL1x: "1x", ImageURL2x: "2x", ImageURL4x: "4x"}
+
+		for scale, want := range map[float64]string{1: "1x", 1.5: "2x", 3: "4x", 8: "4x"} {
+			if got := v.ImageURL(scale); got != want {
+				t.Errorf("scale %v: got %s, want %s", scale, got, want)
+			}
+		}
+	})
+
+	t.Run("must select cheermote tier and image", func(t *testing.T) {
+		c := &Cheermote{Prefix: "Cheer", Tiers: []*CheermoteTier{
+			{MinBits: 1, Id: "1"},
+			{MinBits: 100, Id: "100", Images: map[string]map[string]map[string]string{
+				EmoteThemeDark: {EmoteFormatAnimated: {"1": "a1", "1.5": "a1.5", "2": "a2", "3": "a3", "4": "a4"}},
+			}},
+			{MinBits: 1000, Id: "1000"},
+		}}
+
+		if c.Tier(0) != nil || c.Tier(1).Id != "1" || c.Tier(999).Id != "100" || c.Tier(5000).Id != "1000" {
+			t.Error("wrong tier selected")
+		}
+
+		tier := c.Tier(150)
+		if got := tier.ImageURL(EmoteThemeDark, EmoteFormatAnimated, 1.2); got != "a1.5" {
+			t.Errorf("got %s, want a1.5", got)
+		}
+		if got := tier.ImageURL(EmoteThemeLight, EmoteFormatAnimated, 1); got != "" {
+			t.Errorf("got %s, want no image", got)
+		}
+	})
+}
@@ -0,0 +1,288 @@
+// Command gen generates option structs, response models and service methods
+// of Helix endpoints from their definitions:
+//
+//	go run ./internal/gen -in endpoints.json -out endpoints_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Definition is the machine-readable definition of Helix endpoints.
+type Definition struct {
+	Endpoints []*Endpoint `json:"endpoints"`
+}
+
+type Endpoint struct {
+	// Service is the name of the service without the suffix, e.g. Users.
+	Service string `json:"service"`
+	// Name is the name of the method, e.g. GetUserBlockList.
+	Name   string `json:"name"`
+	Doc    string `json:"doc"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Options is the name of the struct of Params.
+	Options string   `json:"options"`
+	Params  []*Field `json:"params"`
+	// Response is the name of the DataResponse of Model.
+	Response string   `json:"response"`
+	Model    string   `json:"model"`
+	Fields   []*Field `json:"fields"`
+}
+
+type Field struct {
+	Name     string `json:"name"`
+	Key      string `json:"key"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	// Max is the maximum length or value of the param, 0 is unlimited.
+	Max int `json:"max"`
+}
+
+func main() {
+	in := flag.String("in", "endpoints.json", "definition of the endpoints")
+	out := flag.String("out", "endpoints_gen.go", "generated file")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := Generate(*in, data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Generate returns the formatted source generated from the definition
+// read from the file named in.
+func Generate(in string, data []byte) ([]byte, error) {
+	def := new(Definition)
+	if err := json.Unmarshal(data, def); err != nil {
+		return nil, err
+	}
+
+	for _, e := range def.Endpoints {
+		if err := e.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]interface{}{
+		"Source":    in,
+		"Endpoints": def.Endpoints,
+		"Services":  services(def.Endpoints),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func (e *Endpoint) validate() error {
+	switch {
+	case e.Service == "" || e.Name == "" || e.Path == "":
+		return fmt.Errorf("endpoint %q: service, name and path are required", e.Name)
+	case e.Options == "" && len(e.Params) > 0:
+		return fmt.Errorf("endpoint %s: options are required for params", e.Name)
+	case e.Response == "" || e.Model == "":
+		return fmt.Errorf("endpoint %s: response and model are required", e.Name)
+	}
+
+	switch e.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return nil
+	default:
+		return fmt.Errorf("endpoint %s: unsupported method %q", e.Name, e.Method)
+	}
+}
+
+// HTTPMethod returns the name of the net/http constant of the method.
+func (e *Endpoint) HTTPMethod() string {
+	return "http.Method" + e.Method[:1] + strings.ToLower(e.Method[1:])
+}
+
+// InBody reports whether the params are sent as JSON body
+// instead of the query.
+func (e *Endpoint) InBody() bool {
+	return e.Method != http.MethodGet && e.Method != http.MethodDelete
+}
+
+// DefaultsBroadcaster reports whether the endpoint has a BroadcasterId param,
+// which defaults to BroadcasterId of the client when it's empty.
+func (e *Endpoint) DefaultsBroadcaster() bool {
+	for _, f := range e.Params {
+		if f.Name == "BroadcasterId" && f.Type == "string" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Endpoint) PathConst() string {
+	return lowerFirst(e.Name) + "Path"
+}
+
+func (e *Endpoint) ParamTag() string {
+	if e.InBody() {
+		return "json"
+	}
+
+	return "url"
+}
+
+// Tag returns the struct tag of the param, validated by validateOptions.
+func (e *Endpoint) Tag(f *Field) string {
+	tag := fmt.Sprintf(`%s:"%s,omitempty"`, e.ParamTag(), f.Key)
+	if f.Required {
+		tag += ` validate:"required"`
+	}
+	if f.Max > 0 {
+		tag += fmt.Sprintf(` max:"%d"`, f.Max)
+	}
+
+	return tag
+}
+
+// JSONTag returns the struct tag of the model field,
+// timestamps are tagged omitzero as well.
+func (f *Field) JSONTag() string {
+	if f.Type == "Timestamp" {
+		return fmt.Sprintf(`json:"%s,omitempty,omitzero"`, f.Key)
+	}
+
+	return fmt.Sprintf(`json:"%s,omitempty"`, f.Key)
+}
+
+type service struct {
+	Name      string
+	Endpoints []*Endpoint
+}
+
+func services(endpoints []*Endpoint) []*service {
+	byName := make(map[string]*service)
+	for _, e := range endpoints {
+		s := byName[e.Service]
+		if s == nil {
+			s = &service{Name: e.Service}
+			byName[e.Service] = s
+		}
+		s.Endpoints = append(s.Endpoints, e)
+	}
+
+	list := make([]*service, 0, len(byName))
+	for _, s := range byName {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+
+	return string(r)
+}
+
+var tmpl = template.Must(template.New("gen").Funcs(template.FuncMap{
+	"lowerFirst": lowerFirst,
+}).Parse(`// Code generated by internal/gen from {{.Source}}. DO NOT EDIT.
+
+package twitch
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+{{- range .Endpoints}}
+	{{.PathConst}} = "{{.Path}}"
+{{- end}}
+)
+{{range .Services}}
+// {{lowerFirst .Name}}GeneratedAPI holds the generated methods of {{.Name}}API.
+type {{lowerFirst .Name}}GeneratedAPI interface {
+{{- range .Endpoints}}
+	{{.Name}}(ctx context.Context{{if .Options}}, opts *{{.Options}}{{end}}) (*{{.Response}}, *Response, error)
+{{- end}}
+}
+{{end}}
+{{- range .Endpoints}}
+{{- $e := .}}
+{{if .Options}}
+type {{.Options}} struct {
+{{- range .Params}}
+	{{.Name}} {{.Type}} ` + "`" + `{{$e.Tag .}}` + "`" + `
+{{- end}}
+}
+{{end}}
+type {{.Model}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `{{.JSONTag}}` + "`" + `
+{{- end}}
+}
+
+type {{.Response}} = DataResponse[*{{.Model}}]
+
+{{if .Doc}}// {{.Doc}}
+{{if .DefaultsBroadcaster}}//
+// BroadcasterId defaults to BroadcasterId of the client.
+{{end}}{{end -}}
+func (s *{{.Service}}Service) {{.Name}}(ctx context.Context{{if .Options}}, opts *{{.Options}}{{end}}) (*{{.Response}}, *Response, error) {
+{{- if .DefaultsBroadcaster}}
+	o := new({{.Options}})
+	if opts != nil {
+		*o = *opts
+	}
+	o.BroadcasterId = s.client.broadcasterId(o.BroadcasterId)
+	opts = o
+{{end}}
+{{- if and .Options .InBody}}
+	req, err := s.client.NewRequest({{.HTTPMethod}}, {{.PathConst}}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+{{- else}}
+{{- if .Options}}
+	u, err := addParams({{.PathConst}}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+{{end}}
+	req, err := s.client.NewRequest({{.HTTPMethod}}, {{if .Options}}u{{else}}{{.PathConst}}{{end}}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+{{- end}}
+
+	data := new({{.Response}})
+	resp, err := s.client.Do(ctx, req, data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return data, resp, nil
+}
+{{end}}`))
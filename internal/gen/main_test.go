@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("generated file must be up to date", func(t *testing.T) {
+		data, err := ioutil.ReadFile("../../endpoints.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		src, err := Generate("endpoints.json", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		generated, err := ioutil.ReadFile("../../endpoints_gen.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(src, generated) {
+			t.Error("endpoints_gen.go is outdated, run go generate")
+		}
+	})
+
+	t.Run("must reject unsupported methods", func(t *testing.T) {
+		_, err := Generate("endpoints.json", []byte(`{"endpoints":[{"service":"Users","name":"GetUsers","path":"users","method":"HEAD","response":"R","model":"M"}]}`))
+		if err == nil {
+			t.Error("expected error to be returned")
+		}
+	})
+}
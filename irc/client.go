@@ -0,0 +1,394 @@
+// Package irc connects to Twitch chat over IRC: it logs in with the token
+// of a twitch.Client, joins channels and delivers parsed messages to handlers.
+//
+//	chat := irc.NewClient(client)
+//	chat.Handle(irc.CommandPrivmsg, func(m *irc.Message) {
+//		if m.Text() == "!ping" {
+//			chat.Say(m.Channel(), "pong")
+//		}
+//	})
+//	chat.Join("dallas")
+//	err := chat.Connect(ctx)
+//
+// Chat is reached over WebSocket by default, set Client.Dial to DialTLS to
+// connect over TCP instead.
+package irc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/holypower777/go-twitch"
+)
+
+const (
+	// Capabilities of Twitch chat, see Client.Capabilities.
+	CapabilityTags       = "twitch.tv/tags"
+	CapabilityCommands   = "twitch.tv/commands"
+	CapabilityMembership = "twitch.tv/membership"
+)
+
+const (
+	defaultReconnectDelay = time.Second
+	maxReconnectDelay     = time.Minute
+)
+
+var (
+	ErrNotConnected = errors.New("irc client is not connected")
+	ErrInvalidLine  = errors.New("irc line must not contain CR or LF")
+
+	errNonNilContext = errors.New("context must be non-nil")
+	errReconnect     = errors.New("irc server asked to reconnect")
+)
+
+// ErrorLogin is returned by Connect when Twitch rejects the token.
+type ErrorLogin struct {
+	// Message is the notice of Twitch, e.g. Login authentication failed.
+	Message string
+}
+
+func (e *ErrorLogin) Error() string {
+	return fmt.Sprintf("Message: irc login failed: %s", e.Message)
+}
+
+// HandlerFunc handles a message of chat.
+type HandlerFunc func(m *Message)
+
+// Client is a connection to Twitch chat. Channels joined with Join are
+// joined again after reconnects, RECONNECT messages of Twitch and failed
+// connections are handled by connecting again and PING messages are answered.
+type Client struct {
+	// Dial connects to chat, DialWebSocket(DefaultWebSocketURL, nil) by default.
+	Dial DialFunc
+
+	// Nick is the login of the user of the token. If it is empty, it is
+	// looked up by validating the token.
+	Nick string
+	// Capabilities are requested on login,
+	// CapabilityTags and CapabilityCommands by default.
+	Capabilities []string
+
+	// ReconnectDelay is the delay before connecting again after the
+	// connection failed, it doubles with every failure up to a minute.
+	// It defaults to 1 second.
+	ReconnectDelay time.Duration
+
+	// OnConnect is called when the login succeeded,
+	// before the channels are joined.
+	OnConnect func()
+	// OnError is called when the connection failed,
+	// the client keeps running and connects again.
+	OnError func(err error)
+
+	client *twitch.Client
+
+	mu       sync.Mutex
+	conn     Conn
+	channels map[string]bool
+	handlers map[string][]HandlerFunc
+
+	writeMu sync.Mutex
+}
+
+// NewClient returns a chat client logging in with the token of client.
+// If client is nil, it logs in anonymously and can only read chat.
+func NewClient(client *twitch.Client) *Client {
+	return &Client{
+		Dial:         DialWebSocket(DefaultWebSocketURL, nil),
+		Capabilities: []string{CapabilityTags, CapabilityCommands},
+		client:       client,
+		channels:     make(map[string]bool),
+		handlers:     make(map[string][]HandlerFunc),
+	}
+}
+
+// Handle calls handler with every message of command, e.g. CommandPrivmsg,
+// or with every message if command is empty. Handlers are called in the
+// order of their messages, a slow handler delays the following messages.
+func (c *Client) Handle(command string, handler HandlerFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handlers[command] = append(c.handlers[command], handler)
+}
+
+// Join joins the channels, e.g. dallas, right away if the client is
+// connected, otherwise once it is.
+func (c *Client) Join(channels ...string) error {
+	return c.membership(CommandJoin, channels, true)
+}
+
+// Part leaves the channels.
+func (c *Client) Part(channels ...string) error {
+	return c.membership(CommandPart, channels, false)
+}
+
+func (c *Client) membership(command string, channels []string, join bool) error {
+	names := make([]string, len(channels))
+	c.mu.Lock()
+	for i, channel := range channels {
+		names[i] = normalizeChannel(channel)
+		if join {
+			c.channels[names[i]] = true
+		} else {
+			delete(c.channels, names[i])
+		}
+	}
+	connected := c.conn != nil
+	c.mu.Unlock()
+
+	if !connected || len(names) == 0 {
+		return nil
+	}
+
+	return c.Send(command + " #" + strings.Join(names, ",#"))
+}
+
+// Channels returns the channels joined by the client.
+func (c *Client) Channels() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channels := make([]string, 0, len(c.channels))
+	for channel := range c.channels {
+		channels = append(channels, channel)
+	}
+
+	return channels
+}
+
+// Say sends text to the chat of channel. Text must be a single line,
+// otherwise ErrInvalidLine is returned.
+func (c *Client) Say(channel, text string) error {
+	return c.Send(CommandPrivmsg + " #" + normalizeChannel(channel) + " :" + text)
+}
+
+// Send sends a raw line, e.g. PRIVMSG #dallas :Hello!, without line ending.
+// It returns ErrInvalidLine if line contains CR or LF, as those would end
+// the line early and send the rest as another command, and ErrNotConnected
+// if the client is not connected.
+func (c *Client) Send(line string) error {
+	if strings.ContainsAny(line, "\r\n") {
+		return ErrInvalidLine
+	}
+
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return conn.WriteLine(line)
+}
+
+// Connect connects to chat and blocks reading messages until ctx is done,
+// the token can't be used or the login is rejected with *ErrorLogin.
+// When the connection fails, it connects again after ReconnectDelay.
+func (c *Client) Connect(ctx context.Context) error {
+	if ctx == nil {
+		return errNonNilContext
+	}
+
+	base := c.ReconnectDelay
+	if base <= 0 {
+		base = defaultReconnectDelay
+	}
+
+	for delay := base; ; {
+		pass, nick, err := c.credentials(ctx)
+		if err != nil {
+			return err
+		}
+
+		welcomed, err := c.run(ctx, pass, nick)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, ok := err.(*ErrorLogin); ok {
+			return err
+		}
+
+		// Failures of established connections are retried right away,
+		// repeated failures to connect back off.
+		if welcomed {
+			delay = base
+		}
+
+		reason := twitch.ReconnectSessionReconnect
+		if err != errReconnect {
+			reason = twitch.ReconnectConnectionLost
+			if c.OnError != nil {
+				c.OnError(err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+
+			if !welcomed && delay < maxReconnectDelay {
+				delay *= 2
+			}
+		}
+
+		if c.client != nil && c.client.Metrics != nil {
+			c.client.Metrics.IncReconnect(reason)
+		}
+	}
+}
+
+// run logs in and reads messages until the connection fails, it reports
+// whether Twitch welcomed the client.
+func (c *Client) run(ctx context.Context, pass, nick string) (welcomed bool, err error) {
+	conn, err := c.Dial(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	c.setConn(conn)
+	defer c.setConn(nil)
+
+	// Closing the connection stops ReadLine once ctx is done.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	if len(c.Capabilities) > 0 {
+		if err := c.Send("CAP REQ :" + strings.Join(c.Capabilities, " ")); err != nil {
+			return false, err
+		}
+	}
+	if pass != "" {
+		if err := c.Send("PASS " + pass); err != nil {
+			return false, err
+		}
+	}
+	if err := c.Send("NICK " + nick); err != nil {
+		return false, err
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			if ctx.Err() != nil {
+				return welcomed, ctx.Err()
+			}
+			return welcomed, err
+		}
+
+		m, err := ParseMessage(line)
+		if err != nil {
+			continue
+		}
+
+		if m.Command == CommandWelcome {
+			welcomed = true
+		}
+
+		if err := c.handle(m); err != nil {
+			return welcomed, err
+		}
+	}
+}
+
+// handle answers the messages of the connection and calls the handlers.
+func (c *Client) handle(m *Message) error {
+	switch m.Command {
+	case CommandPing:
+		if err := c.Send(CommandPong + " :" + m.Text()); err != nil {
+			return err
+		}
+	case CommandReconnect:
+		return errReconnect
+	case CommandNotice:
+		if m.Channel() == "" && isLoginFailure(m.Text()) {
+			return &ErrorLogin{Message: m.Text()}
+		}
+	case CommandWelcome:
+		if c.OnConnect != nil {
+			c.OnConnect()
+		}
+
+		if channels := c.Channels(); len(channels) > 0 {
+			if err := c.Send(CommandJoin + " #" + strings.Join(channels, ",#")); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.mu.Lock()
+	handlers := append(append([]HandlerFunc(nil), c.handlers[m.Command]...), c.handlers[""]...)
+	c.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(m)
+	}
+
+	return nil
+}
+
+// credentials returns the password and the nick to log in with.
+func (c *Client) credentials(ctx context.Context) (string, string, error) {
+	if c.client == nil {
+		// Anonymous users are named justinfan followed by any number.
+		return "", fmt.Sprintf("justinfan%d", 10000+rand.Intn(90000)), nil
+	}
+
+	token, err := c.client.Token()
+	if err != nil {
+		return "", "", err
+	}
+
+	c.mu.Lock()
+	nick := c.Nick
+	c.mu.Unlock()
+
+	if nick == "" {
+		validation, _, err := c.client.Auth.ValidateToken(ctx)
+		if err != nil {
+			return "", "", err
+		}
+
+		nick = validation.Login
+		c.mu.Lock()
+		c.Nick = nick
+		c.mu.Unlock()
+	}
+
+	return "oauth:" + token.AccessToken, nick, nil
+}
+
+func (c *Client) setConn(conn Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn = conn
+}
+
+// isLoginFailure reports whether the notice of Twitch rejects the login.
+func isLoginFailure(notice string) bool {
+	return notice == "Login authentication failed" || notice == "Improperly formatted auth"
+}
+
+// normalizeChannel returns the channel in lower case without #.
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(channel, "#"))
+}
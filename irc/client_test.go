@@ -0,0 +1,231 @@
+package irc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/holypower777/go-twitch"
+)
+
+var upgrader = websocket.Upgrader{}
+
+// serve runs a chat server calling handler with every line sent by the client.
+func serve(t *testing.T, handler func(conn *websocket.Conn, line string)) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			handler(conn, strings.TrimSuffix(string(data), "\r\n"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func send(conn *websocket.Conn, lines ...string) {
+	conn.WriteMessage(websocket.TextMessage, []byte(strings.Join(lines, "\r\n")+"\r\n"))
+}
+
+func TestClient(t *testing.T) {
+	t.Run("must log in, join channels, answer pings and call handlers", func(t *testing.T) {
+		lines := make(chan string, 10)
+		url := serve(t, func(conn *websocket.Conn, line string) {
+			lines <- line
+
+			switch {
+			case strings.HasPrefix(line, "NICK "):
+				send(conn, ":tmi.twitch.tv 001 ronni :Welcome, GLHF!")
+			case strings.HasPrefix(line, "JOIN "):
+				send(conn,
+					"PING :tmi.twitch.tv",
+					"@display-name=Ronni :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Hello!",
+				)
+			}
+		})
+
+		tc, err := twitch.NewClientWithToken("ClientId", "t0ken", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tc.Close()
+
+		c := NewClient(tc)
+		c.Dial = DialWebSocket(url, nil)
+		c.Nick = "ronni"
+		c.Join("#Dallas")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		messages := make(chan *Message, 1)
+		c.Handle(CommandPrivmsg, func(m *Message) {
+			messages <- m
+			cancel()
+		})
+
+		if err := c.Connect(ctx); err != context.Canceled {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+
+		m := <-messages
+		if m.Channel() != "dallas" || m.Text() != "Hello!" || m.Tags["display-name"] != "Ronni" {
+			t.Errorf("bad message %#v", m)
+		}
+
+		want := []string{
+			"CAP REQ :twitch.tv/tags twitch.tv/commands",
+			"PASS oauth:t0ken",
+			"NICK ronni",
+			"JOIN #dallas",
+			"PONG :tmi.twitch.tv",
+		}
+		for _, w := range want {
+			if got := <-lines; got != w {
+				t.Errorf("got line %q, want %q", got, w)
+			}
+		}
+	})
+
+	t.Run("must log in anonymously without twitch client", func(t *testing.T) {
+		url := serve(t, func(conn *websocket.Conn, line string) {
+			if strings.HasPrefix(line, "NICK justinfan") {
+				send(conn, ":tmi.twitch.tv 001 justinfan123 :Welcome, GLHF!")
+			}
+		})
+
+		c := NewClient(nil)
+		c.Dial = DialWebSocket(url, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		c.OnConnect = cancel
+		if err := c.Connect(ctx); err != context.Canceled {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+	})
+
+	t.Run("must return ErrorLogin, when the token is rejected", func(t *testing.T) {
+		url := serve(t, func(conn *websocket.Conn, line string) {
+			if strings.HasPrefix(line, "NICK ") {
+				send(conn, ":tmi.twitch.tv NOTICE * :Login authentication failed")
+			}
+		})
+
+		tc, _ := twitch.NewClientWithToken("ClientId", "t0ken", nil)
+		defer tc.Close()
+
+		c := NewClient(tc)
+		c.Dial = DialWebSocket(url, nil)
+		c.Nick = "ronni"
+
+		err := c.Connect(context.Background())
+		if e, ok := err.(*ErrorLogin); !ok || e.Message != "Login authentication failed" {
+			t.Errorf("expected *ErrorLogin, got: %v", err)
+		}
+	})
+
+	t.Run("must connect again on RECONNECT", func(t *testing.T) {
+		connects := 0
+		url := serve(t, func(conn *websocket.Conn, line string) {
+			if strings.HasPrefix(line, "NICK ") {
+				send(conn, ":tmi.twitch.tv 001 justinfan123 :Welcome, GLHF!", ":tmi.twitch.tv RECONNECT")
+			}
+		})
+
+		c := NewClient(nil)
+		c.Dial = DialWebSocket(url, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		c.OnConnect = func() {
+			connects++
+			if connects == 2 {
+				cancel()
+			}
+		}
+		if err := c.Connect(ctx); err != context.Canceled {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+	})
+
+	t.Run("must connect again, when the connection drops", func(t *testing.T) {
+		var mu sync.Mutex
+		connects := 0
+		url := serve(t, func(conn *websocket.Conn, line string) {
+			if !strings.HasPrefix(line, "NICK ") {
+				return
+			}
+
+			mu.Lock()
+			connects++
+			first := connects == 1
+			mu.Unlock()
+
+			send(conn, ":tmi.twitch.tv 001 justinfan123 :Welcome, GLHF!")
+			if first {
+				conn.Close()
+			}
+		})
+
+		c := NewClient(nil)
+		c.Dial = DialWebSocket(url, nil)
+		c.ReconnectDelay = time.Millisecond
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		errs := 0
+		c.OnError = func(err error) {
+			errs++
+		}
+
+		welcomes := 0
+		c.OnConnect = func() {
+			welcomes++
+			if welcomes == 2 {
+				cancel()
+			}
+		}
+		if err := c.Connect(ctx); err != context.Canceled {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+
+		if errs != 1 {
+			t.Errorf("expected the dropped connection to be reported, got: %d errors", errs)
+		}
+	})
+
+	t.Run("must return ErrInvalidLine, when the line contains CR or LF", func(t *testing.T) {
+		c := NewClient(nil)
+		for _, text := range []string{"Hello!\r\nPRIVMSG #dallas :injected", "Hello!\n", "Hello!\r"} {
+			if err := c.Say("dallas", text); err != ErrInvalidLine {
+				t.Errorf("expected ErrInvalidLine for %q, got: %v", text, err)
+			}
+		}
+	})
+
+	t.Run("must return ErrNotConnected, when not connected", func(t *testing.T) {
+		if err := NewClient(nil).Say("dallas", "Hello!"); err != ErrNotConnected {
+			t.Errorf("expected ErrNotConnected, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package irc
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	DefaultWebSocketURL = "wss://irc-ws.chat.twitch.tv:443"
+	DefaultTLSAddr      = "irc.chat.twitch.tv:6697"
+)
+
+// Conn is a connection to chat carrying lines without line endings.
+// ReadLine and WriteLine may be called concurrently with each other,
+// Close may be called concurrently with both.
+type Conn interface {
+	ReadLine() (string, error)
+	WriteLine(line string) error
+	Close() error
+}
+
+// DialFunc connects to chat, see DialWebSocket and DialTLS.
+type DialFunc func(ctx context.Context) (Conn, error)
+
+// DialWebSocket connects to chat over WebSocket at url, e.g. DefaultWebSocketURL.
+// If dialer is nil, websocket.DefaultDialer is used.
+func DialWebSocket(url string, dialer *websocket.Dialer) DialFunc {
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+
+	return func(ctx context.Context) (Conn, error) {
+		conn, _, err := dialer.DialContext(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return &webSocketConn{conn: conn}, nil
+	}
+}
+
+// DialTLS connects to chat over TCP secured by TLS at addr, e.g. DefaultTLSAddr,
+// for hosts blocking WebSocket upgrades. If config is nil, the default
+// configuration is used.
+func DialTLS(addr string, config *tls.Config) DialFunc {
+	return func(ctx context.Context) (Conn, error) {
+		dialer := &tls.Dialer{Config: config}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &tcpConn{conn: conn, r: bufio.NewReader(conn)}, nil
+	}
+}
+
+// webSocketConn is a Conn over WebSocket, where a message may carry several lines.
+type webSocketConn struct {
+	conn    *websocket.Conn
+	pending []string
+}
+
+func (c *webSocketConn) ReadLine() (string, error) {
+	for len(c.pending) == 0 {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return "", err
+		}
+
+		for _, line := range strings.Split(string(data), "\r\n") {
+			if line != "" {
+				c.pending = append(c.pending, line)
+			}
+		}
+	}
+
+	line := c.pending[0]
+	c.pending = c.pending[1:]
+
+	return line, nil
+}
+
+func (c *webSocketConn) WriteLine(line string) error {
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(line+"\r\n"))
+}
+
+func (c *webSocketConn) Close() error {
+	return c.conn.Close()
+}
+
+// tcpConn is a Conn over TCP, where lines end with \r\n.
+type tcpConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func (c *tcpConn) ReadLine() (string, error) {
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			return line, nil
+		}
+	}
+}
+
+func (c *tcpConn) WriteLine(line string) error {
+	_, err := c.conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+func (c *tcpConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,65 @@
+package irc
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDialTLS(t *testing.T) {
+	// The test server provides a certificate and a client trusting it.
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: server.TLS.Certificates})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			lines <- line
+
+			if strings.HasPrefix(line, "NICK ") {
+				conn.Write([]byte(":tmi.twitch.tv 001 justinfan123 :Welcome, GLHF!\r\n\r\nPING :tmi.twitch.tv\r\n"))
+			}
+		}
+	}()
+
+	c := NewClient(nil)
+	c.Capabilities = nil
+	c.Dial = DialTLS(ln.Addr().String(), server.Client().Transport.(*http.Transport).TLSClientConfig)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c.Handle(CommandPing, func(m *Message) { cancel() })
+	if err := c.Connect(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+
+	if got := <-lines; !strings.HasPrefix(got, "NICK justinfan") || !strings.HasSuffix(got, "\r\n") {
+		t.Errorf("bad line %q", got)
+	}
+	if got := <-lines; got != "PONG :tmi.twitch.tv\r\n" {
+		t.Errorf("bad line %q", got)
+	}
+}
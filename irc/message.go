@@ -0,0 +1,185 @@
+package irc
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Commands of Twitch chat.
+const (
+	CommandPrivmsg         = "PRIVMSG"
+	CommandWhisper         = "WHISPER"
+	CommandNotice          = "NOTICE"
+	CommandUserNotice      = "USERNOTICE"
+	CommandClearChat       = "CLEARCHAT"
+	CommandClearMsg        = "CLEARMSG"
+	CommandRoomState       = "ROOMSTATE"
+	CommandUserState       = "USERSTATE"
+	CommandGlobalUserState = "GLOBALUSERSTATE"
+	CommandHostTarget      = "HOSTTARGET"
+	CommandJoin            = "JOIN"
+	CommandPart            = "PART"
+	CommandPing            = "PING"
+	CommandPong            = "PONG"
+	CommandReconnect       = "RECONNECT"
+	CommandWelcome         = "001"
+)
+
+var errEmptyMessage = errors.New("irc message is empty")
+
+// Message is a message of Twitch chat, e.g.
+//
+//	@badges=moderator/1;color=#1E90FF :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Hello!
+type Message struct {
+	// Raw is the line the message was parsed from.
+	Raw string
+	// Tags are the IRCv3 tags of the message with unescaped values,
+	// nil if it has none.
+	Tags map[string]string
+	// Prefix is the source of the message, e.g. ronni!ronni@ronni.tmi.twitch.tv.
+	Prefix  string
+	Command string
+	// Params are the parameters of the command, the trailing one included,
+	// e.g. #dallas and Hello!.
+	Params []string
+}
+
+// ParseMessage parses a line of Twitch chat without its line ending.
+func ParseMessage(line string) (*Message, error) {
+	m := &Message{Raw: line}
+	rest := strings.TrimRight(line, "\r\n")
+
+	if strings.HasPrefix(rest, "@") {
+		var tags string
+		tags, rest = cut(rest[1:], " ")
+		m.Tags = parseTags(tags)
+	}
+
+	rest = strings.TrimLeft(rest, " ")
+	if strings.HasPrefix(rest, ":") {
+		m.Prefix, rest = cut(rest[1:], " ")
+	}
+
+	m.Command, rest = cut(strings.TrimLeft(rest, " "), " ")
+	if m.Command == "" {
+		return nil, errEmptyMessage
+	}
+
+	for rest != "" {
+		rest = strings.TrimLeft(rest, " ")
+		if strings.HasPrefix(rest, ":") {
+			m.Params = append(m.Params, rest[1:])
+			break
+		}
+
+		var param string
+		param, rest = cut(rest, " ")
+		if param != "" {
+			m.Params = append(m.Params, param)
+		}
+	}
+
+	return m, nil
+}
+
+// cut returns s before and after the first sep, or s and "".
+func cut(s, sep string) (string, string) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):]
+	}
+
+	return s, ""
+}
+
+var (
+	tagUnescaper = strings.NewReplacer(`\:`, ";", `\s`, " ", `\\`, `\`, `\r`, "\r", `\n`, "\n")
+	tagEscaper   = strings.NewReplacer(";", `\:`, " ", `\s`, `\`, `\\`, "\r", `\r`, "\n", `\n`)
+)
+
+// parseTags parses the tags of a message, e.g. badges=moderator/1;color=#1E90FF.
+func parseTags(s string) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(s, ";") {
+		if tag == "" {
+			continue
+		}
+
+		key, value := cut(tag, "=")
+		tags[key] = tagUnescaper.Replace(value)
+	}
+
+	return tags
+}
+
+// Nick returns the nick of the prefix, e.g. ronni.
+func (m *Message) Nick() string {
+	nick, _ := cut(m.Prefix, "!")
+	if strings.Contains(nick, ".") {
+		// The prefix of the server, e.g. tmi.twitch.tv.
+		return ""
+	}
+
+	return nick
+}
+
+// Channel returns the channel of the message without #, e.g. dallas,
+// or "" if the first parameter is not a channel.
+func (m *Message) Channel() string {
+	if len(m.Params) == 0 || !strings.HasPrefix(m.Params[0], "#") {
+		return ""
+	}
+
+	return m.Params[0][1:]
+}
+
+// Text returns the last parameter, e.g. the text of a PRIVMSG.
+func (m *Message) Text() string {
+	if len(m.Params) == 0 {
+		return ""
+	}
+
+	return m.Params[len(m.Params)-1]
+}
+
+// String returns the line of the message: Raw if it was parsed, otherwise
+// the tags, the command and the parameters, the last one as trailing parameter.
+func (m *Message) String() string {
+	if m.Raw != "" {
+		return m.Raw
+	}
+
+	var b strings.Builder
+	if len(m.Tags) > 0 {
+		keys := make([]string, 0, len(m.Tags))
+		for key := range m.Tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for i, key := range keys {
+			if i == 0 {
+				b.WriteString("@")
+			} else {
+				b.WriteString(";")
+			}
+			b.WriteString(key + "=" + tagEscaper.Replace(m.Tags[key]))
+		}
+		b.WriteString(" ")
+	}
+
+	if m.Prefix != "" {
+		b.WriteString(":" + m.Prefix + " ")
+	}
+	b.WriteString(m.Command)
+
+	for i, param := range m.Params {
+		b.WriteString(" ")
+		if i == len(m.Params)-1 && (param == "" || strings.ContainsAny(param, " :")) {
+			b.WriteString(":")
+		}
+		b.WriteString(param)
+	}
+
+	return b.String()
+}
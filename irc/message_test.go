@@ -0,0 +1,87 @@
+package irc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    *Message
+		nick    string
+		channel string
+	}{
+		{
+			name: "privmsg with tags",
+			line: `@badges=moderator/1;display-name=Ronni;system-msg=Hello\sthere\:)\\ :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Hello: world!`,
+			want: &Message{
+				Tags:    map[string]string{"badges": "moderator/1", "display-name": "Ronni", "system-msg": `Hello there;)\`},
+				Prefix:  "ronni!ronni@ronni.tmi.twitch.tv",
+				Command: CommandPrivmsg,
+				Params:  []string{"#dallas", "Hello: world!"},
+			},
+			nick:    "ronni",
+			channel: "dallas",
+		},
+		{
+			name: "ping",
+			line: "PING :tmi.twitch.tv",
+			want: &Message{Command: CommandPing, Params: []string{"tmi.twitch.tv"}},
+		},
+		{
+			name: "server prefix",
+			line: ":tmi.twitch.tv 001 ronni :Welcome, GLHF!\r\n",
+			want: &Message{Prefix: "tmi.twitch.tv", Command: CommandWelcome, Params: []string{"ronni", "Welcome, GLHF!"}},
+		},
+		{
+			name: "empty tag value",
+			line: "@emote-sets=;login :tmi.twitch.tv CLEARCHAT #dallas",
+			want: &Message{
+				Tags:    map[string]string{"emote-sets": "", "login": ""},
+				Prefix:  "tmi.twitch.tv",
+				Command: CommandClearChat,
+				Params:  []string{"#dallas"},
+			},
+			channel: "dallas",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMessage(tc.line)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tc.want.Raw = tc.line
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("messages are not equal\ngot: %#v\nwant: %#v", got, tc.want)
+			}
+
+			if got.Nick() != tc.nick {
+				t.Errorf("got nick %q, want %q", got.Nick(), tc.nick)
+			}
+			if got.Channel() != tc.channel {
+				t.Errorf("got channel %q, want %q", got.Channel(), tc.channel)
+			}
+
+			got.Raw = ""
+			again, err := ParseMessage(got.String())
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", got.String(), err)
+			}
+			again.Raw = ""
+			if !reflect.DeepEqual(again, got) {
+				t.Errorf("String does not round-trip\ngot: %#v\nwant: %#v", again, got)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		if _, err := ParseMessage("@a=b :prefix "); err != errEmptyMessage {
+			t.Errorf("got error %v, want %v", err, errEmptyMessage)
+		}
+	})
+}
@@ -0,0 +1,562 @@
+package irc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/holypower777/go-twitch"
+)
+
+// ErrorInvalidMessage is returned when a message can't be parsed into
+// a typed message: its command doesn't match or a tag is malformed.
+type ErrorInvalidMessage struct {
+	Command string
+	Message string
+}
+
+func (e *ErrorInvalidMessage) Error() string {
+	return fmt.Sprintf("Message: invalid %s: %s", e.Command, e.Message)
+}
+
+// User is the sender of a message as described by its tags.
+type User struct {
+	Id          string
+	Login       string
+	DisplayName string
+	// Color is the color of the name, e.g. #1E90FF, or "" if the user
+	// didn't choose one.
+	Color string
+	// Badges are the badges shown with the message, Info is the number
+	// of months of subscriber badges.
+	Badges     []*twitch.ChatBadge
+	Moderator  bool
+	Subscriber bool
+	Turbo      bool
+}
+
+// HasBadge reports whether the user has a badge of the set, e.g. vip or broadcaster.
+func (u *User) HasBadge(setId string) bool {
+	for _, b := range u.Badges {
+		if b.SetId == setId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Emote is an emote in the text of a message.
+type Emote struct {
+	Id   string
+	Name string
+	// Start and End are the positions of the first and last character
+	// of the emote in the text, counted in runes.
+	Start int
+	End   int
+}
+
+// PrivateMessage is a PRIVMSG, a message sent to the chat of a channel.
+type PrivateMessage struct {
+	Message *Message
+
+	Id      string
+	Channel string
+	RoomId  string
+	User    User
+	Text    string
+	// Action reports whether the message was sent with /me,
+	// Text is without the ACTION markers then.
+	Action bool
+	Emotes []*Emote
+	Bits   int
+	// FirstMessage reports whether this is the first message of the user in the chat.
+	FirstMessage bool
+	// Reply is the message replied to, nil if the message is no reply.
+	Reply     *twitch.ChatReply
+	Timestamp twitch.Timestamp
+}
+
+// Whisper is a private message to the user of the client.
+type Whisper struct {
+	Message *Message
+
+	Id       string
+	ThreadId string
+	User     User
+	// To is the login of the recipient.
+	To     string
+	Text   string
+	Emotes []*Emote
+}
+
+// UserNotice is a USERNOTICE, an event in a channel like a subscription or raid.
+type UserNotice struct {
+	Message *Message
+
+	Id      string
+	Channel string
+	RoomId  string
+	User    User
+	// Type is the kind of event, e.g. sub, resub, subgift or raid.
+	Type string
+	// SystemMessage is the message of Twitch describing the event.
+	SystemMessage string
+	// Text is the message of the user, "" if none was sent.
+	Text   string
+	Emotes []*Emote
+	// Params are the msg-param- tags describing the event without prefix,
+	// e.g. cumulative-months.
+	Params    map[string]string
+	Timestamp twitch.Timestamp
+}
+
+// ClearChat is a CLEARCHAT, sent when the chat of a channel is cleared or
+// the messages of a user are removed because of a timeout or ban.
+type ClearChat struct {
+	Message *Message
+
+	Channel string
+	RoomId  string
+	// TargetUserId and TargetLogin are the user whose messages were removed,
+	// "" if the whole chat was cleared.
+	TargetUserId string
+	TargetLogin  string
+	// BanDuration is the duration of the timeout, 0 if the user was banned.
+	BanDuration time.Duration
+	Timestamp   twitch.Timestamp
+}
+
+// ClearMessage is a CLEARMSG, sent when a single message was removed.
+type ClearMessage struct {
+	Message *Message
+
+	Channel         string
+	RoomId          string
+	Login           string
+	TargetMessageId string
+	Text            string
+	Timestamp       twitch.Timestamp
+}
+
+// Notice is a NOTICE of Twitch, e.g. the reply to a command.
+type Notice struct {
+	Message *Message
+
+	// Channel is "" for notices sent before joining.
+	Channel string
+	// Id is the kind of notice, e.g. msg_channel_suspended.
+	Id   string
+	Text string
+}
+
+// RoomState is a ROOMSTATE, the chat settings of a channel. Updates only
+// carry the changed settings, the others are nil.
+type RoomState struct {
+	Message *Message
+
+	Channel   string
+	RoomId    string
+	EmoteOnly *bool
+	// FollowersOnly is the number of minutes users must follow to chat,
+	// -1 if disabled.
+	FollowersOnly *int
+	UniqueChat    *bool
+	// Slow is the number of seconds between messages of a user, 0 if disabled.
+	Slow     *int
+	SubsOnly *bool
+}
+
+// UserState is a USERSTATE or GLOBALUSERSTATE, the state of the user of the
+// client in a channel or, for GLOBALUSERSTATE, after logging in.
+type UserState struct {
+	Message *Message
+
+	// Channel is "" for GLOBALUSERSTATE.
+	Channel   string
+	User      User
+	EmoteSets []string
+}
+
+// Parse returns the typed message of m, e.g. *PrivateMessage for a PRIVMSG,
+// or m itself for commands without typed message.
+func Parse(m *Message) (interface{}, error) {
+	switch m.Command {
+	case CommandPrivmsg:
+		return m.PrivateMessage()
+	case CommandWhisper:
+		return m.Whisper()
+	case CommandUserNotice:
+		return m.UserNotice()
+	case CommandClearChat:
+		return m.ClearChat()
+	case CommandClearMsg:
+		return m.ClearMessage()
+	case CommandNotice:
+		return m.Notice()
+	case CommandRoomState:
+		return m.RoomState()
+	case CommandUserState, CommandGlobalUserState:
+		return m.UserState()
+	}
+
+	return m, nil
+}
+
+// PrivateMessage returns the PRIVMSG m as *PrivateMessage.
+func (m *Message) PrivateMessage() (*PrivateMessage, error) {
+	p, err := m.parser(CommandPrivmsg)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := &PrivateMessage{
+		Message:      m,
+		Id:           m.Tags["id"],
+		Channel:      m.Channel(),
+		RoomId:       m.Tags["room-id"],
+		User:         p.user(),
+		Text:         m.Text(),
+		Bits:         p.int("bits"),
+		FirstMessage: p.bool("first-msg"),
+		Timestamp:    p.timestamp("tmi-sent-ts"),
+	}
+
+	// /me messages are sent as CTCP ACTION: \x01ACTION text\x01.
+	if strings.HasPrefix(pm.Text, "\x01ACTION ") && strings.HasSuffix(pm.Text, "\x01") {
+		pm.Text = pm.Text[len("\x01ACTION ") : len(pm.Text)-1]
+		pm.Action = true
+	}
+	pm.Emotes = p.emotes(pm.Text)
+
+	if id := m.Tags["reply-parent-msg-id"]; id != "" {
+		pm.Reply = &twitch.ChatReply{
+			ParentMessageId:   id,
+			ParentMessageBody: m.Tags["reply-parent-msg-body"],
+			ParentUserId:      m.Tags["reply-parent-user-id"],
+			ParentUserLogin:   m.Tags["reply-parent-user-login"],
+			ParentUserName:    m.Tags["reply-parent-display-name"],
+			ThreadMessageId:   m.Tags["reply-thread-parent-msg-id"],
+			ThreadUserLogin:   m.Tags["reply-thread-parent-user-login"],
+		}
+	}
+
+	return pm, p.err
+}
+
+// Whisper returns the WHISPER m as *Whisper.
+func (m *Message) Whisper() (*Whisper, error) {
+	p, err := m.parser(CommandWhisper)
+	if err != nil {
+		return nil, err
+	}
+
+	var to string
+	if len(m.Params) > 1 {
+		to = m.Params[0]
+	}
+
+	return &Whisper{
+		Message:  m,
+		Id:       m.Tags["message-id"],
+		ThreadId: m.Tags["thread-id"],
+		User:     p.user(),
+		To:       to,
+		Text:     m.Text(),
+		Emotes:   p.emotes(m.Text()),
+	}, p.err
+}
+
+// UserNotice returns the USERNOTICE m as *UserNotice.
+func (m *Message) UserNotice() (*UserNotice, error) {
+	p, err := m.parser(CommandUserNotice)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &UserNotice{
+		Message:       m,
+		Id:            m.Tags["id"],
+		Channel:       m.Channel(),
+		RoomId:        m.Tags["room-id"],
+		User:          p.user(),
+		Type:          m.Tags["msg-id"],
+		SystemMessage: m.Tags["system-msg"],
+		Params:        make(map[string]string),
+		Timestamp:     p.timestamp("tmi-sent-ts"),
+	}
+
+	if len(m.Params) > 1 {
+		n.Text = m.Text()
+		n.Emotes = p.emotes(n.Text)
+	}
+
+	for key, value := range m.Tags {
+		if strings.HasPrefix(key, "msg-param-") {
+			n.Params[strings.TrimPrefix(key, "msg-param-")] = value
+		}
+	}
+
+	return n, p.err
+}
+
+// ClearChat returns the CLEARCHAT m as *ClearChat.
+func (m *Message) ClearChat() (*ClearChat, error) {
+	p, err := m.parser(CommandClearChat)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ClearChat{
+		Message:      m,
+		Channel:      m.Channel(),
+		RoomId:       m.Tags["room-id"],
+		TargetUserId: m.Tags["target-user-id"],
+		BanDuration:  time.Duration(p.int("ban-duration")) * time.Second,
+		Timestamp:    p.timestamp("tmi-sent-ts"),
+	}
+
+	if len(m.Params) > 1 {
+		c.TargetLogin = m.Text()
+	}
+
+	return c, p.err
+}
+
+// ClearMessage returns the CLEARMSG m as *ClearMessage.
+func (m *Message) ClearMessage() (*ClearMessage, error) {
+	p, err := m.parser(CommandClearMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClearMessage{
+		Message:         m,
+		Channel:         m.Channel(),
+		RoomId:          m.Tags["room-id"],
+		Login:           m.Tags["login"],
+		TargetMessageId: m.Tags["target-msg-id"],
+		Text:            m.Text(),
+		Timestamp:       p.timestamp("tmi-sent-ts"),
+	}, p.err
+}
+
+// Notice returns the NOTICE m as *Notice.
+func (m *Message) Notice() (*Notice, error) {
+	if _, err := m.parser(CommandNotice); err != nil {
+		return nil, err
+	}
+
+	return &Notice{
+		Message: m,
+		Channel: m.Channel(),
+		Id:      m.Tags["msg-id"],
+		Text:    m.Text(),
+	}, nil
+}
+
+// RoomState returns the ROOMSTATE m as *RoomState.
+func (m *Message) RoomState() (*RoomState, error) {
+	p, err := m.parser(CommandRoomState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoomState{
+		Message:       m,
+		Channel:       m.Channel(),
+		RoomId:        m.Tags["room-id"],
+		EmoteOnly:     p.optionalBool("emote-only"),
+		FollowersOnly: p.optionalInt("followers-only"),
+		UniqueChat:    p.optionalBool("r9k"),
+		Slow:          p.optionalInt("slow"),
+		SubsOnly:      p.optionalBool("subs-only"),
+	}, p.err
+}
+
+// UserState returns the USERSTATE or GLOBALUSERSTATE m as *UserState.
+func (m *Message) UserState() (*UserState, error) {
+	command := CommandUserState
+	if m.Command == CommandGlobalUserState {
+		command = CommandGlobalUserState
+	}
+
+	p, err := m.parser(command)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &UserState{
+		Message: m,
+		Channel: m.Channel(),
+		User:    p.user(),
+	}
+	if sets := m.Tags["emote-sets"]; sets != "" {
+		s.EmoteSets = strings.Split(sets, ",")
+	}
+
+	return s, p.err
+}
+
+// tagParser parses the tags of a message, keeping the first error.
+type tagParser struct {
+	m   *Message
+	err error
+}
+
+func (m *Message) parser(command string) (*tagParser, error) {
+	if m.Command != command {
+		return nil, &ErrorInvalidMessage{Command: command, Message: "got " + m.Command}
+	}
+
+	return &tagParser{m: m}, nil
+}
+
+func (p *tagParser) fail(key, value string) {
+	if p.err == nil {
+		p.err = &ErrorInvalidMessage{
+			Command: p.m.Command,
+			Message: fmt.Sprintf("bad tag %s=%q", key, value),
+		}
+	}
+}
+
+func (p *tagParser) int(key string) int {
+	value, ok := p.m.Tags[key]
+	if !ok || value == "" {
+		return 0
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		p.fail(key, value)
+	}
+
+	return i
+}
+
+func (p *tagParser) optionalInt(key string) *int {
+	if _, ok := p.m.Tags[key]; !ok {
+		return nil
+	}
+
+	i := p.int(key)
+	return &i
+}
+
+func (p *tagParser) bool(key string) bool {
+	return p.m.Tags[key] == "1"
+}
+
+func (p *tagParser) optionalBool(key string) *bool {
+	if _, ok := p.m.Tags[key]; !ok {
+		return nil
+	}
+
+	b := p.bool(key)
+	return &b
+}
+
+// timestamp parses a Unix time in milliseconds.
+func (p *tagParser) timestamp(key string) twitch.Timestamp {
+	value := p.m.Tags[key]
+	if value == "" {
+		return twitch.Timestamp{}
+	}
+
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		p.fail(key, value)
+		return twitch.Timestamp{}
+	}
+
+	return twitch.Timestamp{Time: time.UnixMilli(ms)}
+}
+
+func (p *tagParser) user() User {
+	u := User{
+		Id:          p.m.Tags["user-id"],
+		Login:       p.m.Tags["login"],
+		DisplayName: p.m.Tags["display-name"],
+		Color:       p.m.Tags["color"],
+		Badges:      p.badges(),
+		Moderator:   p.bool("mod"),
+		Subscriber:  p.bool("subscriber"),
+		Turbo:       p.bool("turbo"),
+	}
+	if u.Login == "" {
+		u.Login = p.m.Nick()
+	}
+
+	return u
+}
+
+// badges parses badges and badge-info, e.g. broadcaster/1,subscriber/12
+// and subscriber/16.
+func (p *tagParser) badges() []*twitch.ChatBadge {
+	value := p.m.Tags["badges"]
+	if value == "" {
+		return nil
+	}
+
+	info := make(map[string]string)
+	for _, badge := range strings.Split(p.m.Tags["badge-info"], ",") {
+		if setId, i, ok := strings.Cut(badge, "/"); ok {
+			info[setId] = i
+		}
+	}
+
+	var badges []*twitch.ChatBadge
+	for _, badge := range strings.Split(value, ",") {
+		setId, id, ok := strings.Cut(badge, "/")
+		if !ok {
+			p.fail("badges", value)
+			continue
+		}
+		badges = append(badges, &twitch.ChatBadge{SetId: setId, Id: id, Info: info[setId]})
+	}
+
+	return badges
+}
+
+// emotes parses emotes, e.g. 25:0-4,12-16/1902:6-10, naming them after text.
+func (p *tagParser) emotes(text string) []*Emote {
+	value := p.m.Tags["emotes"]
+	if value == "" {
+		return nil
+	}
+
+	runes := []rune(text)
+	var emotes []*Emote
+	for _, emote := range strings.Split(value, "/") {
+		id, positions, ok := strings.Cut(emote, ":")
+		if !ok {
+			p.fail("emotes", value)
+			continue
+		}
+
+		for _, position := range strings.Split(positions, ",") {
+			start, end, _ := strings.Cut(position, "-")
+			e := &Emote{Id: id}
+
+			var err1, err2 error
+			e.Start, err1 = strconv.Atoi(start)
+			e.End, err2 = strconv.Atoi(end)
+			if err1 != nil || err2 != nil || e.Start > e.End {
+				p.fail("emotes", value)
+				continue
+			}
+
+			if e.End < len(runes) {
+				e.Name = string(runes[e.Start : e.End+1])
+			}
+			emotes = append(emotes, e)
+		}
+	}
+
+	sort.Slice(emotes, func(i, j int) bool { return emotes[i].Start < emotes[j].Start })
+
+	return emotes
+}
@@ -0,0 +1,193 @@
+package irc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/holypower777/go-twitch"
+)
+
+func intPtr(i int) *int    { return &i }
+func boolPtr(b bool) *bool { return &b }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want interface{}
+	}{
+		{
+			name: "privmsg",
+			line: `@badge-info=subscriber/16;badges=moderator/1,subscriber/12;bits=100;color=#1E90FF;display-name=Ronni;emotes=25:0-4,12-16/1902:6-10;first-msg=1;id=b34ccfc7;mod=1;room-id=1337;subscriber=1;tmi-sent-ts=1507246572675;turbo=0;user-id=1337 :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Kappa Keepo Kappa`,
+			want: &PrivateMessage{
+				Id:      "b34ccfc7",
+				Channel: "dallas",
+				RoomId:  "1337",
+				User: User{
+					Id:          "1337",
+					Login:       "ronni",
+					DisplayName: "Ronni",
+					Color:       "#1E90FF",
+					Badges: []*twitch.ChatBadge{
+						{SetId: "moderator", Id: "1"},
+						{SetId: "subscriber", Id: "12", Info: "16"},
+					},
+					Moderator:  true,
+					Subscriber: true,
+				},
+				Text: "Kappa Keepo Kappa",
+				Emotes: []*Emote{
+					{Id: "25", Name: "Kappa", Start: 0, End: 4},
+					{Id: "1902", Name: "Keepo", Start: 6, End: 10},
+					{Id: "25", Name: "Kappa", Start: 12, End: 16},
+				},
+				Bits:         100,
+				FirstMessage: true,
+				Timestamp:    twitch.Timestamp{Time: time.UnixMilli(1507246572675)},
+			},
+		},
+		{
+			name: "action reply",
+			line: "@reply-parent-msg-id=b34ccfc7;reply-parent-msg-body=Hello\\sthere;reply-parent-user-login=dallas :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :\x01ACTION waves\x01",
+			want: &PrivateMessage{
+				Channel: "dallas",
+				User:    User{Login: "ronni"},
+				Text:    "waves",
+				Action:  true,
+				Reply: &twitch.ChatReply{
+					ParentMessageId:   "b34ccfc7",
+					ParentMessageBody: "Hello there",
+					ParentUserLogin:   "dallas",
+				},
+			},
+		},
+		{
+			name: "whisper",
+			line: "@display-name=Ronni;message-id=6;thread-id=1337_4242;user-id=4242 :ronni!ronni@ronni.tmi.twitch.tv WHISPER dallas :Hello!",
+			want: &Whisper{
+				Id:       "6",
+				ThreadId: "1337_4242",
+				User:     User{Id: "4242", Login: "ronni", DisplayName: "Ronni"},
+				To:       "dallas",
+				Text:     "Hello!",
+			},
+		},
+		{
+			name: "usernotice",
+			line: `@id=db25007f;login=ronni;msg-id=resub;msg-param-cumulative-months=6;msg-param-sub-plan=Prime;room-id=1337;system-msg=ronni\shas\ssubscribed\sfor\s6\smonths!;tmi-sent-ts=1507246572675;user-id=4242 :tmi.twitch.tv USERNOTICE #dallas :Great stream`,
+			want: &UserNotice{
+				Id:            "db25007f",
+				Channel:       "dallas",
+				RoomId:        "1337",
+				User:          User{Id: "4242", Login: "ronni"},
+				Type:          "resub",
+				SystemMessage: "ronni has subscribed for 6 months!",
+				Text:          "Great stream",
+				Params:        map[string]string{"cumulative-months": "6", "sub-plan": "Prime"},
+				Timestamp:     twitch.Timestamp{Time: time.UnixMilli(1507246572675)},
+			},
+		},
+		{
+			name: "clearchat",
+			line: "@ban-duration=350;room-id=1337;target-user-id=4242;tmi-sent-ts=1507246572675 :tmi.twitch.tv CLEARCHAT #dallas :ronni",
+			want: &ClearChat{
+				Channel:      "dallas",
+				RoomId:       "1337",
+				TargetUserId: "4242",
+				TargetLogin:  "ronni",
+				BanDuration:  350 * time.Second,
+				Timestamp:    twitch.Timestamp{Time: time.UnixMilli(1507246572675)},
+			},
+		},
+		{
+			name: "clearmsg",
+			line: "@login=ronni;room-id=;target-msg-id=abc-123;tmi-sent-ts=1507246572675 :tmi.twitch.tv CLEARMSG #dallas :HeyGuys",
+			want: &ClearMessage{
+				Channel:         "dallas",
+				Login:           "ronni",
+				TargetMessageId: "abc-123",
+				Text:            "HeyGuys",
+				Timestamp:       twitch.Timestamp{Time: time.UnixMilli(1507246572675)},
+			},
+		},
+		{
+			name: "notice",
+			line: "@msg-id=slow_off :tmi.twitch.tv NOTICE #dallas :This room is no longer in slow mode.",
+			want: &Notice{Channel: "dallas", Id: "slow_off", Text: "This room is no longer in slow mode."},
+		},
+		{
+			name: "roomstate",
+			line: "@emote-only=0;followers-only=-1;r9k=0;room-id=1337;slow=0;subs-only=0 :tmi.twitch.tv ROOMSTATE #dallas",
+			want: &RoomState{
+				Channel:       "dallas",
+				RoomId:        "1337",
+				EmoteOnly:     boolPtr(false),
+				FollowersOnly: intPtr(-1),
+				UniqueChat:    boolPtr(false),
+				Slow:          intPtr(0),
+				SubsOnly:      boolPtr(false),
+			},
+		},
+		{
+			name: "roomstate update",
+			line: "@room-id=1337;slow=10 :tmi.twitch.tv ROOMSTATE #dallas",
+			want: &RoomState{Channel: "dallas", RoomId: "1337", Slow: intPtr(10)},
+		},
+		{
+			name: "globaluserstate",
+			line: "@badges=staff/1;color=#0D4200;display-name=Ronni;emote-sets=0,33,50;user-id=4242 :tmi.twitch.tv GLOBALUSERSTATE",
+			want: &UserState{
+				User: User{
+					Id:          "4242",
+					DisplayName: "Ronni",
+					Color:       "#0D4200",
+					Badges:      []*twitch.ChatBadge{{SetId: "staff", Id: "1"}},
+				},
+				EmoteSets: []string{"0", "33", "50"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := ParseMessage(tc.line)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Parse(m)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// The typed message keeps the message it was parsed from.
+			reflect.ValueOf(tc.want).Elem().FieldByName("Message").Set(reflect.ValueOf(m))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("messages are not equal\ngot: %+v\nwant: %+v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("must return the message of commands without typed message", func(t *testing.T) {
+		m, _ := ParseMessage("PING :tmi.twitch.tv")
+		if got, err := Parse(m); err != nil || got != m {
+			t.Errorf("expected the message, got: %v, %v", got, err)
+		}
+	})
+
+	t.Run("must return ErrorInvalidMessage, when a tag is malformed", func(t *testing.T) {
+		m, _ := ParseMessage("@tmi-sent-ts=now :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Hello!")
+		_, err := m.PrivateMessage()
+		if err == nil || err.Error() != `Message: invalid PRIVMSG: bad tag tmi-sent-ts="now"` {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("must return ErrorInvalidMessage, when the command doesn't match", func(t *testing.T) {
+		m, _ := ParseMessage("PING :tmi.twitch.tv")
+		if _, err := m.Whisper(); err == nil || err.Error() != "Message: invalid WHISPER: got PING" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
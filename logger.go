@@ -0,0 +1,98 @@
+package twitch
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+var (
+	redactedHeaders = []string{"Authorization", "Client-Secret"}
+	redactedJSON    = regexp.MustCompile(`("(?:access_token|refresh_token|client_secret|stream_key|device_code)"\s*:\s*")[^"]*"`)
+	redactedForm    = regexp.MustCompile(`((?:^|[&?\s])(?:client_secret|refresh_token|token|code|code_verifier|device_code)=)[^&\s]*`)
+)
+
+// Logger logs messages of the client with key-value pairs, e.g.
+//
+//	client.Logger = twitch.LoggerFunc(func(msg string, keyvals ...interface{}) {
+//		slogger.Debug(msg, keyvals...)
+//	})
+type Logger interface {
+	Log(msg string, keyvals ...interface{})
+}
+
+// The LoggerFunc type is an adapter to allow the use of ordinary
+// functions as loggers.
+type LoggerFunc func(msg string, keyvals ...interface{})
+
+func (f LoggerFunc) Log(msg string, keyvals ...interface{}) {
+	f(msg, keyvals...)
+}
+
+// NewStdLogger returns a logger writing to l as "msg key=value ...".
+func NewStdLogger(l *log.Logger) Logger {
+	return LoggerFunc(func(msg string, keyvals ...interface{}) {
+		var b strings.Builder
+		b.WriteString(msg)
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			fmt.Fprintf(&b, " %v=%v", keyvals[i], keyvals[i+1])
+		}
+		l.Print(b.String())
+	})
+}
+
+// logRoundTrip logs the request sent with next. With Debug of the client,
+// requests and responses are dumped with credentials and stream keys redacted.
+func (c *Client) logRoundTrip(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.Debug {
+			if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+				c.Logger.Log("twitch request", "dump", redact(dump, req.Header))
+			}
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		keyvals := []interface{}{"method", req.Method, "url", req.URL.Redacted(), "duration", time.Since(start)}
+
+		if err != nil {
+			c.Logger.Log("twitch request failed", append(keyvals, "error", err)...)
+			return resp, err
+		}
+
+		keyvals = append(keyvals, "status", resp.StatusCode)
+		if id := requestId(resp.Header); id != "" {
+			keyvals = append(keyvals, "request_id", id)
+		}
+		c.Logger.Log("twitch request", keyvals...)
+
+		if c.Debug {
+			if dump, err := httputil.DumpResponse(resp, true); err == nil {
+				c.Logger.Log("twitch response", "dump", redact(dump, resp.Header))
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+// redact removes credentials and stream keys of a dump of a request or response with header.
+func redact(dump []byte, header http.Header) string {
+	s := string(dump)
+	for _, name := range redactedHeaders {
+		if value := header.Get(name); value != "" {
+			s = strings.ReplaceAll(s, value, redacted)
+		}
+	}
+
+	s = redactedJSON.ReplaceAllString(s, `${1}`+redacted+`"`)
+	s = redactedForm.ReplaceAllString(s, `${1}`+redacted)
+
+	return s
+}
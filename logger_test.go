@@ -0,0 +1,82 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("must log requests and redact dumps", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/streams/key", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[{"stream_key":"live_44322889_a34ub37c8ajv98a0"}]}`)
+		})
+
+		var buf bytes.Buffer
+		c.Logger = NewStdLogger(log.New(&buf, "", 0))
+		c.Debug = true
+		c.Use(func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("Authorization", "Bearer s3cret")
+				return next(req)
+			}
+		})
+
+		_, _, err := c.Streams.GetStreamKey(context.Background(), &BroadcasterID{Id: "1"})
+		assertNoError(t, err)
+
+		out := buf.String()
+		for _, secret := range []string{"s3cret", "live_44322889_a34ub37c8ajv98a0"} {
+			if strings.Contains(out, secret) {
+				t.Errorf("%s is not redacted:\n%s", secret, out)
+			}
+		}
+
+		if !strings.Contains(out, "twitch request method=GET") || !strings.Contains(out, "status=200") {
+			t.Errorf("request is not logged:\n%s", out)
+		}
+	})
+
+	t.Run("must not dump without debug", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		var msgs []string
+		c.Logger = LoggerFunc(func(msg string, keyvals ...interface{}) { msgs = append(msgs, msg) })
+
+		_, _, err := c.Streams.GetStreams(context.Background(), nil)
+		assertNoError(t, err)
+
+		if len(msgs) != 1 || msgs[0] != "twitch request" {
+			t.Errorf("bad messages: %v", msgs)
+		}
+	})
+}
+
+func TestRedact(t *testing.T) {
+	form := url.Values{"client_id": {"id"}, "client_secret": {"s3cret"}, "refresh_token": {"r3fresh"}}.Encode()
+	body := `{"access_token":"acc3ss","refresh_token":"r3fresh","expires_in":3600}`
+
+	got := redact([]byte(form+"\n"+body), http.Header{})
+	for _, secret := range []string{"s3cret", "r3fresh", "acc3ss"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("%s is not redacted: %s", secret, got)
+		}
+	}
+
+	if !strings.Contains(got, "client_id=id") || !strings.Contains(got, `"expires_in":3600`) {
+		t.Errorf("too much is redacted: %s", got)
+	}
+}
@@ -0,0 +1,220 @@
+package twitch
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ReconnectSessionReconnect = "session_reconnect"
+	ReconnectKeepaliveTimeout = "keepalive_timeout"
+	ReconnectConnectionLost   = "connection_lost"
+)
+
+// Metrics records what the client does. Endpoints are paths
+// relative to the base URL, e.g. streams. Status is 0 for requests
+// failed without a response.
+type Metrics interface {
+	ObserveRequest(endpoint string, status int, duration time.Duration)
+	ObserveRateLimit(remaining, limit int)
+	IncRetry(endpoint string)
+	IncReconnect(reason string)
+}
+
+func (c *Client) observeRoundTrip(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(req)
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.Metrics.ObserveRequest(c.endpoint(req), status, time.Since(start))
+
+		return resp, err
+	}
+}
+
+// DefaultDurationBuckets are the request duration buckets in seconds
+// of PrometheusMetrics.
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// PrometheusMetrics implements Metrics and serves them
+// in the Prometheus text format:
+//
+//	metrics := twitch.NewPrometheusMetrics("twitch")
+//	client.Metrics = metrics
+//	http.Handle("/metrics", metrics)
+type PrometheusMetrics struct {
+	Namespace string
+	Buckets   []float64
+
+	mu                 sync.Mutex
+	requests           map[[2]string]uint64
+	durations          map[string]*histogram
+	rateLimitRemaining int
+	rateLimitLimit     int
+	retries            map[string]uint64
+	reconnects         map[string]uint64
+}
+
+func NewPrometheusMetrics(namespace string) *PrometheusMetrics {
+	return &PrometheusMetrics{
+		Namespace:  namespace,
+		Buckets:    DefaultDurationBuckets,
+		requests:   make(map[[2]string]uint64),
+		durations:  make(map[string]*histogram),
+		retries:    make(map[string]uint64),
+		reconnects: make(map[string]uint64),
+	}
+}
+
+func (m *PrometheusMetrics) ObserveRequest(endpoint string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[[2]string{endpoint, strconv.Itoa(status)}]++
+
+	h := m.durations[endpoint]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(m.Buckets))}
+		m.durations[endpoint] = h
+	}
+
+	seconds := duration.Seconds()
+	for i, le := range m.Buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+func (m *PrometheusMetrics) ObserveRateLimit(remaining, limit int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rateLimitRemaining, m.rateLimitLimit = remaining, limit
+}
+
+func (m *PrometheusMetrics) IncRetry(endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries[endpoint]++
+}
+
+func (m *PrometheusMetrics) IncReconnect(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reconnects[reason]++
+}
+
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	name := m.name("requests_total")
+	writeHeader(&b, name, "counter", "Requests sent to Twitch by endpoint and status.")
+	requests := make([][2]string, 0, len(m.requests))
+	for key := range m.requests {
+		requests = append(requests, key)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i][0] != requests[j][0] {
+			return requests[i][0] < requests[j][0]
+		}
+		return requests[i][1] < requests[j][1]
+	})
+	for _, key := range requests {
+		fmt.Fprintf(&b, "%s{endpoint=%s,status=%s} %d\n", name, quoteLabel(key[0]), quoteLabel(key[1]), m.requests[key])
+	}
+
+	name = m.name("request_duration_seconds")
+	writeHeader(&b, name, "histogram", "Duration of requests sent to Twitch by endpoint.")
+	for _, endpoint := range sortedKeys(m.durations) {
+		h := m.durations[endpoint]
+		for i, le := range m.Buckets {
+			fmt.Fprintf(&b, "%s_bucket{endpoint=%s,le=\"%s\"} %d\n", name, quoteLabel(endpoint), formatFloat(le), h.counts[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket{endpoint=%s,le=\"+Inf\"} %d\n", name, quoteLabel(endpoint), h.count)
+		fmt.Fprintf(&b, "%s_sum{endpoint=%s} %s\n", name, quoteLabel(endpoint), formatFloat(h.sum))
+		fmt.Fprintf(&b, "%s_count{endpoint=%s} %d\n", name, quoteLabel(endpoint), h.count)
+	}
+
+	name = m.name("rate_limit_remaining")
+	writeHeader(&b, name, "gauge", "Points left in the rate limit bucket.")
+	fmt.Fprintf(&b, "%s %d\n", name, m.rateLimitRemaining)
+
+	name = m.name("rate_limit_limit")
+	writeHeader(&b, name, "gauge", "Size of the rate limit bucket.")
+	fmt.Fprintf(&b, "%s %d\n", name, m.rateLimitLimit)
+
+	writeCounters(&b, m.name("retries_total"), "Retried requests by endpoint.", "endpoint", m.retries)
+	writeCounters(&b, m.name("websocket_reconnects_total"), "Reconnects of EventSub WebSockets by reason.", "reason", m.reconnects)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (m *PrometheusMetrics) name(name string) string {
+	if m.Namespace == "" {
+		return name
+	}
+
+	return m.Namespace + "_" + name
+}
+
+func writeHeader(b *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func writeCounters(b *strings.Builder, name, help, label string, counters map[string]uint64) {
+	writeHeader(b, name, "counter", help)
+	for _, value := range sortedKeys(counters) {
+		fmt.Fprintf(b, "%s{%s=%s} %d\n", name, label, quoteLabel(value), counters[value])
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabel(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,56 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set(headerRateLimit, "800")
+		w.Header().Set(headerRateRemaining, "799")
+		w.Header().Set(headerRateReset, fmt.Sprint(time.Now().Add(time.Minute).Unix()))
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	metrics := NewPrometheusMetrics("twitch")
+	c.Metrics = metrics
+	c.RetryPolicy = &DefaultRetryPolicy{Backoff: time.Millisecond}
+
+	_, _, err := c.Streams.GetStreams(context.Background(), nil)
+	assertNoError(t, err)
+	metrics.IncReconnect(ReconnectSessionReconnect)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+
+	for _, want := range []string{
+		`twitch_requests_total{endpoint="streams",status="200"} 1`,
+		`twitch_requests_total{endpoint="streams",status="503"} 1`,
+		`twitch_request_duration_seconds_count{endpoint="streams"} 2`,
+		`twitch_request_duration_seconds_bucket{endpoint="streams",le="+Inf"} 2`,
+		`twitch_rate_limit_remaining 799`,
+		`twitch_rate_limit_limit 800`,
+		`twitch_retries_total{endpoint="streams"} 1`,
+		`twitch_websocket_reconnects_total{reason="session_reconnect"} 1`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("metrics don't contain %s:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package twitch
+
+import "net/http"
+
+// RoundTripFunc sends a request and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of requests, e.g. to change requests, log them
+// or answer them from a cache:
+//
+//	client.Use(func(next twitch.RoundTripFunc) twitch.RoundTripFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			log.Printf("%s %s took %v", req.Method, req.URL.Path, time.Since(start))
+//			return resp, err
+//		}
+//	})
+//
+// Every attempt of a request, including retries, goes through the middleware.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use adds middleware to the client, the first added runs first.
+// It must not be called concurrently with requests.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
@@ -0,0 +1,50 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		assertQuery(t, r, params{"game_id": "509658"})
+		if got := r.Header.Get("X-Kek"); got != "lol" {
+			t.Errorf("bad header: %s", got)
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(req)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	c.Use(trace("first"), func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Kek", "lol")
+			return next(req)
+		}
+	})
+	c.Use(trace("second"))
+
+	_, _, err := c.Streams.GetStreams(context.Background(), &StreamsOptions{GameId: "509658"})
+	assertNoError(t, err)
+
+	want := []string{"first before", "second before", "second after", "first after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("\ngot: %v\nwant: %v", calls, want)
+	}
+}
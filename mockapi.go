@@ -0,0 +1,70 @@
+package twitch
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Paths of the mock API of the Twitch CLI.
+const (
+	mockAPIHelixPath = "mock/"
+	mockAPIAuthPath  = "auth/"
+)
+
+// WithMockAPI makes the copy call the mock API of the Twitch CLI at baseURL,
+// e.g. http://localhost:8080 of twitch mock-api start, instead of Twitch:
+//
+//	mockURL, _ := url.Parse("http://localhost:8080")
+//	mock := client.With(twitch.WithMockAPI(mockURL))
+//
+// Helix calls go to /mock/ and the auth server is /auth/. Tokens of Twitch
+// are unknown to the mock, so the app token of the copy is fetched from the
+// mock with the client id and secret of the credentials, which must be those
+// of a client generated by twitch mock-api generate. A user token must be
+// one issued by the mock, e.g. set with WithAccessToken. Responses cached
+// by c are not used.
+func WithMockAPI(baseURL *url.URL) Option {
+	return func(c *Client) {
+		c.BaseURL = mockAPIURL(baseURL, mockAPIHelixPath)
+		c.AuthURL = mockAPIURL(baseURL, mockAPIAuthPath)
+		c.Cache = nil
+
+		if c.credentials.ClientSecret == "" {
+			return
+		}
+
+		// The mock reads the parameters of the token request from the query.
+		query := url.Values{
+			"client_id":     {c.credentials.ClientId},
+			"client_secret": {c.credentials.ClientSecret},
+			"grant_type":    {"client_credentials"},
+		}
+		config := &clientcredentials.Config{
+			ClientID:     c.credentials.ClientId,
+			ClientSecret: c.credentials.ClientSecret,
+			TokenURL:     c.AuthURL.String() + tokenPath + "?" + query.Encode(),
+			AuthStyle:    oauth2.AuthStyleInParams,
+		}
+
+		appHTTPClient := config.Client(context.WithValue(c.ctx, oauth2.HTTPClient, baseHTTPClient(c.HTTPClient)))
+
+		if c.tokenSource == nil && c.credentials.AccessToken == "" {
+			c.HTTPClient = appHTTPClient
+		} else {
+			c.appHTTPClient = appHTTPClient
+		}
+	}
+}
+
+// mockAPIURL returns the URL of path of the mock API at baseURL.
+func mockAPIURL(baseURL *url.URL, path string) *url.URL {
+	u := *baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + path
+	u.RawPath = ""
+
+	return &u
+}
@@ -0,0 +1,98 @@
+//go:build integration
+
+package twitch
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"testing"
+)
+
+// TestMockAPIIntegration calls the services against the mock API of the
+// Twitch CLI, run with:
+//
+//	twitch mock-api generate
+//	twitch mock-api start
+//	TWITCH_MOCK_API_URL=http://localhost:8080 TWITCH_MOCK_CLIENT_ID=... TWITCH_MOCK_CLIENT_SECRET=... \
+//		go test -tags integration -run TestMockAPIIntegration
+//
+// Calls requiring a user token run if TWITCH_MOCK_USER_TOKEN and
+// TWITCH_MOCK_USER_ID are set to a token issued by the mock and its user.
+func TestMockAPIIntegration(t *testing.T) {
+	mockURL, err := url.Parse(os.Getenv("TWITCH_MOCK_API_URL"))
+	if err != nil || mockURL.Host == "" {
+		t.Skip("TWITCH_MOCK_API_URL is not set")
+	}
+
+	c, err := NewClient(&Credentials{
+		ClientId:     os.Getenv("TWITCH_MOCK_CLIENT_ID"),
+		ClientSecret: os.Getenv("TWITCH_MOCK_CLIENT_SECRET"),
+	}, nil)
+	assertNoError(t, err)
+	defer c.Close()
+
+	c = c.With(WithMockAPI(mockURL))
+	ctx := context.Background()
+
+	var userIds []string
+
+	t.Run("Streams", func(t *testing.T) {
+		streams, _, err := c.Streams.GetStreams(ctx, &StreamsOptions{First: 20})
+		assertNoError(t, err)
+
+		for _, s := range streams.Data {
+			userIds = append(userIds, s.UserId)
+		}
+
+		_, _, err = c.Streams.GetAllStreams(ctx, nil, 50)
+		assertNoError(t, err)
+
+		if len(userIds) > 0 {
+			_, _, err = c.Streams.GetStreamsByUsers(ctx, &StreamsOptions{UserIds: userIds}, 2)
+			assertNoError(t, err)
+		}
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		if len(userIds) == 0 {
+			t.Skip("the mock has no live streams")
+		}
+
+		users, _, err := c.Users.GetUsers(ctx, &UsersOptions{Ids: userIds})
+		assertNoError(t, err)
+
+		if len(users) == 0 {
+			t.Error("users of the streams must be returned")
+		}
+
+		_, _, err = c.Users.GetUsersAll(ctx, &UsersOptions{Ids: userIds}, 2)
+		assertNoError(t, err)
+	})
+
+	t.Run("EventSub", func(t *testing.T) {
+		_, _, err := c.EventSub.GetSubscriptions(ctx, nil)
+		assertNoError(t, err)
+	})
+
+	userToken, userId := os.Getenv("TWITCH_MOCK_USER_TOKEN"), os.Getenv("TWITCH_MOCK_USER_ID")
+	if userToken == "" || userId == "" {
+		t.Log("TWITCH_MOCK_USER_TOKEN or TWITCH_MOCK_USER_ID is not set, calls of users are skipped")
+		return
+	}
+
+	u := c.With(WithAccessToken(userToken), WithBroadcasterId(userId))
+
+	t.Run("Streams of the user", func(t *testing.T) {
+		_, _, err := u.Streams.GetFollowedStreams(ctx, &StreamsOptions{UserId: userId})
+		assertNoError(t, err)
+
+		_, _, err = u.Streams.GetStreamKey(ctx, nil)
+		assertNoError(t, err)
+	})
+
+	t.Run("Users of the user", func(t *testing.T) {
+		_, _, err := u.Users.GetUserBlockList(ctx, &UserBlockListOptions{BroadcasterId: userId})
+		assertNoError(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWithMockAPI(t *testing.T) {
+	c, _, _, teardown := setup()
+	defer teardown()
+
+	mux := http.NewServeMux()
+	mock := httptest.NewServer(mux)
+	defer mock.Close()
+
+	mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, r, http.MethodPost)
+		if got := r.URL.Query(); got.Get("client_id") != creds.ClientId || got.Get("grant_type") != "client_credentials" {
+			t.Errorf("bad token request %v", got)
+		}
+
+		w.Header().Set("Content-Type", applicationJSON)
+		fmt.Fprint(w, `{"access_token":"mock-token","token_type":"bearer","expires_in":3600}`)
+	})
+
+	mux.HandleFunc("/mock/streams", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer mock-token" {
+			t.Errorf("bad Authorization: %q", got)
+		}
+		fmt.Fprint(w, `{"data":[{"id":"1"}]}`)
+	})
+
+	mockURL, _ := url.Parse(mock.URL)
+	m := c.With(WithMockAPI(mockURL))
+
+	if got, want := m.AuthURL.String(), mock.URL+"/auth/"; got != want {
+		t.Errorf("bad auth url\ngot: %s\nwant: %s", got, want)
+	}
+
+	streams, _, err := m.Streams.GetStreams(context.Background(), nil)
+	assertNoError(t, err)
+
+	if len(streams.Data) != 1 || streams.Data[0].Id != "1" {
+		t.Errorf("bad streams %+v", streams)
+	}
+
+	if c.BaseURL.Host == mockURL.Host {
+		t.Error("client must not call the mock")
+	}
+}
@@ -0,0 +1,258 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// PageFunc returns the items of the page starting at cursor,
+// the cursor of the next page and the response.
+type PageFunc[T any] func(ctx context.Context, cursor string) ([]T, string, *Response, error)
+
+// Pages iterates over the pages of a paginated endpoint:
+//
+//	pages := client.Streams.StreamPages(ctx, &StreamsOptions{GameId: "509658"})
+//	for pages.Next() {
+//		for _, stream := range pages.Items() {
+//			...
+//		}
+//	}
+//	if err := pages.Err(); err != nil {
+//		...
+//	}
+type Pages[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+
+	cursor string
+	items  []T
+	resp   *Response
+	err    error
+	done   bool
+}
+
+// NewPages returns pages fetched with fetch, starting at cursor.
+// An empty cursor starts at the first page.
+func NewPages[T any](ctx context.Context, cursor string, fetch PageFunc[T]) *Pages[T] {
+	return &Pages[T]{ctx: ctx, cursor: cursor, fetch: fetch}
+}
+
+// Next fetches the next page. It returns false when there are
+// no more pages or the request failed, see Err.
+func (p *Pages[T]) Next() bool {
+	if p.done {
+		return false
+	}
+
+	items, cursor, resp, err := p.fetch(p.ctx, p.cursor)
+	p.resp = resp
+	if err != nil {
+		p.err = err
+		p.items = nil
+		p.done = true
+		return false
+	}
+
+	p.items = items
+	p.cursor = cursor
+	p.done = cursor == ""
+
+	return true
+}
+
+// Items returns the items of the current page.
+func (p *Pages[T]) Items() []T {
+	return p.items
+}
+
+// Response returns the response of the current page.
+func (p *Pages[T]) Response() *Response {
+	return p.resp
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pages[T]) Err() error {
+	return p.err
+}
+
+// Collect fetches the remaining pages until there are no more or max items
+// are collected, max of 0 means no limit. Requests are paced by
+// the rate limiter of the client, as any other request.
+func (p *Pages[T]) Collect(max int) ([]T, *Response, error) {
+	var all []T
+	for (max <= 0 || len(all) < max) && p.Next() {
+		all = append(all, p.Items()...)
+	}
+
+	if p.err != nil {
+		return nil, p.resp, p.err
+	}
+
+	if max > 0 && len(all) > max {
+		all = all[:max]
+	}
+
+	return all, p.resp, nil
+}
+
+// Chan fetches the remaining pages in a goroutine, sending their items
+// to the returned channel as pages arrive. The error channel receives the error
+// that stopped the iteration, if any. Both channels are closed when the
+// iteration ends, cancel the context of the pages to stop it early.
+func (p *Pages[T]) Chan() (<-chan T, <-chan error) {
+	items := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(items)
+
+		for p.Next() {
+			for _, item := range p.Items() {
+				select {
+				case items <- item:
+				case <-p.ctx.Done():
+					errs <- p.ctx.Err()
+					return
+				}
+			}
+		}
+
+		if p.err != nil {
+			errs <- p.err
+		}
+	}()
+
+	return items, errs
+}
+
+// pager is implemented by DataResponse and responses embedding it.
+type pager[T any] interface {
+	page() ([]T, string)
+}
+
+// dataPages iterates over the pages of get, starting at the cursor
+// of opts returned by after.
+func dataPages[T any, R pager[T], O any](ctx context.Context, opts *O, after func(opts *O) *string, get func(context.Context, *O) (R, *Response, error)) *Pages[T] {
+	var pageOpts O
+	if opts != nil {
+		pageOpts = *opts
+	}
+	cursor := after(&pageOpts)
+
+	return NewPages(ctx, *cursor, func(ctx context.Context, next string) ([]T, string, *Response, error) {
+		*cursor = next
+
+		data, resp, err := get(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", resp, err
+		}
+
+		items, next := data.page()
+		return items, next, resp, nil
+	})
+}
+
+func streamsAfter(opts *StreamsOptions) *string { return &opts.After }
+
+// StreamPages iterates over the pages of GetStreams, starting at opts.After.
+func (s *StreamsService) StreamPages(ctx context.Context, opts *StreamsOptions) *Pages[*Stream] {
+	return dataPages[*Stream](ctx, opts, streamsAfter, s.GetStreams)
+}
+
+// FollowedStreamPages iterates over the pages of GetFollowedStreams, starting at opts.After.
+func (s *StreamsService) FollowedStreamPages(ctx context.Context, opts *StreamsOptions) *Pages[*Stream] {
+	return dataPages[*Stream](ctx, opts, streamsAfter, s.GetFollowedStreams)
+}
+
+// SubscriptionPages iterates over the pages of GetSubscriptions, starting at opts.After.
+func (s *EventSubService) SubscriptionPages(ctx context.Context, opts *EventSubSubscriptionsOptions) *Pages[*EventSubSubscription] {
+	return dataPages[*EventSubSubscription](ctx, opts, func(opts *EventSubSubscriptionsOptions) *string { return &opts.After }, s.GetSubscriptions)
+}
+
+// ConduitShardPages iterates over the pages of GetConduitShards, starting at opts.After.
+func (s *EventSubService) ConduitShardPages(ctx context.Context, opts *ConduitShardsOptions) *Pages[*ConduitShard] {
+	return dataPages[*ConduitShard](ctx, opts, func(opts *ConduitShardsOptions) *string { return &opts.After }, s.GetConduitShards)
+}
+
+// GetAllStreams returns up to max streams of all pages of GetStreams,
+// max of 0 means no limit.
+func (s *StreamsService) GetAllStreams(ctx context.Context, opts *StreamsOptions, max int) ([]*Stream, *Response, error) {
+	return s.StreamPages(ctx, opts).Collect(max)
+}
+
+// GetAllFollowedStreams returns up to max streams of all pages of GetFollowedStreams,
+// max of 0 means no limit.
+func (s *StreamsService) GetAllFollowedStreams(ctx context.Context, opts *StreamsOptions, max int) ([]*Stream, *Response, error) {
+	return s.FollowedStreamPages(ctx, opts).Collect(max)
+}
+
+// GetAllSubscriptions returns up to max subscriptions of all pages of GetSubscriptions,
+// max of 0 means no limit.
+func (s *EventSubService) GetAllSubscriptions(ctx context.Context, opts *EventSubSubscriptionsOptions, max int) ([]*EventSubSubscription, *Response, error) {
+	return s.SubscriptionPages(ctx, opts).Collect(max)
+}
+
+// GetAllConduitShards returns up to max shards of all pages of GetConduitShards,
+// max of 0 means no limit.
+func (s *EventSubService) GetAllConduitShards(ctx context.Context, opts *ConduitShardsOptions, max int) ([]*ConduitShard, *Response, error) {
+	return s.ConduitShardPages(ctx, opts).Collect(max)
+}
+
+// HasNextPage reports whether the response has a next page.
+func (p Pagination) HasNextPage() bool {
+	return p.Cursor != ""
+}
+
+func (p Pagination) nextCursor() string {
+	return p.Cursor
+}
+
+// HasNextPage reports whether the response of a paginated endpoint has
+// a next page, which starts at Cursor.
+func (r *Response) HasNextPage() bool {
+	return r.Cursor != ""
+}
+
+// NextOptions returns a copy of opts, which may be nil, starting at cursor,
+// e.g. to fetch the next page:
+//
+//	opts := &twitch.StreamsOptions{GameId: "509658"}
+//	streams, resp, err := client.Streams.GetStreams(ctx, opts)
+//	for err == nil && resp.HasNextPage() {
+//		...
+//		streams, resp, err = client.Streams.GetStreams(ctx, twitch.NextOptions(opts, resp.Cursor))
+//	}
+//
+// The cursor is set to the field of the after key, the field of the before
+// key is cleared. It panics if the options have no after field.
+func NextOptions[O any](opts *O, cursor string) *O {
+	next := new(O)
+	if opts != nil {
+		*next = *opts
+	}
+
+	v := reflect.ValueOf(next).Elem()
+	after := -1
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" || f.Type.Kind() != reflect.String {
+			continue
+		}
+
+		switch fieldName(f) {
+		case "after":
+			after = i
+		case "before":
+			v.Field(i).SetString("")
+		}
+	}
+
+	if after < 0 {
+		panic(fmt.Sprintf("twitch: %s has no after cursor", v.Type().Name()))
+	}
+	v.Field(after).SetString(cursor)
+
+	return next
+}
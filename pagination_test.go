@@ -0,0 +1,251 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPages(t *testing.T) {
+	t.Run("must iterate over all pages", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodGet)
+
+			switch r.URL.Query().Get("after") {
+			case "":
+				fmt.Fprint(w, `{"data":[{"id":"1"},{"id":"2"}],"pagination":{"cursor":"c1"}}`)
+			case "c1":
+				fmt.Fprint(w, `{"data":[{"id":"3"}],"pagination":{}}`)
+			default:
+				t.Errorf("unexpected cursor: %s", r.URL.Query().Get("after"))
+			}
+		})
+
+		var ids []string
+		pages := c.Streams.StreamPages(context.Background(), &StreamsOptions{First: 2})
+		for pages.Next() {
+			for _, stream := range pages.Items() {
+				ids = append(ids, stream.Id)
+			}
+		}
+		assertNoError(t, pages.Err())
+
+		if want := []string{"1", "2", "3"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("\ngot: %v\nwant: %v", ids, want)
+		}
+
+		if pages.Next() {
+			t.Error("Next must return false after the last page")
+		}
+	})
+
+	t.Run("must iterate over pages of embedding responses", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/eventsub/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("after") == "" {
+				fmt.Fprint(w, `{"data":[{"id":"1"}],"total":2,"pagination":{"cursor":"c1"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"id":"2"}],"total":2,"pagination":{}}`)
+		})
+
+		data, _, err := c.EventSub.GetSubscriptions(context.Background(), nil)
+		assertNoError(t, err)
+		if data.Total != 2 || data.Cursor != "c1" {
+			t.Errorf("got total %d and cursor %q", data.Total, data.Cursor)
+		}
+
+		var ids []string
+		pages := c.EventSub.SubscriptionPages(context.Background(), nil)
+		for pages.Next() {
+			for _, sub := range pages.Items() {
+				ids = append(ids, sub.Id)
+			}
+		}
+		assertNoError(t, pages.Err())
+
+		if want := []string{"1", "2"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("\ngot: %v\nwant: %v", ids, want)
+		}
+	})
+
+	t.Run("must stop with error", func(t *testing.T) {
+		pages := NewPages(context.Background(), "", func(ctx context.Context, cursor string) ([]int, string, *Response, error) {
+			if cursor == "" {
+				return []int{1}, "c1", nil, nil
+			}
+			return nil, "", nil, fmt.Errorf("kek")
+		})
+
+		if !pages.Next() || pages.Next() || pages.Err() == nil {
+			t.Errorf("bad iteration, err: %v", pages.Err())
+		}
+	})
+
+	t.Run("must return validation error", func(t *testing.T) {
+		c, _ := NewClient(creds, nil)
+		pages := c.Streams.FollowedStreamPages(context.Background(), nil)
+
+		if pages.Next() {
+			t.Error("Next must return false")
+		}
+		assertErrorMessage(t, pages.Err(), userIdIsRequired)
+	})
+}
+
+func TestGetAllStreams(t *testing.T) {
+	newServer := func(t *testing.T) (*Client, *int, func()) {
+		c, mux, _, teardown := setup()
+
+		requests := 0
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			n := requests * 2
+			if requests == 3 {
+				fmt.Fprintf(w, `{"data":[{"id":"%d"}]}`, n-1)
+				return
+			}
+			fmt.Fprintf(w, `{"data":[{"id":"%d"},{"id":"%d"}],"pagination":{"cursor":"c%d"}}`, n-1, n, requests)
+		})
+
+		return c, &requests, teardown
+	}
+
+	t.Run("must drain all pages", func(t *testing.T) {
+		c, requests, teardown := newServer(t)
+		defer teardown()
+
+		streams, _, err := c.Streams.GetAllStreams(context.Background(), nil, 0)
+		assertNoError(t, err)
+
+		if len(streams) != 5 || *requests != 3 {
+			t.Errorf("got %d streams with %d requests", len(streams), *requests)
+		}
+	})
+
+	t.Run("must stop at max items", func(t *testing.T) {
+		c, requests, teardown := newServer(t)
+		defer teardown()
+
+		streams, _, err := c.Streams.GetAllStreams(context.Background(), nil, 3)
+		assertNoError(t, err)
+
+		if len(streams) != 3 || streams[2].Id != "3" || *requests != 2 {
+			t.Errorf("got %d streams with %d requests", len(streams), *requests)
+		}
+	})
+}
+
+func TestPagesChan(t *testing.T) {
+	newPages := func(ctx context.Context) *Pages[int] {
+		return NewPages(ctx, "", func(ctx context.Context, cursor string) ([]int, string, *Response, error) {
+			switch cursor {
+			case "":
+				return []int{1, 2}, "c1", nil, nil
+			case "c1":
+				return []int{3}, "c2", nil, nil
+			}
+			return nil, "", nil, fmt.Errorf("kek")
+		})
+	}
+
+	t.Run("must send items of all pages and the error", func(t *testing.T) {
+		items, errs := newPages(context.Background()).Chan()
+
+		var got []int
+		for item := range items {
+			got = append(got, item)
+		}
+
+		if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("\ngot: %v\nwant: %v", got, want)
+		}
+
+		if err := <-errs; err == nil || err.Error() != "kek" {
+			t.Errorf("expected error, got: %v", err)
+		}
+	})
+
+	t.Run("must stop when context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		items, errs := newPages(ctx).Chan()
+
+		<-items
+		cancel()
+
+		if err := <-errs; err != context.Canceled {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+	})
+}
+
+func TestNextOptions(t *testing.T) {
+	t.Run("must fetch pages with the cursor of the response", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("game_id"); got != "1" {
+				t.Errorf("options must be kept, got game_id %q", got)
+			}
+
+			switch r.URL.Query().Get("after") {
+			case "":
+				fmt.Fprint(w, `{"data":[{"id":"1"}],"pagination":{"cursor":"c1"}}`)
+			case "c1":
+				fmt.Fprint(w, `{"data":[{"id":"2"}],"pagination":{}}`)
+			}
+		})
+
+		opts := &StreamsOptions{GameId: "1", Before: "c0"}
+		var ids []string
+
+		streams, resp, err := c.Streams.GetStreams(context.Background(), &StreamsOptions{GameId: "1"})
+		for err == nil {
+			for _, s := range streams.Data {
+				ids = append(ids, s.Id)
+			}
+
+			if streams.HasNextPage() != resp.HasNextPage() {
+				t.Fatalf("HasNextPage of the response and the body differ at cursor %q", resp.Cursor)
+			}
+			if !resp.HasNextPage() {
+				break
+			}
+
+			streams, resp, err = c.Streams.GetStreams(context.Background(), NextOptions(opts, resp.Cursor))
+		}
+		assertNoError(t, err)
+
+		if !reflect.DeepEqual(ids, []string{"1", "2"}) {
+			t.Errorf("bad ids %v", ids)
+		}
+
+		if opts.After != "" || opts.Before != "c0" {
+			t.Errorf("options must not be changed: %+v", opts)
+		}
+	})
+
+	t.Run("must accept nil options", func(t *testing.T) {
+		if got := NextOptions[EventSubSubscriptionsOptions](nil, "c1"); got.After != "c1" {
+			t.Errorf("bad options %+v", got)
+		}
+	})
+
+	t.Run("must panic without after field", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic")
+			}
+		}()
+
+		NextOptions(&UsersOptions{}, "c1")
+	})
+}
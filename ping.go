@@ -0,0 +1,33 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// pingPath is the cheapest call of Helix accepting any token.
+const pingPath = "streams?first=1"
+
+// Ping sends a minimal authenticated request to Helix, e.g. for readiness
+// probes of a bot. It returns the latency of the request and the response,
+// which has the rate limit snapshot of the token in Rate.
+//
+// The request is never answered from Cache nor shared with other requests,
+// it fails like any other request when the token or Helix are unusable.
+func (c *Client) Ping(ctx context.Context) (time.Duration, *Response, error) {
+	client := c.With(func(c *Client) {
+		c.Cache = nil
+		c.CoalesceRequests = false
+	})
+
+	req, err := client.NewRequest(http.MethodGet, pingPath, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	start := time.Now()
+	resp, err := client.Do(ctx, req, nil)
+
+	return time.Since(start), resp, err
+}
@@ -0,0 +1,54 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	t.Run("must return latency and rate", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		requests := 0
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assertQuery(t, r, params{"first": "1"})
+			w.Header().Set(headerRateLimit, "800")
+			w.Header().Set(headerRateRemaining, "799")
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		c.Cache = NewResponseCache(map[string]time.Duration{"streams": time.Minute})
+
+		for i := 0; i < 2; i++ {
+			latency, resp, err := c.Ping(context.Background())
+			assertNoError(t, err)
+
+			if latency <= 0 || resp.Rate.Limit != 800 || resp.Rate.Remaining != 799 {
+				t.Errorf("bad latency %v or rate %+v", latency, resp.Rate)
+			}
+		}
+
+		if requests != 2 {
+			t.Errorf("ping must not be cached, got %d requests", requests)
+		}
+	})
+
+	t.Run("must fail with an invalid token", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		if _, _, err := c.Ping(context.Background()); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("expected ErrUnauthorized, got: %v", err)
+		}
+	})
+}
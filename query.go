@@ -0,0 +1,42 @@
+package twitch
+
+import (
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+// encodeQuery encodes opts by their url tags.
+//
+// Lists are sent as repeated keys, id=1&id=2, as Helix expects them.
+// Fields tagged with the comma or space option are joined into a single
+// value where Twitch requires it, e.g. url:"scope,space" for the scopes
+// of the authorization. Empty items of lists are dropped, Helix rejects
+// requests with empty ids or logins.
+func encodeQuery(opts interface{}) (url.Values, error) {
+	qs, err := query.Values(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range qs {
+		if len(values) < 2 {
+			continue
+		}
+
+		kept := values[:0]
+		for _, v := range values {
+			if v != "" {
+				kept = append(kept, v)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(qs, key)
+		} else {
+			qs[key] = kept
+		}
+	}
+
+	return qs, nil
+}
@@ -0,0 +1,52 @@
+package twitch
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	type options struct {
+		Ids    []string `url:"id,omitempty"`
+		Fields []string `url:"fields,comma,omitempty"`
+		Scopes []string `url:"scope,space,omitempty"`
+		First  int      `url:"first,omitempty"`
+	}
+
+	t.Run("must encode lists as repeated or joined values", func(t *testing.T) {
+		qs, err := encodeQuery(&options{
+			Ids:    []string{"1", "2"},
+			Fields: []string{"a", "b"},
+			Scopes: []string{"chat:read", "chat:edit"},
+			First:  20,
+		})
+		assertNoError(t, err)
+
+		want := url.Values{
+			"id":     {"1", "2"},
+			"fields": {"a,b"},
+			"scope":  {"chat:read chat:edit"},
+			"first":  {"20"},
+		}
+		if !reflect.DeepEqual(qs, want) {
+			t.Errorf("\ngot: %v\nwant: %v", qs, want)
+		}
+	})
+
+	t.Run("must drop empty items of lists", func(t *testing.T) {
+		qs, err := encodeQuery(&options{Ids: []string{"1", "", "2"}})
+		assertNoError(t, err)
+
+		if want := (url.Values{"id": {"1", "2"}}); !reflect.DeepEqual(qs, want) {
+			t.Errorf("\ngot: %v\nwant: %v", qs, want)
+		}
+
+		qs, err = encodeQuery(&options{Ids: []string{"", ""}})
+		assertNoError(t, err)
+
+		if len(qs) != 0 {
+			t.Errorf("expected empty query, got: %v", qs)
+		}
+	})
+}
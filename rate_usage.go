@@ -0,0 +1,150 @@
+package twitch
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// HelixBucket is the name of the bucket of Response.Rate,
+	// which most endpoints draw from.
+	HelixBucket = "helix"
+
+	headerRatePrefix = "Ratelimit-"
+)
+
+// separateLimits are the names of the limits of endpoints limited separately
+// by Twitch, without headers of their buckets.
+var separateLimits = map[string]string{
+	"POST moderation/enforcements/status": "automod",
+	"POST whispers":                       "whispers",
+	"POST chat/announcements":             "announcements",
+	"POST chat/shoutouts":                 "shoutouts",
+}
+
+// parseBuckets returns the rates of the Ratelimit-<Name>-Limit, -Remaining and
+// -Reset headers by the lowercase name, or nil if there are none.
+func parseBuckets(header http.Header) map[string]Rate {
+	var buckets map[string]Rate
+	for key := range header {
+		if len(key) <= len(headerRatePrefix+"-Limit") || !strings.HasPrefix(key, headerRatePrefix) || !strings.HasSuffix(key, "-Limit") {
+			continue
+		}
+
+		name := key[len(headerRatePrefix) : len(key)-len("-Limit")]
+		prefix := headerRatePrefix + name
+		if buckets == nil {
+			buckets = make(map[string]Rate)
+		}
+		buckets[strings.ToLower(name)] = parseRate(header, prefix+"-Limit", prefix+"-Remaining", prefix+"-Reset")
+	}
+
+	return buckets
+}
+
+// EndpointUsage is the use of the rate limits by an endpoint.
+type EndpointUsage struct {
+	// Requests is the number of requests sent, including retries.
+	// Each takes a point of the buckets of the endpoint.
+	Requests int
+	// RateLimited is the number of requests answered with 429.
+	RateLimited int
+	// Buckets are the names of the buckets the endpoint draws from,
+	// e.g. helix and helixclipscreation.
+	Buckets []string
+}
+
+// RateUsage tracks the use of the rate limits by endpoint and the state of
+// every bucket, e.g. to find out which feature of a bot uses up the points:
+//
+//	client.RateUsage = twitch.NewRateUsage()
+//	...
+//	for endpoint, usage := range client.RateUsage.Endpoints() {
+//		log.Printf("%s: %d requests, %d rate limited", endpoint, usage.Requests, usage.RateLimited)
+//	}
+//
+// Endpoints are keyed by the method and path, e.g. "POST chat/announcements".
+// Twitch limits some endpoints, e.g. AutoMod checks, whispers and
+// announcements, by separate limits it doesn't report in headers: their
+// Buckets list the name of the limit, e.g. whispers, but Buckets of the
+// RateUsage has no rate of it. A RateUsage is safe for concurrent use.
+type RateUsage struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointUsage
+	buckets   map[string]Rate
+}
+
+func NewRateUsage() *RateUsage {
+	return &RateUsage{
+		endpoints: make(map[string]*EndpointUsage),
+		buckets:   make(map[string]Rate),
+	}
+}
+
+// Endpoints returns a copy of the usage by endpoint.
+func (u *RateUsage) Endpoints() map[string]EndpointUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	endpoints := make(map[string]EndpointUsage, len(u.endpoints))
+	for endpoint, usage := range u.endpoints {
+		e := *usage
+		e.Buckets = append([]string(nil), usage.Buckets...)
+		endpoints[endpoint] = e
+	}
+
+	return endpoints
+}
+
+// Buckets returns the rates of the buckets by name as of their last response,
+// HelixBucket is the bucket of Response.Rate.
+func (u *RateUsage) Buckets() map[string]Rate {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	buckets := make(map[string]Rate, len(u.buckets))
+	for name, rate := range u.buckets {
+		buckets[name] = rate
+	}
+
+	return buckets
+}
+
+// record records the response of a request to endpoint.
+func (u *RateUsage) record(endpoint string, resp *Response) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage := u.endpoints[endpoint]
+	if usage == nil {
+		usage = new(EndpointUsage)
+		u.endpoints[endpoint] = usage
+	}
+
+	usage.Requests++
+	if resp.StatusCode == http.StatusTooManyRequests {
+		usage.RateLimited++
+	}
+
+	if resp.Rate.Limit > 0 {
+		u.buckets[HelixBucket] = resp.Rate
+		usage.addBucket(HelixBucket)
+	}
+	for name, rate := range resp.Buckets {
+		u.buckets[name] = rate
+		usage.addBucket(name)
+	}
+	if name, ok := separateLimits[endpoint]; ok {
+		usage.addBucket(name)
+	}
+}
+
+func (e *EndpointUsage) addBucket(name string) {
+	for _, b := range e.Buckets {
+		if b == name {
+			return
+		}
+	}
+	e.Buckets = append(e.Buckets, name)
+}
@@ -0,0 +1,55 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRateUsage(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/clips", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "800")
+		w.Header().Set(headerRateRemaining, "798")
+		w.Header().Set("Ratelimit-Helixclipscreation-Limit", "600")
+		w.Header().Set("Ratelimit-Helixclipscreation-Remaining", "599")
+		w.Header().Set("Ratelimit-Helixclipscreation-Reset", "1700000000")
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	mux.HandleFunc("/whispers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "800")
+		w.Header().Set(headerRateRemaining, "797")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	c.RateUsage = NewRateUsage()
+
+	req, _ := c.NewRequest(http.MethodPost, "clips", nil)
+	resp, err := c.Do(context.Background(), req, nil)
+	assertNoError(t, err)
+
+	if got := resp.Buckets["helixclipscreation"]; got.Limit != 600 || got.Remaining != 599 || got.Reset.Unix() != 1700000000 {
+		t.Errorf("bad bucket of the response %+v", resp.Buckets)
+	}
+
+	req, _ = c.NewRequest(http.MethodPost, "whispers", nil)
+	c.Do(context.Background(), req, nil)
+
+	wantEndpoints := map[string]EndpointUsage{
+		"POST clips":    {Requests: 1, Buckets: []string{HelixBucket, "helixclipscreation"}},
+		"POST whispers": {Requests: 1, RateLimited: 1, Buckets: []string{HelixBucket, "whispers"}},
+	}
+	if got := c.RateUsage.Endpoints(); !reflect.DeepEqual(got, wantEndpoints) {
+		t.Errorf("endpoints are not equal\ngot: %+v\nwant: %+v", got, wantEndpoints)
+	}
+
+	buckets := c.RateUsage.Buckets()
+	if len(buckets) != 2 || buckets[HelixBucket].Remaining != 797 || buckets["helixclipscreation"].Remaining != 599 {
+		t.Errorf("bad buckets %+v", buckets)
+	}
+}
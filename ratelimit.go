@@ -0,0 +1,128 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces requests to stay within the rate limit bucket of Twitch.
+// It keeps track of the bucket from the Ratelimit headers of every response
+// and delays requests while the bucket is empty, until it resets.
+//
+// A RateLimiter is safe for concurrent use and may be shared by Clients
+// drawing from the same bucket, e.g. workers using the same app token,
+// so they coordinate instead of exhausting the bucket independently:
+//
+//	limiter := twitch.NewRateLimiter()
+//	for _, c := range workers {
+//		c.RateLimiter = limiter
+//	}
+type RateLimiter struct {
+	// Reserve is the number of points kept for requests with a context
+	// returned by WithRateLimitReserve, other requests are delayed
+	// once Reserve points are left, e.g. to keep background polling
+	// from starving the commands of a bot.
+	Reserve int
+
+	mu    sync.Mutex
+	rate  Rate
+	known bool
+}
+
+func NewRateLimiter() *RateLimiter {
+	return new(RateLimiter)
+}
+
+type rateLimitReserveKey struct{}
+
+// WithRateLimitReserve lets requests with ctx use the points
+// the rate limiter keeps in reserve.
+func WithRateLimitReserve(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rateLimitReserveKey{}, true)
+}
+
+// Wait blocks until a request can be sent without exceeding the bucket
+// and takes a point of the bucket for it.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	return l.wait(ctx, nil)
+}
+
+// wait is Wait calling onDelay before the request is delayed.
+func (l *RateLimiter) wait(ctx context.Context, onDelay func(wait time.Duration)) error {
+	reserve := l.Reserve
+	if ctx.Value(rateLimitReserveKey{}) != nil {
+		reserve = 0
+	}
+
+	for {
+		delay := l.take(reserve)
+		if delay == 0 {
+			return nil
+		}
+
+		if onDelay != nil {
+			onDelay(delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take takes a point of the bucket, or returns the time left until
+// the bucket resets if no more than reserve points are left.
+func (l *RateLimiter) take(reserve int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.known {
+		return 0
+	}
+
+	if !time.Now().Before(l.rate.Reset) {
+		// The bucket is refilled, the next response tells the actual state.
+		l.known = false
+		return 0
+	}
+
+	if l.rate.Remaining <= reserve {
+		return l.rate.untilReset()
+	}
+
+	l.rate.Remaining--
+	return 0
+}
+
+// Update records the state of the bucket reported by a response.
+// Rates without a limit, e.g. of responses without Ratelimit headers, are ignored.
+func (l *RateLimiter) Update(rate Rate) {
+	if rate.Limit == 0 || rate.Reset.IsZero() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Responses of concurrent requests may arrive out of order,
+	// the lowest remaining of the same bucket is the most recent one.
+	if l.known && rate.Reset.Equal(l.rate.Reset) && rate.Remaining > l.rate.Remaining {
+		return
+	}
+
+	l.rate = rate
+	l.known = true
+}
+
+// Rate returns the last known state of the bucket.
+func (l *RateLimiter) Rate() Rate {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rate
+}
@@ -0,0 +1,143 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("must delay requests until the bucket resets", func(t *testing.T) {
+		l := NewRateLimiter()
+		l.Update(Rate{Limit: 800, Remaining: 1, Reset: time.Now().Add(50 * time.Millisecond)})
+
+		start := time.Now()
+		assertNoError(t, l.Wait(context.Background()))
+		if time.Since(start) > 10*time.Millisecond {
+			t.Error("request must not be delayed while points are left")
+		}
+
+		assertNoError(t, l.Wait(context.Background()))
+		if time.Since(start) < 40*time.Millisecond {
+			t.Error("request must be delayed until the bucket resets")
+		}
+	})
+
+	t.Run("must keep reserve for requests allowed to use it", func(t *testing.T) {
+		l := &RateLimiter{Reserve: 5}
+		l.Update(Rate{Limit: 800, Remaining: 5, Reset: time.Now().Add(time.Minute)})
+
+		assertNoError(t, l.Wait(WithRateLimitReserve(context.Background())))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := l.Wait(ctx); err != context.DeadlineExceeded {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+
+		if got := l.Rate().Remaining; got != 4 {
+			t.Errorf("bad remaining: %d", got)
+		}
+	})
+
+	t.Run("must ignore stale and empty rates", func(t *testing.T) {
+		l := NewRateLimiter()
+		reset := time.Now().Add(time.Minute)
+
+		l.Update(Rate{Limit: 800, Remaining: 10, Reset: reset})
+		l.Update(Rate{Limit: 800, Remaining: 12, Reset: reset})
+		l.Update(Rate{})
+
+		if got := l.Rate().Remaining; got != 10 {
+			t.Errorf("bad remaining: %d", got)
+		}
+	})
+
+	t.Run("client must wait for the bucket", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerRateLimit, "800")
+			w.Header().Set(headerRateRemaining, "0")
+			w.Header().Set(headerRateReset, reset)
+			w.Write([]byte(`{"data":[]}`))
+		})
+
+		_, _, err := c.Streams.GetStreams(context.Background(), nil)
+		assertNoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, _, err := c.Streams.GetStreams(ctx, nil); err != context.DeadlineExceeded {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+	})
+
+	t.Run("clients sharing the limiter must wait for the same bucket", func(t *testing.T) {
+		c1, mux1, _, teardown1 := setup()
+		defer teardown1()
+		c2, mux2, _, teardown2 := setup()
+		defer teardown2()
+
+		c2.RateLimiter = c1.RateLimiter
+
+		reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+		mux1.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerRateLimit, "800")
+			w.Header().Set(headerRateRemaining, "0")
+			w.Header().Set(headerRateReset, reset)
+			w.Write([]byte(`{"data":[]}`))
+		})
+		mux2.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request must not be sent while the shared bucket is empty")
+		})
+
+		_, _, err := c1.Streams.GetStreams(context.Background(), nil)
+		assertNoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, _, err := c2.Streams.GetStreams(ctx, nil); err != context.DeadlineExceeded {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+	})
+}
+
+func TestOnRateLimited(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "800")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRateReset, reset)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	var endpoints []string
+	c.OnRateLimited = func(endpoint string, wait time.Duration) {
+		if wait <= 0 || wait > time.Minute {
+			t.Errorf("bad wait: %v", wait)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	_, _, err := c.Streams.GetStreams(context.Background(), nil)
+	assertErrorPresence(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c.Streams.GetStreams(ctx, nil)
+
+	if len(endpoints) != 2 || endpoints[0] != "streams" || endpoints[1] != "streams" {
+		t.Errorf("bad rate limited endpoints: %v", endpoints)
+	}
+}
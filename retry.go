@@ -0,0 +1,100 @@
+package twitch
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBackoff     = time.Second
+)
+
+// RetryPolicy decides whether a request is sent again. ShouldRetry is called
+// after every attempt with either the response or the error of the request,
+// attempt starts at 1. It returns the delay before the next attempt.
+//
+// Requests with a body that can't be rewound are never retried.
+type RetryPolicy interface {
+	ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool)
+}
+
+// The RetryPolicyFunc type is an adapter to allow the use of ordinary
+// functions as retry policies.
+type RetryPolicyFunc func(resp *http.Response, err error, attempt int) (time.Duration, bool)
+
+func (f RetryPolicyFunc) ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	return f(resp, err, attempt)
+}
+
+// DefaultRetryPolicy retries requests answered with 429 or 5xx. A request
+// rejected with 429 is retried when the rate limit bucket resets,
+// others after Backoff doubled with every attempt.
+//
+// Requests failed without a response are not retried, as they may have reached Twitch.
+type DefaultRetryPolicy struct {
+	// MaxAttempts defaults to 3.
+	MaxAttempts int
+	// Backoff defaults to 1 second.
+	Backoff time.Duration
+	// Methods are the methods of requests to retry,
+	// only GET requests are retried if it is empty.
+	Methods []string
+}
+
+func (p *DefaultRetryPolicy) ShouldRetry(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+
+	if err != nil || resp == nil || attempt >= maxAttempts || !p.retriesMethod(resp.Request) {
+		return 0, false
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return NewResponse(resp).Rate.untilReset(), true
+	}
+
+	if resp.StatusCode < http.StatusInternalServerError {
+		return 0, false
+	}
+
+	backoff := p.Backoff
+	if backoff == 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	return backoff << (attempt - 1), true
+}
+
+func (p *DefaultRetryPolicy) retriesMethod(req *http.Request) bool {
+	if req == nil {
+		return false
+	}
+
+	if len(p.Methods) == 0 {
+		return req.Method == http.MethodGet
+	}
+
+	for _, method := range p.Methods {
+		if method == req.Method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// untilReset returns the time left until the rate limit bucket resets.
+func (r Rate) untilReset() time.Duration {
+	if r.Reset.IsZero() {
+		return 0
+	}
+
+	if d := time.Until(r.Reset); d > 0 {
+		return d
+	}
+
+	return 0
+}
@@ -0,0 +1,103 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("must retry with custom policy until it gives up", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		requests := 0
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		var attempts []int
+		c.RetryPolicy = RetryPolicyFunc(func(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+			attempts = append(attempts, attempt)
+			return 0, attempt < 3
+		})
+
+		_, _, err := c.Streams.GetStreams(context.Background(), nil)
+		assertErrorPresence(t, err)
+
+		if requests != 3 || len(attempts) != 3 {
+			t.Errorf("bad retries: %d requests, attempts %v", requests, attempts)
+		}
+	})
+
+	t.Run("must send body again", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var bodies []string
+		mux.HandleFunc(authURLPath+"/kek", func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			bodies = append(bodies, r.Form.Get("lol"))
+			if len(bodies) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		})
+
+		c.RetryPolicy = &DefaultRetryPolicy{Backoff: time.Millisecond, Methods: []string{http.MethodPost}}
+
+		req, err := c.newAuthRequest(http.MethodPost, "kek", url.Values{"lol": {"kek"}})
+		assertNoError(t, err)
+
+		_, err = c.Do(context.Background(), req, nil)
+		assertNoError(t, err)
+
+		if len(bodies) != 2 || bodies[1] != "kek" {
+			t.Errorf("bad bodies: %v", bodies)
+		}
+	})
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	p := &DefaultRetryPolicy{Backoff: time.Second}
+	get, _ := http.NewRequest(http.MethodGet, "/", nil)
+	post, _ := http.NewRequest(http.MethodPost, "/", nil)
+
+	reset := time.Now().Add(time.Minute)
+	header := http.Header{headerRateReset: {strconv.FormatInt(reset.Unix(), 10)}}
+
+	tests := []struct {
+		name    string
+		resp    *http.Response
+		err     error
+		attempt int
+		retry   bool
+		delay   time.Duration
+	}{
+		{"5xx is retried with backoff", &http.Response{StatusCode: 500, Request: get}, nil, 2, true, 2 * time.Second},
+		{"attempts are limited", &http.Response{StatusCode: 500, Request: get}, nil, 3, false, 0},
+		{"post is not retried", &http.Response{StatusCode: 500, Request: post}, nil, 1, false, 0},
+		{"4xx is not retried", &http.Response{StatusCode: 400, Request: get}, nil, 1, false, 0},
+		{"error is not retried", nil, fmt.Errorf("kek"), 1, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := p.ShouldRetry(tt.resp, tt.err, tt.attempt)
+			if retry != tt.retry || delay != tt.delay {
+				t.Errorf("got: %v %v, want: %v %v", delay, retry, tt.delay, tt.retry)
+			}
+		})
+	}
+
+	t.Run("429 is retried after reset", func(t *testing.T) {
+		delay, retry := p.ShouldRetry(&http.Response{StatusCode: 429, Request: get, Header: header}, nil, 1)
+		if !retry || delay <= 0 || delay > time.Minute {
+			t.Errorf("bad delay: %v %v", delay, retry)
+		}
+	})
+}
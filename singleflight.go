@@ -0,0 +1,127 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type flight struct {
+	done chan struct{}
+	resp *http.Response
+	body []byte
+	err  error
+
+	// waiters are the callers waiting for the response, the request
+	// is canceled once all of them gave up.
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup sends concurrent identical requests once,
+// every caller gets a copy of the response.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do returns the response of the request sent by send for key, or ctx.Err()
+// when ctx is done first. The request is sent once for concurrent callers
+// with the values of the context of the first one. It keeps running while
+// any caller waits for it, until closed is done, e.g. the context of the
+// client. Bodies larger than limit fail with *ErrorResponseTooLarge.
+func (g *flightGroup) do(ctx, closed context.Context, key string, limit int64, send func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+
+	f, ok := g.flights[key]
+	if !ok {
+		sendCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		stop := context.AfterFunc(closed, cancel)
+
+		f = &flight{done: make(chan struct{}), cancel: func() { stop(); cancel() }}
+		g.flights[key] = f
+		go g.send(sendCtx, key, f, limit, send)
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		g.leave(key, f)
+		return nil, ctx.Err()
+	case <-f.done:
+		return f.copy()
+	}
+}
+
+// leave cancels the request of f when its last caller gave up, callers
+// coming later send it again.
+func (g *flightGroup) leave(key string, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 {
+		return
+	}
+
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	f.cancel()
+}
+
+func (g *flightGroup) send(ctx context.Context, key string, f *flight, limit int64, send func(ctx context.Context) (*http.Response, error)) {
+	f.resp, f.err = send(ctx)
+	if f.err == nil {
+		f.body, f.err = readBody(f.resp, limit)
+		f.resp.Body.Close()
+	}
+	f.cancel()
+
+	g.mu.Lock()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	g.mu.Unlock()
+	close(f.done)
+}
+
+func (f *flight) copy() (*http.Response, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	resp := *f.resp
+	resp.Header = f.resp.Header.Clone()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(f.body))
+
+	return &resp, nil
+}
+
+// flightKey identifies identical requests, requests sent with another
+// token or other headers, e.g. of WithHeader, are not identical.
+func flightKey(ctx context.Context, req *http.Request) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %v", req.Method, req.URL, ctx.Value(tokenKindKey{}))
+
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\n%s: %q", key, req.Header[key])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,222 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceRequests(t *testing.T) {
+	t.Run("must send concurrent identical requests once", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var requests int32
+		release := make(chan struct{})
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			<-release
+			fmt.Fprint(w, `{"data":[{"id":"1","login":"twitchdev"}]}`)
+		})
+
+		c.CoalesceRequests = true
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				users, _, err := c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+				if err != nil || len(users) != 1 || users[0].Login != "twitchdev" {
+					t.Errorf("bad users %v: %v", users, err)
+				}
+			}()
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if got := atomic.LoadInt32(&requests); got != 1 {
+			t.Errorf("expected 1 request, got: %d", got)
+		}
+	})
+
+	t.Run("must not fail the shared request, when its first caller gives up", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var requests int32
+		release := make(chan struct{})
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			<-release
+			fmt.Fprint(w, `{"data":[{"id":"1","login":"twitchdev"}]}`)
+		})
+
+		c.CoalesceRequests = true
+
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error, 1)
+		go func() {
+			_, _, err := c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"1"}})
+			first <- err
+		}()
+
+		// The second caller joins the request of the first one.
+		time.Sleep(50 * time.Millisecond)
+		second := make(chan error, 1)
+		go func() {
+			users, _, err := c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+			if err == nil && (len(users) != 1 || users[0].Login != "twitchdev") {
+				err = fmt.Errorf("bad users %v", users)
+			}
+			second <- err
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		if err := <-first; err != context.Canceled {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+
+		close(release)
+		if err := <-second; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if got := atomic.LoadInt32(&requests); got != 1 {
+			t.Errorf("expected 1 request, got: %d", got)
+		}
+	})
+
+	t.Run("must return, when the context of a waiting caller is done", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		release := make(chan struct{})
+		defer close(release)
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		})
+
+		c.CoalesceRequests = true
+
+		go c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+		time.Sleep(50 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if _, _, err := c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"1"}}); err != context.DeadlineExceeded {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+	})
+
+	t.Run("must send the request again, when every caller gave up", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var requests int32
+		canceled := make(chan struct{}, 2)
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			<-r.Context().Done()
+			canceled <- struct{}{}
+		})
+
+		c.CoalesceRequests = true
+
+		for i := 1; i <= 2; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			if _, _, err := c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"1"}}); err != context.DeadlineExceeded {
+				t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+			}
+			cancel()
+
+			select {
+			case <-canceled:
+			case <-time.After(time.Second):
+				t.Fatal("the shared request must be canceled")
+			}
+
+			if got := atomic.LoadInt32(&requests); got != int32(i) {
+				t.Errorf("expected %d requests, got: %d", i, got)
+			}
+		}
+	})
+
+	t.Run("must cancel the shared request, when the client is closed", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		started := make(chan struct{})
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+		})
+
+		c.CoalesceRequests = true
+
+		errs := make(chan error, 1)
+		go func() {
+			_, _, err := c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+			errs <- err
+		}()
+
+		<-started
+		c.Close()
+
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context.Canceled, got: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("the caller must not wait after the client is closed")
+		}
+	})
+
+	t.Run("must not share requests with other headers", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var requests int32
+		release := make(chan struct{})
+		mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			<-release
+			fmt.Fprintf(w, `{"data":[{"id":"1","login":"%s"}]}`, r.Header.Get("X-Trace"))
+		})
+
+		c.CoalesceRequests = true
+
+		var wg sync.WaitGroup
+		for _, trace := range []string{"a", "b"} {
+			wg.Add(1)
+			go func(trace string) {
+				defer wg.Done()
+
+				ctx := WithHeader(context.Background(), "X-Trace", trace)
+				users, _, err := c.Users.GetUsers(ctx, &UsersOptions{Ids: []string{"1"}})
+				if err != nil || len(users) != 1 || users[0].Login != trace {
+					t.Errorf("bad users %v: %v", users, err)
+				}
+			}(trace)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if got := atomic.LoadInt32(&requests); got != 2 {
+			t.Errorf("expected 2 requests, got: %d", got)
+		}
+	})
+}
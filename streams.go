@@ -1,50 +1,81 @@
-package bot
+package twitch
 
 import (
 	"context"
 	"net/http"
+	"strings"
 )
 
 const (
 	getStreamsPath         = "streams"
 	getFollowedStreamsPath = "streams/followed"
 	getStreamKeyPath       = "streams/key"
-	getStreamMarkersPath   = "stream/markers"
 )
 
 type StreamsService service
 
+// StreamsAPI is implemented by StreamsService, so calls can be mocked in tests.
+type StreamsAPI interface {
+	streamsGeneratedAPI
+
+	GetStreams(ctx context.Context, opts *StreamsOptions) (*StreamsResponse, *Response, error)
+	GetFollowedStreams(ctx context.Context, opts *StreamsOptions) (*StreamsResponse, *Response, error)
+	GetStreamKey(ctx context.Context, opts *BroadcasterID) (StreamKey, *Response, error)
+	StreamPages(ctx context.Context, opts *StreamsOptions) *Pages[*Stream]
+	FollowedStreamPages(ctx context.Context, opts *StreamsOptions) *Pages[*Stream]
+	GetAllStreams(ctx context.Context, opts *StreamsOptions, max int) ([]*Stream, *Response, error)
+	GetAllFollowedStreams(ctx context.Context, opts *StreamsOptions, max int) ([]*Stream, *Response, error)
+	GetStreamsByUsers(ctx context.Context, opts *StreamsOptions, concurrency int) ([]*Stream, *Response, error)
+}
+
+var _ StreamsAPI = (*StreamsService)(nil)
+
+// StreamType is the type of a stream, GetStreams filters by it.
+type StreamType string
+
+const (
+	StreamTypeAll  StreamType = "all"
+	StreamTypeLive StreamType = "live"
+)
+
+func (t StreamType) Valid() bool {
+	return t == StreamTypeAll || t == StreamTypeLive
+}
+
 type StreamsOptions struct {
-	After     string `url:"after,omitempty"`
-	Before    string `url:"before,omitempty"`
-	First     int    `url:"first,omitempty"`
-	GameId    string `url:"game_id,omitempty"`
-	Language  string `url:"language,omitempty"`
-	UserId    string `url:"user_id,omitempty"`
-	UserLogin string `url:"user_login,omitempty"`
+	After    string `url:"after,omitempty" exclusive:"cursor"`
+	Before   string `url:"before,omitempty" exclusive:"cursor"`
+	First    int    `url:"first,omitempty" max:"100"`
+	GameId   string `url:"game_id,omitempty"`
+	Language string `url:"language,omitempty"`
+	// Type defaults to StreamTypeAll.
+	Type      StreamType `url:"type,omitempty"`
+	UserId    string     `url:"user_id,omitempty"`
+	UserLogin string     `url:"user_login,omitempty"`
+	// UserIds and UserLogins filter GetStreams by up to 100 users,
+	// GetStreamsByUsers takes any number of them.
+	UserIds    []string `url:"user_id,omitempty" group:"users" max:"100"`
+	UserLogins []string `url:"user_login,omitempty" group:"users"`
 }
 
 type Stream struct {
-	Id          string    `json:"id,omitempty"`
-	UserId      string    `json:"user_id,omitempty"`
-	UserLogin   string    `json:"user_login,omitempty"`
-	Username    string    `json:"user_name,omitempty"`
-	GameId      string    `json:"game_id,omitempty"`
-	GameName    string    `json:"game_name,omitempty"`
-	Type        string    `json:"type,omitempty"`
-	Title       string    `json:"title,omitempty"`
-	ViewerCount int       `json:"viewer_count,omitempty"`
-	StartedAt   Timestamp `json:"started_at,omitempty"`
-	Language    string    `json:"language,omitempty"`
-	ThumnailURL string    `json:"thumbnail_url,omitempty"`
-	TagIds      []string  `json:"tag_ids,omitempty"`
-	IsMature    bool      `json:"is_mature,omitempty"`
+	Id          string     `json:"id,omitempty"`
+	UserId      string     `json:"user_id,omitempty"`
+	UserLogin   string     `json:"user_login,omitempty"`
+	Username    string     `json:"user_name,omitempty"`
+	GameId      string     `json:"game_id,omitempty"`
+	GameName    string     `json:"game_name,omitempty"`
+	Type        StreamType `json:"type,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	ViewerCount int        `json:"viewer_count,omitempty"`
+	StartedAt   Timestamp  `json:"started_at,omitempty,omitzero"`
+	Language    string     `json:"language,omitempty"`
+	ThumnailURL string     `json:"thumbnail_url,omitempty"`
+	TagIds      []string   `json:"tag_ids,omitempty"`
+	IsMature    bool       `json:"is_mature,omitempty"`
 }
 
-type StreamsResponse struct {
-	Data       []*Stream `json:"data,omitempty"`
-	Pagination `json:"pagination,omitempty"`
-}
+type StreamsResponse = DataResponse[*Stream]
 
 func (s *StreamsService) GetStreams(ctx context.Context, opts *StreamsOptions) (*StreamsResponse, *Response, error) {
 	u, err := addParams(getStreamsPath, opts)
@@ -66,6 +97,68 @@ func (s *StreamsService) GetStreams(ctx context.Context, opts *StreamsOptions) (
 	return streams, resp, nil
 }
 
+// GetStreamsByUsers returns the live streams of any number of UserIds and
+// UserLogins, along with UserId and UserLogin if set. They are requested in
+// batches of 100 users, at most concurrency at once, which defaults to 4 if
+// it is 0. Streams are returned in the order of the ids and then the logins,
+// users that are offline are left out.
+func (s *StreamsService) GetStreamsByUsers(ctx context.Context, opts *StreamsOptions, concurrency int) ([]*Stream, *Response, error) {
+	if opts == nil {
+		opts = new(StreamsOptions)
+	}
+
+	ids, logins := opts.UserIds, opts.UserLogins
+	if opts.UserId != "" {
+		ids = append([]string{opts.UserId}, ids...)
+	}
+	if opts.UserLogin != "" {
+		logins = append([]string{opts.UserLogin}, logins...)
+	}
+
+	if len(ids)+len(logins) == 0 {
+		return nil, nil, &ErrorInvalidOptions{Options: opts, Message: "user_id or user_login is required"}
+	}
+
+	batches, resp, err := runBatches(ctx, batchCount(len(ids)+len(logins)), concurrency, func(ctx context.Context, i int) ([]*Stream, *Response, error) {
+		batch := *opts
+		batch.UserId, batch.UserLogin = "", ""
+		batch.After, batch.Before, batch.First = "", "", maxBatchSize
+		batch.UserIds, batch.UserLogins = splitBatch(ids, logins, i)
+
+		return s.StreamPages(ctx, &batch).Collect(0)
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	byId := make(map[string]*Stream)
+	byLogin := make(map[string]*Stream)
+	for _, batch := range batches {
+		for _, stream := range batch {
+			byId[stream.UserId] = stream
+			byLogin[strings.ToLower(stream.UserLogin)] = stream
+		}
+	}
+
+	streams := make([]*Stream, 0, len(byId))
+	seen := make(map[string]bool, len(byId))
+	add := func(stream *Stream) {
+		if stream != nil && !seen[stream.Id] {
+			seen[stream.Id] = true
+			streams = append(streams, stream)
+		}
+	}
+
+	for _, id := range ids {
+		add(byId[id])
+	}
+	for _, login := range logins {
+		add(byLogin[strings.ToLower(login)])
+	}
+
+	return streams, resp, nil
+}
+
 func (s *StreamsService) GetFollowedStreams(ctx context.Context, opts *StreamsOptions) (*StreamsResponse, *Response, error) {
 	if opts == nil || opts.UserId == "" {
 		return nil, nil, &ErrorInvalidOptions{Options: opts, Message: userIdIsRequired}
@@ -94,17 +187,19 @@ type BroadcasterID struct {
 	Id string `url:"broadcaster_id,omitempty"`
 }
 
-type StreamKeyResponse struct {
-	Data []*struct {
-		Key StreamKey `json:"stream_key,omitempty"`
-	} `json:"data,omitempty"`
-}
+type StreamKeyResponse = DataResponse[*struct {
+	Key StreamKey `json:"stream_key,omitempty"`
+}]
 
 type StreamKey string
 
 func (s *StreamsService) GetStreamKey(ctx context.Context, opts *BroadcasterID) (StreamKey, *Response, error) {
 	if opts == nil || opts.Id == "" {
-		return "", nil, &ErrorInvalidOptions{Options: opts, Message: broadcasterIdIsRequired}
+		if s.client.BroadcasterId == "" {
+			return "", nil, &ErrorInvalidOptions{Options: opts, Message: broadcasterIdIsRequired}
+		}
+
+		opts = &BroadcasterID{Id: s.client.BroadcasterId}
 	}
 
 	u, err := addParams(getStreamKeyPath, opts)
@@ -123,5 +218,9 @@ func (s *StreamsService) GetStreamKey(ctx context.Context, opts *BroadcasterID)
 		return "", resp, err
 	}
 
+	if len(keyResp.Data) == 0 {
+		return "", resp, ErrNoData
+	}
+
 	return keyResp.Data[0].Key, resp, nil
 }
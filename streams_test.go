@@ -1,10 +1,14 @@
-package bot
+package twitch
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -94,6 +98,76 @@ func TestGetStreams(t *testing.T) {
 	})
 }
 
+func TestGetStreamsByUsers(t *testing.T) {
+	t.Run("must request batches and return streams in input order", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var mu sync.Mutex
+		requests := 0
+		mux.HandleFunc("/"+getStreamsPath, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests++
+			mu.Unlock()
+
+			q := r.URL.Query()
+			if n := len(q["user_id"]) + len(q["user_login"]); n > 100 || q.Get("first") != "100" {
+				t.Errorf("bad batch query: %v", q)
+			}
+
+			// Only users with even ids are live, in reverse order.
+			var streams []string
+			for _, id := range q["user_id"] {
+				if n, _ := strconv.Atoi(id); n%2 == 0 {
+					streams = append([]string{fmt.Sprintf(`{"id":"s%s","user_id":"%s","user_login":"user%s"}`, id, id, id)}, streams...)
+				}
+			}
+			for _, login := range q["user_login"] {
+				id := strings.TrimPrefix(login, "user")
+				streams = append(streams, fmt.Sprintf(`{"id":"s%s","user_id":"%s","user_login":"%s"}`, id, id, login))
+			}
+			fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(streams, ","))
+		})
+
+		var ids []string
+		for i := 0; i < 150; i++ {
+			ids = append(ids, strconv.Itoa(i))
+		}
+
+		streams, _, err := c.Streams.GetStreamsByUsers(context.Background(), &StreamsOptions{
+			UserIds:    ids,
+			UserLogins: []string{"user1000"},
+		}, 0)
+		assertNoError(t, err)
+
+		if requests != 2 {
+			t.Errorf("got %d requests, want 2", requests)
+		}
+
+		if len(streams) != 76 {
+			t.Fatalf("got %d streams, want 76", len(streams))
+		}
+		for i, stream := range streams[:75] {
+			if want := strconv.Itoa(i * 2); stream.UserId != want {
+				t.Fatalf("stream %d is of %s, want %s", i, stream.UserId, want)
+			}
+		}
+		if streams[75].UserLogin != "user1000" {
+			t.Errorf("last stream is of %s, want user1000", streams[75].UserLogin)
+		}
+	})
+
+	t.Run("must return error, when users are not provided", func(t *testing.T) {
+		c, _ := NewClient(creds, nil)
+
+		_, _, err := c.Streams.GetStreamsByUsers(context.Background(), nil, 0)
+		assertErrorMessage(t, err, "user_id or user_login is required")
+
+		_, _, err = c.Streams.GetStreams(context.Background(), &StreamsOptions{UserIds: make([]string, 101)})
+		assertErrorMessage(t, err, "user_id and user_login must be at most 100 in total")
+	})
+}
+
 func TestGetFollowedStreams(t *testing.T) {
 	t.Run("tests parameters and body to be valid", func(t *testing.T) {
 		c, mux, _, teardown := setup()
@@ -161,6 +235,20 @@ func TestGetStreamKey(t *testing.T) {
 		}
 	})
 
+	t.Run("must return ErrNoData, when data is empty", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+getStreamKeyPath, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		_, _, err := c.Streams.GetStreamKey(context.Background(), &BroadcasterID{"12"})
+		if err != ErrNoData {
+			t.Errorf("expected ErrNoData, got: %v", err)
+		}
+	})
+
 	t.Run("must return error, when broadcaster_id is not provided", func(t *testing.T) {
 		client, _ := NewClient(creds, nil)
 		ctx := context.Background()
@@ -181,3 +269,55 @@ func TestGetStreamKey(t *testing.T) {
 // 		fmt.Fprint(w, `{"data":[],"pagination":{"cursor":""}}`)
 // 	})
 // }
+
+func TestCreateStreamMarker(t *testing.T) {
+	t.Run("tests parameters and body to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+createStreamMarkerPath, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodPost)
+
+			opts := new(StreamMarkerOptions)
+			json.NewDecoder(r.Body).Decode(opts)
+			if opts.UserId != "141981764" || opts.Description != "clip" {
+				t.Errorf("bad marker options: %+v", opts)
+			}
+
+			fmt.Fprint(w, `{"data":[{"id":"123","position_seconds":244,"description":"clip"}]}`)
+		})
+
+		markers, _, err := c.Streams.CreateStreamMarker(context.Background(), &StreamMarkerOptions{UserId: "141981764", Description: "clip"})
+		assertNoError(t, err)
+
+		want := []*StreamMarker{{Id: "123", PositionSeconds: 244, Description: "clip"}}
+		if !reflect.DeepEqual(markers.Data, want) {
+			t.Errorf("\ngot: %v\nwant: %v", markers.Data, want)
+		}
+	})
+
+	t.Run("empty parameters returns error", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		_, _, err := client.Streams.CreateStreamMarker(context.Background(), &StreamMarkerOptions{})
+		assertErrorMessage(t, err, "user_id is required")
+	})
+}
+
+func TestStreamType(t *testing.T) {
+	t.Run("must filter by type", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+getStreamsPath, func(w http.ResponseWriter, r *http.Request) {
+			assertQuery(t, r, params{"type": "live"})
+			fmt.Fprint(w, `{"data":[{"id":"1","type":"live"}]}`)
+		})
+
+		streams, _, err := c.Streams.GetStreams(context.Background(), &StreamsOptions{Type: StreamTypeLive})
+		assertNoError(t, err)
+
+		if streams.Data[0].Type != StreamTypeLive || !streams.Data[0].Type.Valid() {
+			t.Errorf("got type %q", streams.Data[0].Type)
+		}
+	})
+}
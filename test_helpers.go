@@ -1,4 +1,4 @@
-package bot
+package twitch
 
 import (
 	"encoding/json"
@@ -11,6 +11,7 @@ import (
 
 const (
 	baseURLPath = "/helix"
+	authURLPath = "/oauth2"
 )
 
 var creds = &Credentials{
@@ -46,6 +47,18 @@ func assertJSONMarshal(t *testing.T, v interface{}, want string) {
 	}
 }
 
+func assertJSONUnmarshal(t testing.TB, data string, got, want interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal([]byte(data), got); err != nil {
+		t.Fatalf("Unable to unmarshal JSON %v: %v", data, err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal returned\ngot: %+v\nwant: %+v", got, want)
+	}
+}
+
 func assertErrorMessage(t testing.TB, err error, msg string) {
 	t.Helper()
 
@@ -120,6 +133,7 @@ func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown fun
 	client, _ = NewClient(creds, httpClient)
 	url, _ := url.Parse(server.URL + baseURLPath)
 	client.BaseURL = url
+	client.AuthURL, _ = url.Parse(server.URL + authURLPath + "/")
 
 	return client, mux, server.URL, server.Close
 }
@@ -0,0 +1,43 @@
+package twitch
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type timeoutKey struct{}
+
+// WithTimeout makes requests with ctx time out after d, e.g. to answer
+// chat commands in time. It overrides Timeout of the client.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// withTimeout returns ctx with the timeout of the request, if any.
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	d := c.Timeout
+	if v, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		d = v
+	}
+
+	if d <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, d)
+}
+
+// cancelBody calls cancel once the body is closed, e.g. to cancel the context
+// of the request or release its slot of the ConcurrencyLimiter.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
@@ -0,0 +1,56 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	newClient := func(t *testing.T) (*Client, func()) {
+		c, mux, _, teardown := setup()
+
+		mux.HandleFunc("/streams", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("game_id") == "slow" {
+				select {
+				case <-time.After(time.Second):
+				case <-r.Context().Done():
+				}
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		return c, teardown
+	}
+
+	t.Run("call must time out after WithTimeout", func(t *testing.T) {
+		c, teardown := newClient(t)
+		defer teardown()
+
+		ctx := WithTimeout(context.Background(), 20*time.Millisecond)
+		_, _, err := c.Streams.GetStreams(ctx, &StreamsOptions{GameId: "slow"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+
+		_, _, err = c.Streams.GetStreams(ctx, nil)
+		assertNoError(t, err)
+	})
+
+	t.Run("call must time out after Timeout of the client", func(t *testing.T) {
+		c, teardown := newClient(t)
+		defer teardown()
+
+		c.Timeout = 20 * time.Millisecond
+		_, _, err := c.Streams.GetStreams(context.Background(), &StreamsOptions{GameId: "slow"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+		}
+
+		_, _, err = c.Streams.GetStreams(WithTimeout(context.Background(), 5*time.Second), &StreamsOptions{GameId: "slow"})
+		assertNoError(t, err)
+	})
+}
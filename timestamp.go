@@ -1,7 +1,8 @@
-package bot
+package twitch
 
 import (
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,6 +19,11 @@ var (
 	// unixOrigin    = time.Unix(0, 0).In(time.UTC)
 )
 
+// Timestamp is a time of the API. It is marshaled as RFC 3339 in UTC and
+// unmarshaled from RFC 3339 or Unix time, in seconds or milliseconds.
+//
+// Zero timestamps are marshaled as null. Fields of timestamps are tagged
+// omitzero as well, so they are left out of the structs of this package.
 type Timestamp struct {
 	time.Time
 }
@@ -26,8 +32,33 @@ func (t Timestamp) String() string {
 	return t.Time.String()
 }
 
+// Equal reports whether t and u are the same instant.
+func (t Timestamp) Equal(u Timestamp) bool {
+	return t.Time.Equal(u.Time)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(`"` + t.UTC().Format(time.RFC3339Nano) + `"`), nil
+}
+
 func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
 	str := string(data)
+	if str == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	// Quoted timestamps are parsed right away, failing to parse them
+	// as Unix time first allocates an error for every timestamp.
+	if strings.HasPrefix(str, `"`) {
+		t.Time, err = time.Parse(`"`+time.RFC3339+`"`, str)
+		return
+	}
+
 	i, err := strconv.ParseInt(str, 10, 64)
 	if err == nil {
 		t.Time = time.Unix(i, 0)
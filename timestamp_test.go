@@ -0,0 +1,55 @@
+package twitch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	t.Run("must round-trip RFC 3339", func(t *testing.T) {
+		ts := Timestamp{time.Date(2024, time.March, 1, 12, 30, 0, 500, time.FixedZone("MSK", 3*60*60))}
+
+		data, err := json.Marshal(ts)
+		assertNoError(t, err)
+		if want := `"2024-03-01T09:30:00.0000005Z"`; string(data) != want {
+			t.Errorf("\ngot: %s\nwant: %s", data, want)
+		}
+
+		var got Timestamp
+		assertNoError(t, json.Unmarshal(data, &got))
+		if !got.Equal(ts) {
+			t.Errorf("\ngot: %v\nwant: %v", got, ts)
+		}
+	})
+
+	t.Run("must marshal zero as null and omit it", func(t *testing.T) {
+		data, err := json.Marshal(Timestamp{})
+		assertNoError(t, err)
+		if string(data) != "null" {
+			t.Errorf("got %s, want null", data)
+		}
+
+		var ts Timestamp
+		assertNoError(t, json.Unmarshal([]byte("null"), &ts))
+		if !ts.IsZero() {
+			t.Errorf("got %v, want zero", ts)
+		}
+
+		data, err = json.Marshal(&Stream{Id: "1"})
+		assertNoError(t, err)
+		if want := `{"id":"1"}`; string(data) != want {
+			t.Errorf("\ngot: %s\nwant: %s", data, want)
+		}
+	})
+
+	t.Run("must unmarshal unix time", func(t *testing.T) {
+		for _, data := range []string{referenceUnixTimeStr, referenceUnixTimeStrMilliSeconds, referenceTimeStr} {
+			var ts Timestamp
+			assertNoError(t, json.Unmarshal([]byte(data), &ts))
+			if !ts.Equal(Timestamp{referenceTime}) {
+				t.Errorf("%s: got %v, want %v", data, ts, referenceTime)
+			}
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrNoToken is returned by Token when the client doesn't send a token
+// itself, e.g. the http.Client passed to NewClient adds it.
+var ErrNoToken = errors.New("client sends no token")
+
+type tokenKind int
+
+const (
+	userToken tokenKind = iota + 1
+	appToken
+)
+
+type tokenKindKey struct{}
+
+// WithAppToken makes requests with ctx use the app access token
+// of a client holding both an app and a user token.
+func WithAppToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tokenKindKey{}, appToken)
+}
+
+// WithUserToken makes requests with ctx use the user access token, even for
+// endpoints that use the app token by default.
+func WithUserToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tokenKindKey{}, userToken)
+}
+
+// preferAppToken selects the app token unless a token is selected already.
+func preferAppToken(ctx context.Context) context.Context {
+	if ctx == nil || ctx.Value(tokenKindKey{}) != nil {
+		return ctx
+	}
+
+	return WithAppToken(ctx)
+}
+
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	if kind, _ := ctx.Value(tokenKindKey{}).(tokenKind); kind == appToken && c.appHTTPClient != nil {
+		return c.appHTTPClient
+	}
+
+	return c.HTTPClient
+}
+
+// Token returns the access token sent by the client, refreshed if it expired,
+// e.g. to log in to chat. It is the user token of clients holding one,
+// the app token otherwise.
+func (c *Client) Token() (*oauth2.Token, error) {
+	if t, ok := c.HTTPClient.Transport.(*oauth2.Transport); ok && t.Source != nil {
+		return t.Source.Token()
+	}
+
+	return nil, ErrNoToken
+}
@@ -0,0 +1,73 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTokenSelection(t *testing.T) {
+	c, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	var got []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"data":[]}`)
+	}
+	mux.HandleFunc(baseURLPath+"/users", handler)
+	mux.HandleFunc(baseURLPath+"/"+eventSubConduitsPath, handler)
+	mux.HandleFunc(baseURLPath+"/"+eventSubSubscriptionsPath, handler)
+
+	c, err := NewClient(&Credentials{ClientId: "ClientId", ClientSecret: "ClientSecret", AccessToken: "user"}, nil)
+	assertNoError(t, err)
+	c.BaseURL, _ = url.Parse(serverURL + baseURLPath + "/")
+
+	if c.appHTTPClient == nil {
+		t.Fatal("client with secret and user token must hold an app token")
+	}
+	c.appHTTPClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "app"}))
+
+	ctx := context.Background()
+	users := &UsersOptions{Ids: []string{"1"}}
+
+	c.Users.GetUsers(ctx, users)
+	c.Users.GetUsers(WithAppToken(ctx), users)
+	c.EventSub.GetConduits(ctx)
+	c.EventSub.GetConduits(WithUserToken(ctx))
+	c.EventSub.CreateSubscription(ctx, &EventSubSubscriptionOptions{Type: EventSubChannelRaid, Version: "1", Transport: EventSubTransport{Method: EventSubTransportWebhook}})
+	c.EventSub.CreateSubscription(ctx, &EventSubSubscriptionOptions{Type: EventSubChannelRaid, Version: "1", Transport: EventSubTransport{Method: EventSubTransportWebSocket}})
+
+	want := "[Bearer user Bearer app Bearer app Bearer user Bearer app Bearer user]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("bad tokens\ngot: %v\nwant: %s", got, want)
+	}
+}
+
+func TestClientToken(t *testing.T) {
+	t.Run("must return the token sent by the client", func(t *testing.T) {
+		c, err := NewClientWithToken("ClientId", "t0ken", nil)
+		assertNoError(t, err)
+		defer c.Close()
+
+		token, err := c.Token()
+		assertNoError(t, err)
+
+		if token.AccessToken != "t0ken" {
+			t.Errorf("bad token %q", token.AccessToken)
+		}
+	})
+
+	t.Run("must return ErrNoToken, when the http.Client adds the token", func(t *testing.T) {
+		c, _, _, teardown := setup()
+		defer teardown()
+
+		if _, err := c.Token(); err != ErrNoToken {
+			t.Errorf("expected ErrNoToken, got: %v", err)
+		}
+	})
+}
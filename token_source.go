@@ -0,0 +1,127 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+var ErrTokenNotRefreshable = errors.New("client has no refresh token")
+
+// ErrorTokenRefresh is returned when a request is rejected with 401
+// and refreshing the user token fails.
+type ErrorTokenRefresh struct {
+	Err error
+}
+
+func (e *ErrorTokenRefresh) Error() string {
+	return fmt.Sprintf("Message: token refresh failed: %v", e.Err)
+}
+
+func (e *ErrorTokenRefresh) Unwrap() error {
+	return e.Err
+}
+
+// tokenSource provides the user token of the client, refreshing it when it
+// expires. Every new token is saved to TokenStore and passed to OnTokenRefreshed
+// of the credentials, as Twitch rotates refresh tokens and the old one stops working.
+// For the same reason, the token is refreshed by one caller at a time.
+type tokenSource struct {
+	client *Client
+	// httpClient is used to refresh the token, if set.
+	httpClient *http.Client
+
+	// refreshMu serializes refreshes, it is held during the request.
+	refreshMu sync.Mutex
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func (s *tokenSource) Token() (*oauth2.Token, error) {
+	token := s.current()
+	if token.Valid() {
+		return token, nil
+	}
+
+	token, err := s.refresh(s.client.ctx, token)
+	if token != nil {
+		return token, nil
+	}
+
+	return nil, err
+}
+
+func (s *tokenSource) current() *oauth2.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token
+}
+
+func (s *tokenSource) refreshable() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token.RefreshToken != ""
+}
+
+// refresh refreshes the token. If seen is set, it is the token the caller
+// found unusable, and the current token is returned without a request if
+// another caller already replaced it.
+func (s *tokenSource) refresh(ctx context.Context, seen *oauth2.Token) (*oauth2.Token, error) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	old := s.current()
+	if seen != nil && old != seen {
+		return old, nil
+	}
+
+	if old.RefreshToken == "" {
+		return nil, ErrTokenNotRefreshable
+	}
+
+	creds := s.client.credentials
+	config := &oauth2.Config{
+		ClientID:     creds.ClientId,
+		ClientSecret: creds.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   s.client.AuthURL.String() + authorizePath,
+			TokenURL:  s.client.AuthURL.String() + tokenPath,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+
+	if s.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, s.httpClient)
+	}
+
+	token, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: old.RefreshToken}).Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = old.RefreshToken
+	}
+
+	s.mu.Lock()
+	s.token = token
+	s.mu.Unlock()
+
+	if creds.TokenStore != nil && creds.UserId != "" {
+		err = creds.TokenStore.Save(ctx, creds.UserId, token)
+	}
+
+	if creds.OnTokenRefreshed != nil {
+		creds.OnTokenRefreshed(token)
+	}
+
+	// The token is usable even if it could not be saved.
+	return token, err
+}
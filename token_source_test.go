@@ -0,0 +1,146 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTokenRefresh(t *testing.T) {
+	newClient := func(t *testing.T) (*Client, *[]*oauth2.Token, func()) {
+		_, mux, serverURL, teardown := setup()
+
+		refreshes := 0
+		mux.HandleFunc(authURLPath+"/"+tokenPath, func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("client_id") != "ClientId" {
+				t.Errorf("bad refresh request: %v", r.Form)
+			}
+
+			refreshes++
+			w.Header().Set("Content-Type", applicationJSON)
+			fmt.Fprintf(w, `{"access_token":"access%d","refresh_token":"refresh%d","expires_in":3600,"token_type":"bearer"}`, refreshes, refreshes)
+		})
+
+		mux.HandleFunc(baseURLPath+"/users", func(w http.ResponseWriter, r *http.Request) {
+			if got, want := r.Header.Get("Authorization"), fmt.Sprintf("Bearer access%d", refreshes); got != want {
+				t.Errorf("bad authorization\ngot: %s\nwant: %s", got, want)
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		var refreshed []*oauth2.Token
+		c, err := NewClient(&Credentials{
+			ClientId:         "ClientId",
+			OAuthToken:       &oauth2.Token{AccessToken: "access0", RefreshToken: "refresh0", Expiry: time.Now().Add(-time.Minute)},
+			OnTokenRefreshed: func(token *oauth2.Token) { refreshed = append(refreshed, token) },
+		}, nil)
+		assertNoError(t, err)
+
+		c.BaseURL, _ = url.Parse(serverURL + baseURLPath + "/")
+		c.AuthURL, _ = url.Parse(serverURL + authURLPath + "/")
+
+		return c, &refreshed, teardown
+	}
+
+	t.Run("expired token must be refreshed and reported", func(t *testing.T) {
+		c, refreshed, teardown := newClient(t)
+		defer teardown()
+
+		_, _, err := c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+		assertNoError(t, err)
+
+		if len(*refreshed) != 1 || (*refreshed)[0].RefreshToken != "refresh1" {
+			t.Errorf("bad refreshed tokens: %v", *refreshed)
+		}
+	})
+
+	t.Run("token must be refreshed on demand", func(t *testing.T) {
+		c, refreshed, teardown := newClient(t)
+		defer teardown()
+
+		token, err := c.Auth.RefreshToken(context.Background())
+		assertNoError(t, err)
+
+		token, err = c.Auth.RefreshToken(context.Background())
+		assertNoError(t, err)
+
+		if token.AccessToken != "access2" || len(*refreshed) != 2 {
+			t.Errorf("bad token %v, refreshed: %v", token, *refreshed)
+		}
+
+		_, _, err = c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+		assertNoError(t, err)
+	})
+
+	t.Run("must return error without refresh token", func(t *testing.T) {
+		c, _ := NewClient(creds, nil)
+		if _, err := c.Auth.RefreshToken(context.Background()); err != ErrTokenNotRefreshable {
+			t.Errorf("expected ErrTokenNotRefreshable, got: %v", err)
+		}
+	})
+}
+
+func TestUnauthorizedRetry(t *testing.T) {
+	newClient := func(t *testing.T, tokenStatus int) (*Client, *int, func()) {
+		_, mux, serverURL, teardown := setup()
+
+		mux.HandleFunc(authURLPath+"/"+tokenPath, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", applicationJSON)
+			w.WriteHeader(tokenStatus)
+			fmt.Fprint(w, `{"access_token":"access1","refresh_token":"refresh1","expires_in":3600,"token_type":"bearer"}`)
+		})
+
+		requests := 0
+		mux.HandleFunc(baseURLPath+"/users", func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if r.Header.Get("Authorization") != "Bearer access1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		c, err := NewClient(&Credentials{
+			ClientId:   "ClientId",
+			OAuthToken: &oauth2.Token{AccessToken: "access0", RefreshToken: "refresh0", Expiry: time.Now().Add(time.Hour)},
+		}, nil)
+		assertNoError(t, err)
+
+		c.BaseURL, _ = url.Parse(serverURL + baseURLPath + "/")
+		c.AuthURL, _ = url.Parse(serverURL + authURLPath + "/")
+
+		return c, &requests, teardown
+	}
+
+	t.Run("rejected request must be retried once with refreshed token", func(t *testing.T) {
+		c, requests, teardown := newClient(t, http.StatusOK)
+		defer teardown()
+
+		_, _, err := c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+		assertNoError(t, err)
+
+		if *requests != 2 {
+			t.Errorf("expected 2 requests, got: %d", *requests)
+		}
+	})
+
+	t.Run("must return ErrorTokenRefresh, when refresh fails", func(t *testing.T) {
+		c, requests, teardown := newClient(t, http.StatusBadRequest)
+		defer teardown()
+
+		_, _, err := c.Users.GetUsers(context.Background(), &UsersOptions{Ids: []string{"1"}})
+		if _, ok := err.(*ErrorTokenRefresh); !ok {
+			t.Errorf("expected *ErrorTokenRefresh, got: %v", err)
+		}
+
+		if *requests != 1 {
+			t.Errorf("expected 1 request, got: %d", *requests)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package twitch
+
+import (
+	"github.com/holypower777/go-twitch/auth"
+)
+
+var (
+	ErrTokenNotFound     = auth.ErrTokenNotFound
+	ErrTokenNotEncrypted = auth.ErrTokenNotEncrypted
+)
+
+// The token stores live in the auth package, they are aliased here
+// so Credentials can be set up with this package alone.
+type (
+	TokenStore          = auth.TokenStore
+	MemoryTokenStore    = auth.MemoryTokenStore
+	FileTokenStore      = auth.FileTokenStore
+	EncryptedTokenStore = auth.EncryptedTokenStore
+)
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return auth.NewMemoryTokenStore()
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return auth.NewFileTokenStore(path)
+}
+
+func NewEncryptedTokenStore(store TokenStore, key []byte) (*EncryptedTokenStore, error) {
+	return auth.NewEncryptedTokenStore(store, key)
+}
@@ -0,0 +1,43 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestClientTokenStore(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(authURLPath+"/"+tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("refresh_token") != "stored" {
+			t.Errorf("stored refresh token must be used: %v", r.Form)
+		}
+
+		w.Header().Set("Content-Type", applicationJSON)
+		fmt.Fprint(w, `{"access_token":"new","refresh_token":"rotated","expires_in":3600,"token_type":"bearer"}`)
+	})
+
+	store := NewMemoryTokenStore()
+	ctx := context.Background()
+	store.Save(ctx, "1337", &oauth2.Token{AccessToken: "old", RefreshToken: "stored", Expiry: time.Now().Add(-time.Minute)})
+
+	c, err := NewClient(&Credentials{ClientId: "ClientId", TokenStore: store, UserId: "1337"}, nil)
+	assertNoError(t, err)
+	c.AuthURL, _ = url.Parse(serverURL + authURLPath + "/")
+
+	_, err = c.Auth.RefreshToken(ctx)
+	assertNoError(t, err)
+
+	token, _ := store.Load(ctx, "1337")
+	if token.RefreshToken != "rotated" {
+		t.Errorf("rotated token must be saved, got: %+v", token)
+	}
+}
@@ -0,0 +1,137 @@
+package twitch
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Defaults of TransportOptions, tuned for long-running clients sending
+// many concurrent requests to the few hosts of Twitch.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultKeepAlive           = 30 * time.Second
+	defaultDialTimeout         = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// defaultTransport is shared by the clients created without an http.Client,
+// so their connections are reused across clients.
+var defaultTransport = NewTransport(nil)
+
+// TransportOptions tune the connections of a transport made by NewTransport.
+// Zero fields are set to their defaults.
+type TransportOptions struct {
+	// MaxIdleConns limits the idle connections to all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits the idle connections to a host, it should
+	// be at least the number of concurrent requests to reuse connections.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the connections to a host, including active
+	// ones. Connections are not limited if it is 0.
+	MaxConnsPerHost int
+	// IdleConnTimeout is the time idle connections are kept open.
+	IdleConnTimeout time.Duration
+	// KeepAlive is the interval of TCP keep-alive probes,
+	// they are disabled if it is negative.
+	KeepAlive time.Duration
+	// DisableKeepAlives makes every request use a new connection.
+	DisableKeepAlives bool
+	// DisableHTTP2 makes requests use HTTP/1.1 only.
+	DisableHTTP2 bool
+	// Proxy is the proxy of all requests. If it is nil, requests use the
+	// proxy of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	Proxy *url.URL
+}
+
+// NewTransport returns a transport with opts, which may be nil, to be used by
+// the http.Client passed to NewClient, e.g. to use more connections:
+//
+//	transport := twitch.NewTransport(&twitch.TransportOptions{MaxIdleConnsPerHost: 64})
+//	client, err := twitch.NewClient(creds, &http.Client{Transport: transport})
+//
+// Clients created without an http.Client share a transport with the defaults.
+func NewTransport(opts *TransportOptions) *http.Transport {
+	o := TransportOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.MaxIdleConns == 0 {
+		o.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost == 0 {
+		o.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout == 0 {
+		o.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	if o.KeepAlive == 0 {
+		o.KeepAlive = DefaultKeepAlive
+	}
+
+	dialer := &net.Dialer{Timeout: defaultDialTimeout, KeepAlive: o.KeepAlive}
+
+	t := &http.Transport{
+		Proxy:                 proxyFunc(o.Proxy),
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     !o.DisableHTTP2,
+		MaxIdleConns:          o.MaxIdleConns,
+		MaxIdleConnsPerHost:   o.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       o.MaxConnsPerHost,
+		IdleConnTimeout:       o.IdleConnTimeout,
+		TLSHandshakeTimeout:   defaultTLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+		DisableKeepAlives:     o.DisableKeepAlives,
+	}
+
+	if o.DisableHTTP2 {
+		// A non-nil empty map keeps the transport from upgrading to HTTP/2.
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return t
+}
+
+// proxyFunc returns the proxy function of http.Transport sending requests
+// through proxyURL, or the proxy of the environment if it is nil.
+func proxyFunc(proxyURL *url.URL) func(*http.Request) (*url.URL, error) {
+	if proxyURL == nil {
+		return http.ProxyFromEnvironment
+	}
+
+	return http.ProxyURL(proxyURL)
+}
+
+// withProxy returns a copy of httpClient sending requests through proxy,
+// the token layers of its transport are kept.
+func withProxy(httpClient *http.Client, proxy func(*http.Request) (*url.URL, error)) *http.Client {
+	c := *httpClient
+	c.Transport = proxiedTransport(c.Transport, proxy)
+
+	return &c
+}
+
+// proxiedTransport returns a copy of rt sending requests through proxy.
+// Transports other than *http.Transport, or oauth2 transports on top of it,
+// are returned as they are.
+func proxiedTransport(rt http.RoundTripper, proxy func(*http.Request) (*url.URL, error)) http.RoundTripper {
+	switch t := rt.(type) {
+	case nil:
+		return proxiedTransport(http.DefaultTransport, proxy)
+	case *oauth2.Transport:
+		return &oauth2.Transport{Source: t.Source, Base: proxiedTransport(t.Base, proxy)}
+	case *http.Transport:
+		t = t.Clone()
+		t.Proxy = proxy
+		return t
+	default:
+		return rt
+	}
+}
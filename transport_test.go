@@ -0,0 +1,59 @@
+package twitch
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestNewTransport(t *testing.T) {
+	t.Run("zero options must be set to defaults", func(t *testing.T) {
+		tr := NewTransport(&TransportOptions{MaxConnsPerHost: 8})
+
+		if tr.MaxIdleConns != DefaultMaxIdleConns || tr.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost ||
+			tr.IdleConnTimeout != DefaultIdleConnTimeout || tr.MaxConnsPerHost != 8 {
+			t.Errorf("bad transport %+v", tr)
+		}
+
+		if !tr.ForceAttemptHTTP2 || tr.TLSNextProto != nil || tr.Proxy == nil {
+			t.Error("HTTP/2 and proxies from the environment must be used by default")
+		}
+	})
+
+	t.Run("must disable HTTP/2 and keep-alives", func(t *testing.T) {
+		tr := NewTransport(&TransportOptions{DisableHTTP2: true, DisableKeepAlives: true, IdleConnTimeout: time.Minute})
+
+		if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil || len(tr.TLSNextProto) != 0 {
+			t.Error("HTTP/2 must be disabled")
+		}
+
+		if !tr.DisableKeepAlives || tr.IdleConnTimeout != time.Minute {
+			t.Errorf("bad transport %+v", tr)
+		}
+	})
+
+	t.Run("must send requests through Proxy", func(t *testing.T) {
+		proxyURL, _ := url.Parse("http://proxy.invalid:3128")
+		tr := NewTransport(&TransportOptions{Proxy: proxyURL})
+
+		req, _ := http.NewRequest(http.MethodGet, defaultBaseURL, nil)
+		if got, err := tr.Proxy(req); err != nil || got != proxyURL {
+			t.Errorf("bad proxy %v: %v", got, err)
+		}
+	})
+
+	t.Run("clients without http.Client must share the default transport", func(t *testing.T) {
+		for _, cr := range []*Credentials{creds, {ClientId: "kek", AccessToken: "t0ken"}} {
+			c, err := NewClient(cr, nil)
+			assertNoError(t, err)
+
+			transport, ok := c.HTTPClient.Transport.(*oauth2.Transport)
+			if !ok || transport.Base != defaultTransport {
+				t.Errorf("default transport is not used: %#v", c.HTTPClient.Transport)
+			}
+		}
+	})
+}
@@ -0,0 +1,346 @@
+package twitchtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	authPath            = "/oauth2/"
+	defaultTokenTTL     = 4 * time.Hour
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+type authToken struct {
+	clientId string
+	userId   string
+	login    string
+	scopes   []string
+	expiry   time.Time
+}
+
+type deviceAuthorization struct {
+	clientId   string
+	scopes     []string
+	userCode   string
+	userId     string
+	login      string
+	authorized bool
+}
+
+// AuthServer is a fake id.twitch.tv server implementing the token, validate,
+// revoke and device endpoints.
+//
+// Set Client.AuthURL, or AuthURL of the auth flows, to URL. Tokens are issued
+// with IssueToken, the device code flow is completed with AuthorizeDevice.
+type AuthServer struct {
+	URL string
+
+	// ClientId and ClientSecret are the credentials of the application,
+	// requests with other credentials are rejected. ClientSecret is not
+	// checked if it is empty.
+	ClientId     string
+	ClientSecret string
+	// TokenTTL is the lifetime of issued access tokens.
+	TokenTTL time.Duration
+
+	server *httptest.Server
+
+	mu      sync.Mutex
+	counter int
+	tokens  map[string]*authToken
+	refresh map[string]*authToken
+	devices map[string]*deviceAuthorization
+}
+
+func NewAuthServer(clientId, clientSecret string) *AuthServer {
+	s := &AuthServer{
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		TokenTTL:     defaultTokenTTL,
+		tokens:       make(map[string]*authToken),
+		refresh:      make(map[string]*authToken),
+		devices:      make(map[string]*deviceAuthorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(authPath+"token", s.serveToken)
+	mux.HandleFunc(authPath+"validate", s.serveValidate)
+	mux.HandleFunc(authPath+"revoke", s.serveRevoke)
+	mux.HandleFunc(authPath+"device", s.serveDevice)
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL + authPath
+
+	return s
+}
+
+func (s *AuthServer) Close() {
+	s.server.Close()
+}
+
+// IssueToken issues a user token with a refresh token.
+func (s *AuthServer) IssueToken(userId, login string, scopes ...string) *oauth2.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issue(&authToken{clientId: s.ClientId, userId: userId, login: login, scopes: scopes}, true)
+}
+
+// ExpireToken makes the access token invalid, so it has to be refreshed.
+func (s *AuthServer) ExpireToken(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t := s.tokens[accessToken]; t != nil {
+		t.expiry = time.Now()
+	}
+}
+
+// ValidToken reports whether the access token is issued, not expired and not revoked.
+func (s *AuthServer) ValidToken(accessToken string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.valid(accessToken) != nil
+}
+
+// AuthorizeDevice authorizes the device code with userCode on behalf of the user,
+// as if the user entered the code at the verification URI.
+func (s *AuthServer) AuthorizeDevice(userCode, userId, login string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, d := range s.devices {
+		if !d.authorized && strings.EqualFold(userCode, d.userCode) {
+			d.userId, d.login, d.authorized = userId, login, true
+			return nil
+		}
+	}
+
+	return fmt.Errorf("twitchtest: unknown user code %q", userCode)
+}
+
+func (s *AuthServer) issue(t *authToken, withRefresh bool) *oauth2.Token {
+	s.counter++
+	t.expiry = time.Now().Add(s.TokenTTL)
+
+	token := &oauth2.Token{
+		AccessToken: fmt.Sprintf("access-%d", s.counter),
+		TokenType:   "bearer",
+		Expiry:      t.expiry,
+	}
+	s.tokens[token.AccessToken] = t
+
+	if withRefresh {
+		token.RefreshToken = fmt.Sprintf("refresh-%d", s.counter)
+		s.refresh[token.RefreshToken] = t
+	}
+
+	return token
+}
+
+func (s *AuthServer) valid(accessToken string) *authToken {
+	t := s.tokens[accessToken]
+	if t == nil || !time.Now().Before(t.expiry) {
+		return nil
+	}
+
+	return t
+}
+
+func (s *AuthServer) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAuthError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	clientId, clientSecret := r.FormValue("client_id"), r.FormValue("client_secret")
+	if id, secret, ok := r.BasicAuth(); ok {
+		clientId, clientSecret = id, secret
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.FormValue("grant_type") {
+	case "client_credentials":
+		if clientId != s.ClientId || clientSecret == "" || clientSecret != s.ClientSecret {
+			writeAuthError(w, http.StatusForbidden, "invalid client secret")
+			return
+		}
+
+		token := s.issue(&authToken{clientId: clientId, scopes: scopes(r)}, false)
+		writeToken(w, token, nil)
+
+	case "refresh_token":
+		if !s.checkClient(clientId, clientSecret) {
+			writeAuthError(w, http.StatusForbidden, "invalid client secret")
+			return
+		}
+
+		old := s.refresh[r.FormValue("refresh_token")]
+		if old == nil {
+			writeAuthError(w, http.StatusBadRequest, "Invalid refresh token")
+			return
+		}
+
+		// Refresh tokens are rotated, the old one stops working.
+		delete(s.refresh, r.FormValue("refresh_token"))
+		t := *old
+		writeToken(w, s.issue(&t, true), t.scopes)
+
+	case grantTypeDeviceCode:
+		if !s.checkClient(clientId, "") {
+			writeAuthError(w, http.StatusBadRequest, "invalid client")
+			return
+		}
+
+		d := s.devices[r.FormValue("device_code")]
+		switch {
+		case d == nil:
+			writeAuthError(w, http.StatusBadRequest, "invalid device code")
+		case !d.authorized:
+			writeAuthError(w, http.StatusBadRequest, "authorization_pending")
+		default:
+			delete(s.devices, r.FormValue("device_code"))
+			token := s.issue(&authToken{clientId: clientId, userId: d.userId, login: d.login, scopes: d.scopes}, true)
+			writeToken(w, token, d.scopes)
+		}
+
+	default:
+		writeAuthError(w, http.StatusBadRequest, "unsupported grant type")
+	}
+}
+
+func (s *AuthServer) serveValidate(w http.ResponseWriter, r *http.Request) {
+	accessToken := r.Header.Get("Authorization")
+	for _, prefix := range []string{"OAuth ", "Bearer "} {
+		accessToken = strings.TrimPrefix(accessToken, prefix)
+	}
+
+	s.mu.Lock()
+	t := s.valid(accessToken)
+	s.mu.Unlock()
+
+	if t == nil {
+		writeAuthError(w, http.StatusUnauthorized, "invalid access token")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"client_id":  t.clientId,
+		"login":      t.login,
+		"user_id":    t.userId,
+		"scopes":     t.scopes,
+		"expires_in": int(time.Until(t.expiry).Seconds()),
+	})
+}
+
+func (s *AuthServer) serveRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAuthError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.tokens[r.FormValue("token")]
+	if t == nil {
+		writeAuthError(w, http.StatusBadRequest, "Invalid token")
+		return
+	}
+
+	if t.clientId != r.FormValue("client_id") {
+		writeAuthError(w, http.StatusNotFound, "client does not exist")
+		return
+	}
+
+	delete(s.tokens, r.FormValue("token"))
+	for refresh, rt := range s.refresh {
+		if rt == t {
+			delete(s.refresh, refresh)
+		}
+	}
+}
+
+func (s *AuthServer) serveDevice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAuthError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkClient(r.FormValue("client_id"), "") {
+		writeAuthError(w, http.StatusBadRequest, "invalid client")
+		return
+	}
+
+	s.counter++
+	deviceCode := fmt.Sprintf("device-%d", s.counter)
+	userCode := fmt.Sprintf("CODE%04d", s.counter)
+
+	s.devices[deviceCode] = &deviceAuthorization{
+		clientId: r.FormValue("client_id"),
+		scopes:   scopes(r),
+		userCode: userCode,
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"device_code":      deviceCode,
+		"user_code":        userCode,
+		"verification_uri": s.server.URL + "/activate?device-code=" + userCode,
+		"expires_in":       1800,
+		"interval":         1,
+	})
+}
+
+func (s *AuthServer) checkClient(clientId, clientSecret string) bool {
+	if clientId != s.ClientId {
+		return false
+	}
+
+	return clientSecret == "" || s.ClientSecret == "" || clientSecret == s.ClientSecret
+}
+
+func scopes(r *http.Request) []string {
+	scope := r.FormValue("scope")
+	if scope == "" {
+		scope = r.FormValue("scopes")
+	}
+
+	return strings.Fields(scope)
+}
+
+func writeToken(w http.ResponseWriter, token *oauth2.Token, scopes []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  token.AccessToken,
+		"refresh_token": token.RefreshToken,
+		"expires_in":    int(time.Until(token.Expiry).Seconds()),
+		"scope":         scopes,
+		"token_type":    token.TokenType,
+	})
+}
+
+func writeAuthError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"status":  status,
+		"message": message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
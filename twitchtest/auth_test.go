@@ -0,0 +1,131 @@
+package twitchtest
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/holypower777/go-twitch"
+	"golang.org/x/oauth2"
+)
+
+func TestAuthServer(t *testing.T) {
+	t.Run("must validate, refresh and revoke tokens", func(t *testing.T) {
+		s := NewAuthServer("ClientId", "ClientSecret")
+		defer s.Close()
+
+		token := s.IssueToken("141981764", "twitchdev", "chat:read")
+
+		c, err := twitch.NewClient(&twitch.Credentials{ClientId: "ClientId", OAuthToken: token}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.AuthURL, _ = url.Parse(s.URL)
+
+		ctx := context.Background()
+		validation, _, err := c.Auth.ValidateToken(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if validation.Login != "twitchdev" || validation.UserId != "141981764" || validation.ClientId != "ClientId" {
+			t.Errorf("bad validation: %+v", validation)
+		}
+
+		s.ExpireToken(token.AccessToken)
+		if _, _, err := c.Auth.ValidateToken(ctx); err != nil {
+			t.Fatalf("expired token must be refreshed, got: %v", err)
+		}
+
+		refreshed, err := c.Auth.RefreshToken(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.ValidToken(refreshed.AccessToken) || s.ValidToken(token.AccessToken) {
+			t.Errorf("bad token state after refresh: %+v", refreshed)
+		}
+
+		if _, err := c.Auth.RevokeToken(ctx, refreshed.AccessToken); err != nil {
+			t.Fatal(err)
+		}
+		if s.ValidToken(refreshed.AccessToken) {
+			t.Error("revoked token must not be valid")
+		}
+	})
+
+	t.Run("must complete device code flow", func(t *testing.T) {
+		s := NewAuthServer("ClientId", "")
+		defer s.Close()
+
+		f := &twitch.DeviceCodeFlow{
+			ClientId: "ClientId",
+			Scopes:   []string{"chat:read"},
+			AuthURL:  s.URL,
+			OnDeviceCode: func(code *twitch.DeviceCode) {
+				if err := s.AuthorizeDevice(code.UserCode, "141981764", "twitchdev"); err != nil {
+					t.Error(err)
+				}
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		token, err := f.Run(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.ValidToken(token.AccessToken) || token.RefreshToken == "" {
+			t.Errorf("bad token: %+v", token)
+		}
+	})
+	t.Run("must refresh the token once for concurrent requests", func(t *testing.T) {
+		s := NewAuthServer("ClientId", "ClientSecret")
+		defer s.Close()
+
+		token := s.IssueToken("141981764", "twitchdev", "chat:read")
+
+		var mu sync.Mutex
+		var refreshed []*oauth2.Token
+		creds := &twitch.Credentials{
+			ClientId:     "ClientId",
+			ClientSecret: "ClientSecret",
+			OAuthToken:   token,
+			OnTokenRefreshed: func(token *oauth2.Token) {
+				mu.Lock()
+				defer mu.Unlock()
+				refreshed = append(refreshed, token)
+			},
+		}
+
+		c, err := twitch.NewClient(creds, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.AuthURL, _ = url.Parse(s.URL)
+
+		// Every request is rejected with the old token, but only the first
+		// one refreshes it, as the old refresh token stops working then.
+		s.ExpireToken(token.AccessToken)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, _, err := c.Auth.ValidateToken(context.Background()); err != nil {
+					t.Errorf("expired token must be refreshed, got: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(refreshed) != 1 {
+			t.Fatalf("expected a single refresh, got: %d", len(refreshed))
+		}
+		if !s.ValidToken(refreshed[0].AccessToken) {
+			t.Errorf("the refreshed token must be valid: %+v", refreshed[0])
+		}
+	})
+}
@@ -0,0 +1,395 @@
+// Package twitchtest provides fake Twitch servers to integration-test
+// code built on top of the client without talking to Twitch.
+package twitchtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/holypower777/go-twitch"
+)
+
+const (
+	eventSubWebSocketPath          = "/ws"
+	defaultKeepaliveTimeoutSeconds = 10
+)
+
+var ErrNoSession = errors.New("twitchtest: no eventsub websocket session is connected")
+
+type eventSubConn struct {
+	conn    *websocket.Conn
+	session *twitch.EventSubSession
+
+	mu sync.Mutex
+}
+
+func (c *eventSubConn) write(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn.WriteJSON(v)
+}
+
+// EventSubServer is a fake EventSub server. It speaks the WebSocket
+// protocol on WebSocketURL and delivers webhook messages to callbacks.
+//
+// Point EventSubWebSocket.URL to WebSocketURL, wait for the session with
+// WaitForSession and push messages with Notify, Revoke, Keepalive and Reconnect.
+type EventSubServer struct {
+	URL          string
+	WebSocketURL string
+
+	// KeepaliveTimeoutSeconds is sent in welcome messages,
+	// unless the client asks for another value.
+	KeepaliveTimeoutSeconds int
+	// HTTPClient is used to deliver webhook messages.
+	HTTPClient *http.Client
+
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu        sync.Mutex
+	sessions  map[string]*eventSubConn
+	order     []string
+	created   int
+	messages  int
+	connected chan struct{}
+}
+
+func NewEventSubServer() *EventSubServer {
+	s := &EventSubServer{
+		KeepaliveTimeoutSeconds: defaultKeepaliveTimeoutSeconds,
+		HTTPClient:              http.DefaultClient,
+		sessions:                make(map[string]*eventSubConn),
+		connected:               make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(eventSubWebSocketPath, s.serveWebSocket)
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+	s.WebSocketURL = "ws" + strings.TrimPrefix(s.server.URL, "http") + eventSubWebSocketPath
+
+	return s
+}
+
+func (s *EventSubServer) Close() {
+	s.mu.Lock()
+	for _, c := range s.sessions {
+		c.conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.server.Close()
+}
+
+// Sessions returns ids of the connected sessions in the order they were created.
+func (s *EventSubServer) Sessions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions := make([]string, len(s.order))
+	copy(sessions, s.order)
+
+	return sessions
+}
+
+// WaitForSession returns the id of the latest session,
+// waiting for a client to connect if there is none.
+func (s *EventSubServer) WaitForSession(ctx context.Context) (string, error) {
+	for {
+		s.mu.Lock()
+		connected := s.connected
+		if n := len(s.order); n != 0 {
+			id := s.order[n-1]
+			s.mu.Unlock()
+			return id, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-connected:
+		}
+	}
+}
+
+func (s *EventSubServer) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	c := &eventSubConn{conn: conn}
+
+	s.mu.Lock()
+	if id := r.URL.Query().Get("reconnect"); id != "" && s.sessions[id] != nil {
+		session := *s.sessions[id].session
+		session.Status = "connected"
+		session.ReconnectURL = ""
+		c.session = &session
+	} else {
+		keepalive := s.KeepaliveTimeoutSeconds
+		if v, err := strconv.Atoi(r.URL.Query().Get("keepalive_timeout_seconds")); err == nil {
+			keepalive = v
+		}
+
+		s.created++
+		c.session = &twitch.EventSubSession{
+			Id:                      fmt.Sprintf("session-%d", s.created),
+			Status:                  "connected",
+			KeepaliveTimeoutSeconds: keepalive,
+		}
+		s.order = append(s.order, c.session.Id)
+	}
+	c.session.ConnectedAt = twitch.Timestamp{Time: time.Now().UTC()}
+	s.mu.Unlock()
+
+	welcome := s.message(twitch.EventSubMessageSessionWelcome, nil, twitch.EventSubMessagePayload{Session: c.session})
+
+	// The new connection takes over the session right away, the old one stays
+	// open until the client closes it. Holding the write lock keeps
+	// the welcome first among the messages of the connection.
+	c.mu.Lock()
+	s.mu.Lock()
+	s.sessions[c.session.Id] = c
+	close(s.connected)
+	s.connected = make(chan struct{})
+	s.mu.Unlock()
+
+	err = conn.WriteJSON(welcome)
+	c.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	if s.sessions[c.session.Id] == c {
+		delete(s.sessions, c.session.Id)
+		for i, id := range s.order {
+			if id == c.session.Id {
+				s.order = append(s.order[:i], s.order[i+1:]...)
+				break
+			}
+		}
+	}
+	s.mu.Unlock()
+}
+
+func (s *EventSubServer) message(typ string, subscription *twitch.EventSubSubscription, payload twitch.EventSubMessagePayload) *twitch.EventSubMessage {
+	s.mu.Lock()
+	s.messages++
+	id := fmt.Sprintf("message-%d", s.messages)
+	s.mu.Unlock()
+
+	msg := &twitch.EventSubMessage{
+		Metadata: twitch.EventSubMessageMetadata{
+			MessageId:        id,
+			MessageType:      typ,
+			MessageTimestamp: twitch.Timestamp{Time: time.Now().UTC()},
+		},
+		Payload: payload,
+	}
+
+	if subscription != nil {
+		msg.Metadata.SubscriptionType = subscription.Type
+		msg.Metadata.SubscriptionVersion = subscription.Version
+	}
+
+	return msg
+}
+
+// connections returns connections of the session, or of every session if id is empty.
+func (s *EventSubServer) connections(id string) []*eventSubConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var conns []*eventSubConn
+	for _, session := range s.order {
+		if id == "" || id == session {
+			conns = append(conns, s.sessions[session])
+		}
+	}
+
+	return conns
+}
+
+func (s *EventSubServer) send(sessionId string, msg func(c *eventSubConn) *twitch.EventSubMessage) error {
+	conns := s.connections(sessionId)
+	if len(conns) == 0 {
+		return ErrNoSession
+	}
+
+	for _, c := range conns {
+		if err := c.write(msg(c)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Notify sends a notification with event to the session of the subscription transport,
+// or to every session if the subscription has no session id.
+func (s *EventSubServer) Notify(subscription *twitch.EventSubSubscription, event interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.send(subscription.Transport.SessionId, func(c *eventSubConn) *twitch.EventSubMessage {
+		return s.message(twitch.EventSubMessageNotification, subscription, twitch.EventSubMessagePayload{
+			Subscription: subscription,
+			Event:        data,
+		})
+	})
+}
+
+// Revoke sends a revocation of the subscription.
+func (s *EventSubServer) Revoke(subscription *twitch.EventSubSubscription) error {
+	return s.send(subscription.Transport.SessionId, func(c *eventSubConn) *twitch.EventSubMessage {
+		return s.message(twitch.EventSubMessageRevocation, subscription, twitch.EventSubMessagePayload{
+			Subscription: subscription,
+		})
+	})
+}
+
+// Keepalive sends a keepalive message to every session.
+func (s *EventSubServer) Keepalive() error {
+	return s.send("", func(c *eventSubConn) *twitch.EventSubMessage {
+		return s.message(twitch.EventSubMessageSessionKeepalive, nil, twitch.EventSubMessagePayload{})
+	})
+}
+
+// Reconnect asks every session to move to a new connection.
+func (s *EventSubServer) Reconnect() error {
+	return s.send("", func(c *eventSubConn) *twitch.EventSubMessage {
+		session := *c.session
+		session.Status = "reconnecting"
+		session.ReconnectURL = s.WebSocketURL + "?reconnect=" + session.Id
+
+		return s.message(twitch.EventSubMessageSessionReconnect, nil, twitch.EventSubMessagePayload{Session: &session})
+	})
+}
+
+// VerifyWebhook sends a callback verification challenge for the subscription
+// and checks that the callback answers with the challenge.
+func (s *EventSubServer) VerifyWebhook(ctx context.Context, subscription *twitch.EventSubSubscription) error {
+	challenge := fmt.Sprintf("challenge-%d", time.Now().UnixNano())
+	body, err := json.Marshal(map[string]interface{}{
+		"challenge":    challenge,
+		"subscription": subscription,
+	})
+	if err != nil {
+		return err
+	}
+
+	got, err := s.postWebhook(ctx, twitch.EventSubMessageWebhookCallbackVerification, subscription, body)
+	if err != nil {
+		return err
+	}
+
+	if got != challenge {
+		return fmt.Errorf("twitchtest: callback answered %q instead of the challenge", got)
+	}
+
+	return nil
+}
+
+// NotifyWebhook signs and delivers a notification with event
+// to the callback of the subscription transport.
+func (s *EventSubServer) NotifyWebhook(ctx context.Context, subscription *twitch.EventSubSubscription, event interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"subscription": subscription,
+		"event":        event,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.postWebhook(ctx, twitch.EventSubMessageNotification, subscription, body)
+	return err
+}
+
+// RevokeWebhook delivers a revocation of the subscription to its callback.
+func (s *EventSubServer) RevokeWebhook(ctx context.Context, subscription *twitch.EventSubSubscription) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"subscription": subscription,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.postWebhook(ctx, twitch.EventSubMessageRevocation, subscription, body)
+	return err
+}
+
+func (s *EventSubServer) postWebhook(ctx context.Context, typ string, subscription *twitch.EventSubSubscription, body []byte) (string, error) {
+	msg := s.message(typ, subscription, twitch.EventSubMessagePayload{})
+	id := msg.Metadata.MessageId
+	timestamp := msg.Metadata.MessageTimestamp.Format(time.RFC3339)
+
+	req, err := http.NewRequest(http.MethodPost, subscription.Transport.Callback, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Twitch-Eventsub-Message-Id", id)
+	req.Header.Set("Twitch-Eventsub-Message-Type", typ)
+	req.Header.Set("Twitch-Eventsub-Message-Timestamp", timestamp)
+	req.Header.Set("Twitch-Eventsub-Message-Signature", Signature(subscription.Transport.Secret, id, timestamp, body))
+	req.Header.Set("Twitch-Eventsub-Subscription-Type", subscription.Type)
+	req.Header.Set("Twitch-Eventsub-Subscription-Version", subscription.Version)
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("twitchtest: callback responded with status %d", resp.StatusCode)
+	}
+
+	return string(data), nil
+}
+
+// Signature returns the Twitch-Eventsub-Message-Signature header value for a webhook message.
+func Signature(secret, id, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
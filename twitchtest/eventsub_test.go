@@ -0,0 +1,190 @@
+package twitchtest
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/holypower777/go-twitch"
+)
+
+var creds = &twitch.Credentials{
+	ClientId:     "ClientId",
+	ClientSecret: "ClientSecret",
+}
+
+func TestEventSubServerWebSocket(t *testing.T) {
+	t.Run("must deliver notifications across reconnect", func(t *testing.T) {
+		s := NewEventSubServer()
+		defer s.Close()
+
+		c, _ := twitch.NewClient(creds, nil)
+		ws := twitch.NewEventSubWebSocket(c)
+		ws.URL = s.WebSocketURL
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var got []int
+		ws.OnNotification = func(n *twitch.EventSubNotification) {
+			event := new(twitch.ChannelRaidEvent)
+			if err := n.Decode(event); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			got = append(got, event.Viewers)
+			if len(got) == 2 {
+				cancel()
+			}
+		}
+
+		revoked := make(chan *twitch.EventSubSubscription, 1)
+		ws.OnRevocation = func(sub *twitch.EventSubSubscription) {
+			revoked <- sub
+		}
+
+		errs := make(chan error, 1)
+		go func() { errs <- ws.Connect(ctx) }()
+
+		session, err := s.WaitForSession(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sub := &twitch.EventSubSubscription{
+			Id:        "1",
+			Type:      twitch.EventSubChannelRaid,
+			Version:   "1",
+			Transport: twitch.EventSubTransport{Method: twitch.EventSubTransportWebSocket, SessionId: session},
+		}
+
+		if err := s.Keepalive(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.Revoke(sub); err != nil {
+			t.Fatal(err)
+		}
+		if got := <-revoked; got.Id != "1" {
+			t.Errorf("bad revoked subscription: %v", got)
+		}
+
+		if err := s.Notify(sub, &twitch.ChannelRaidEvent{Viewers: 1}); err != nil {
+			t.Fatal(err)
+		}
+
+		old := s.connections(session)[0]
+		if err := s.Reconnect(); err != nil {
+			t.Fatal(err)
+		}
+
+		for s.connections(session)[0] == old {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if sessions := s.Sessions(); fmt.Sprint(sessions) != "["+session+"]" {
+			t.Errorf("session must be kept across reconnect, got: %v", sessions)
+		}
+
+		if err := s.Notify(sub, &twitch.ChannelRaidEvent{Viewers: 2}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := <-errs; err != context.Canceled {
+			t.Fatalf("expected context cancellation, got: %v", err)
+		}
+
+		if fmt.Sprint(got) != "[1 2]" {
+			t.Errorf("\ngot: %v\nwant: [1 2]", got)
+		}
+	})
+
+	t.Run("must not reuse ids of closed sessions", func(t *testing.T) {
+		s := NewEventSubServer()
+		defer s.Close()
+
+		dial := func() *websocket.Conn {
+			conn, _, err := websocket.DefaultDialer.Dial(s.WebSocketURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The session is registered before the welcome is sent.
+			if _, _, err := conn.ReadMessage(); err != nil {
+				t.Fatal(err)
+			}
+			return conn
+		}
+
+		first := dial()
+		second := dial()
+		defer second.Close()
+
+		first.Close()
+		for len(s.Sessions()) != 1 {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		third := dial()
+		defer third.Close()
+
+		if sessions := s.Sessions(); fmt.Sprint(sessions) != "[session-2 session-3]" {
+			t.Errorf("bad sessions: %v", sessions)
+		}
+	})
+
+	t.Run("must return error without sessions", func(t *testing.T) {
+		s := NewEventSubServer()
+		defer s.Close()
+
+		if err := s.Keepalive(); err != ErrNoSession {
+			t.Errorf("expected ErrNoSession, got: %v", err)
+		}
+	})
+}
+
+func TestEventSubServerWebhook(t *testing.T) {
+	const secret = "s3cre7-s3cre7-s3cre7"
+
+	s := NewEventSubServer()
+	defer s.Close()
+
+	h := twitch.NewEventSubWebhook(secret)
+
+	var got []int
+	h.OnNotification = func(n *twitch.EventSubNotification) {
+		event := new(twitch.ChannelRaidEvent)
+		n.Decode(event)
+		got = append(got, event.Viewers)
+	}
+
+	callback := httptest.NewServer(h)
+	defer callback.Close()
+
+	sub := &twitch.EventSubSubscription{
+		Id:        "1",
+		Type:      twitch.EventSubChannelRaid,
+		Version:   "1",
+		Transport: twitch.EventSubTransport{Method: twitch.EventSubTransportWebhook, Callback: callback.URL, Secret: secret},
+	}
+
+	ctx := context.Background()
+	if err := s.VerifyWebhook(ctx, sub); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := s.NotifyWebhook(ctx, sub, &twitch.ChannelRaidEvent{Viewers: 9001}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(got) != "[9001]" {
+		t.Errorf("\ngot: %v\nwant: [9001]", got)
+	}
+
+	sub.Transport.Secret = "wrong"
+	if err := s.NotifyWebhook(ctx, sub, &twitch.ChannelRaidEvent{}); err == nil {
+		t.Error("expected error for a wrong signature")
+	}
+}
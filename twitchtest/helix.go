@@ -0,0 +1,193 @@
+package twitchtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	helixPath        = "/helix/"
+	defaultPageSize  = 20
+	defaultRateLimit = 800
+	rateLimitWindow  = time.Minute
+)
+
+// HelixServer is a fake api.twitch.tv/helix server answering endpoints with
+// canned data, paginated like Helix, and sending Ratelimit headers.
+//
+// Set Client.BaseURL to URL. Canned data is set with SetData and returned
+// regardless of the filters of the request, Handle serves anything else.
+type HelixServer struct {
+	URL string
+
+	// PageSize is the number of items per page when the request has no first.
+	PageSize int
+	// RateLimit is the number of requests in a minute,
+	// further requests are answered with 429.
+	RateLimit int
+	// Auth, if set, makes the server reject requests without
+	// a valid token issued by it.
+	Auth *AuthServer
+
+	server *httptest.Server
+	mux    *http.ServeMux
+
+	mu        sync.Mutex
+	data      map[string][]interface{}
+	handled   map[string]bool
+	remaining int
+	reset     time.Time
+}
+
+func NewHelixServer() *HelixServer {
+	s := &HelixServer{
+		PageSize:  defaultPageSize,
+		RateLimit: defaultRateLimit,
+		mux:       http.NewServeMux(),
+		data:      make(map[string][]interface{}),
+		handled:   make(map[string]bool),
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.URL = s.server.URL + helixPath
+
+	return s
+}
+
+func (s *HelixServer) Close() {
+	s.server.Close()
+}
+
+// SetData makes GET requests to the endpoint, e.g. "streams",
+// return items as data, page by page.
+func (s *HelixServer) SetData(endpoint string, items ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[strings.Trim(endpoint, "/")] = items
+}
+
+// Handle serves requests to the endpoint, e.g. "streams/key", with handler.
+// Handlers take precedence over canned data.
+func (s *HelixServer) Handle(endpoint string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint = strings.Trim(endpoint, "/")
+	s.mux.HandleFunc(helixPath+endpoint, handler)
+	s.handled[endpoint] = true
+}
+
+func (s *HelixServer) serve(w http.ResponseWriter, r *http.Request) {
+	if !s.takeRate(w) {
+		writeHelixError(w, http.StatusTooManyRequests, "Too Many Requests")
+		return
+	}
+
+	if s.Auth != nil && !s.authorized(r) {
+		writeHelixError(w, http.StatusUnauthorized, "Invalid OAuth token")
+		return
+	}
+
+	endpoint := strings.Trim(strings.TrimPrefix(r.URL.Path, helixPath), "/")
+
+	s.mu.Lock()
+	handled := s.handled[endpoint]
+	items, ok := s.data[endpoint]
+	s.mu.Unlock()
+
+	switch {
+	case handled:
+		s.mux.ServeHTTP(w, r)
+	case !ok:
+		writeHelixError(w, http.StatusNotFound, "Not Found")
+	case r.Method != http.MethodGet:
+		writeHelixError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	default:
+		s.servePage(w, r, items)
+	}
+}
+
+// servePage writes the page of items after the cursor of the request,
+// cursors are offsets of the next page.
+func (s *HelixServer) servePage(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	first := s.PageSize
+	if v := r.URL.Query().Get("first"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			writeHelixError(w, http.StatusBadRequest, "Invalid first parameter")
+			return
+		}
+		first = n
+	}
+
+	offset := 0
+	if v := r.URL.Query().Get("after"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > len(items) {
+			writeHelixError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		offset = n
+	}
+
+	end := offset + first
+	if end > len(items) {
+		end = len(items)
+	}
+
+	pagination := map[string]interface{}{}
+	if end < len(items) {
+		pagination["cursor"] = strconv.Itoa(end)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       items[offset:end],
+		"pagination": pagination,
+	})
+}
+
+// takeRate takes a point of the rate limit bucket and sets the Ratelimit
+// headers, it reports false if the bucket is empty.
+func (s *HelixServer) takeRate(w http.ResponseWriter) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now := time.Now(); !now.Before(s.reset) {
+		s.remaining = s.RateLimit
+		s.reset = now.Add(rateLimitWindow)
+	}
+
+	ok := s.remaining > 0
+	if ok {
+		s.remaining--
+	}
+
+	w.Header().Set("Ratelimit-Limit", strconv.Itoa(s.RateLimit))
+	w.Header().Set("Ratelimit-Remaining", strconv.Itoa(s.remaining))
+	w.Header().Set("Ratelimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+
+	return ok
+}
+
+func (s *HelixServer) authorized(r *http.Request) bool {
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.Auth.mu.Lock()
+	defer s.Auth.mu.Unlock()
+
+	t := s.Auth.valid(accessToken)
+	return t != nil && t.clientId == r.Header.Get("Client-Id")
+}
+
+func writeHelixError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":   http.StatusText(status),
+		"status":  status,
+		"message": message,
+	})
+}
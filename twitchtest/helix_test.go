@@ -0,0 +1,98 @@
+package twitchtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/holypower777/go-twitch"
+)
+
+func TestHelixServer(t *testing.T) {
+	newClient := func(t *testing.T, s *HelixServer) *twitch.Client {
+		c, err := twitch.NewClientWithToken("ClientId", "token", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.BaseURL, _ = url.Parse(s.URL)
+		c.RetryPolicy = nil
+
+		return c
+	}
+
+	t.Run("must paginate canned data", func(t *testing.T) {
+		s := NewHelixServer()
+		defer s.Close()
+
+		var streams []interface{}
+		for i := 0; i < 45; i++ {
+			streams = append(streams, &twitch.Stream{Id: fmt.Sprint(i)})
+		}
+		s.SetData("streams", streams...)
+
+		c := newClient(t, s)
+		all, _, err := c.Streams.GetAllStreams(context.Background(), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != 45 || all[44].Id != "44" {
+			t.Errorf("expected 45 streams, got: %d", len(all))
+		}
+
+		if rate := c.RateLimiter.Rate(); rate.Limit != 800 || rate.Remaining != 797 {
+			t.Errorf("bad rate: %+v", rate)
+		}
+	})
+
+	t.Run("must answer with 429 when the bucket is empty", func(t *testing.T) {
+		s := NewHelixServer()
+		defer s.Close()
+
+		s.RateLimit = 1
+		s.SetData("streams")
+
+		c := newClient(t, s)
+		c.RateLimiter = nil
+
+		_, _, err := c.Streams.GetStreams(context.Background(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = c.Streams.GetStreams(context.Background(), nil)
+		if !errors.Is(err, twitch.ErrRateLimited) {
+			t.Errorf("expected twitch.ErrRateLimited, got: %v", err)
+		}
+	})
+
+	t.Run("must serve handlers and reject invalid tokens", func(t *testing.T) {
+		auth := NewAuthServer("ClientId", "ClientSecret")
+		defer auth.Close()
+
+		s := NewHelixServer()
+		defer s.Close()
+
+		s.Auth = auth
+		s.Handle("streams/key", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":[{"stream_key":"live_key"}]}`)
+		})
+
+		c := newClient(t, s)
+		_, _, err := c.Streams.GetStreamKey(context.Background(), &twitch.BroadcasterID{Id: "141981764"})
+		if !errors.Is(err, twitch.ErrUnauthorized) {
+			t.Errorf("expected twitch.ErrUnauthorized, got: %v", err)
+		}
+
+		token := auth.IssueToken("141981764", "twitchdev", "channel:read:stream_key")
+		key, _, err := c.With(twitch.WithAccessToken(token.AccessToken)).Streams.GetStreamKey(context.Background(), &twitch.BroadcasterID{Id: "141981764"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != "live_key" {
+			t.Errorf("bad key: %s", key)
+		}
+	})
+}
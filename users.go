@@ -1,55 +1,84 @@
-package bot
+package twitch
 
 import (
 	"context"
 	"net/http"
+	"strings"
 )
 
+const getUsersPath = "users"
+
+// BroadcasterType is the type of the broadcaster of a user.
+type BroadcasterType string
+
+const (
+	BroadcasterTypeNormal    BroadcasterType = ""
+	BroadcasterTypeAffiliate BroadcasterType = "affiliate"
+	BroadcasterTypePartner   BroadcasterType = "partner"
+)
+
+func (t BroadcasterType) Valid() bool {
+	switch t {
+	case BroadcasterTypeNormal, BroadcasterTypeAffiliate, BroadcasterTypePartner:
+		return true
+	default:
+		return false
+	}
+}
+
+// UserType is the type of a user, staff members have other types than normal users.
+type UserType string
+
 const (
-	getUsersPath       = "users"
-	users100LimitError = "The limit of 100 IDs and login names is the total limit. You can request, for example, 50 of each or 100 of one of them. You cannot request 100 of both."
+	UserTypeNormal    UserType = ""
+	UserTypeAdmin     UserType = "admin"
+	UserTypeGlobalMod UserType = "global_mod"
+	UserTypeStaff     UserType = "staff"
 )
 
+func (t UserType) Valid() bool {
+	switch t {
+	case UserTypeNormal, UserTypeAdmin, UserTypeGlobalMod, UserTypeStaff:
+		return true
+	default:
+		return false
+	}
+}
+
 type UsersService service
 
+// UsersAPI is implemented by UsersService, so calls can be mocked in tests.
+type UsersAPI interface {
+	usersGeneratedAPI
+
+	GetUsers(ctx context.Context, opts *UsersOptions) ([]*User, *Response, error)
+	GetUsersAll(ctx context.Context, opts *UsersOptions, concurrency int) ([]*User, *Response, error)
+}
+
+var _ UsersAPI = (*UsersService)(nil)
+
 type UsersOptions struct {
-	Ids    []string `url:"id,omitempty"`
-	Logins []string `url:"id,omitempty"`
+	Ids    []string `url:"id,omitempty" group:"users" validate:"required" max:"100"`
+	Logins []string `url:"login,omitempty" group:"users" validate:"required"`
 }
 
 type User struct {
-	BroadcasterType string    `json:"broadcaster_type,omitempty"`
-	Description     string    `json:"description,omitempty"`
-	DisplayName     string    `json:"display_name,omitempty"`
-	Id              string    `json:"id,omitempty"`
-	Login           string    `json:"login,omitempty"`
-	OfflineImageURL string    `json:"offline_image_url,omitempty"`
-	ProfileImageURL string    `json:"profile_image_url,omitempty"`
-	Type            string    `json:"type,omitempty"`
-	ViewCount       int       `json:"view_count,omitempty"`
-	Email           string    `json:"email,omitempty"`
-	CreatedAt       Timestamp `json:"created_at,omitempty"`
+	BroadcasterType BroadcasterType `json:"broadcaster_type,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	DisplayName     string          `json:"display_name,omitempty"`
+	Id              string          `json:"id,omitempty"`
+	Login           string          `json:"login,omitempty"`
+	OfflineImageURL string          `json:"offline_image_url,omitempty"`
+	ProfileImageURL string          `json:"profile_image_url,omitempty"`
+	Type            UserType        `json:"type,omitempty"`
+	ViewCount       int             `json:"view_count,omitempty"`
+	Email           string          `json:"email,omitempty"`
+	CreatedAt       Timestamp       `json:"created_at,omitempty,omitzero"`
 }
 
-type UsersResponse struct {
-	Data []*User `json:"data,omitempty"`
-}
+type UsersResponse = DataResponse[*User]
 
 func (s *UsersService) GetUsers(ctx context.Context, opts *UsersOptions) ([]*User, *Response, error) {
-	if opts == nil || opts.Ids == nil && opts.Logins == nil {
-		return nil, nil, &ErrorInvalidOptions{
-			Options: opts,
-			Message: userIdLoginIsRequired,
-		}
-	}
-
-	if len(opts.Ids)+len(opts.Logins) > 100 {
-		return nil, nil, &ErrorInvalidOptions{
-			Options: opts,
-			Message: users100LimitError,
-		}
-	}
-
 	u, err := addParams(getUsersPath, opts)
 	if err != nil {
 		return nil, nil, err
@@ -68,3 +97,48 @@ func (s *UsersService) GetUsers(ctx context.Context, opts *UsersOptions) ([]*Use
 
 	return usersResp.Data, resp, nil
 }
+
+// GetUsersAll returns the users of any number of ids and logins. They are
+// requested in batches of 100, at most concurrency at once, which defaults
+// to 4 if it is 0. Users are returned in the order of Ids and then Logins,
+// each once, users that are not found are left out.
+func (s *UsersService) GetUsersAll(ctx context.Context, opts *UsersOptions, concurrency int) ([]*User, *Response, error) {
+	if opts == nil || len(opts.Ids)+len(opts.Logins) == 0 {
+		return nil, nil, &ErrorInvalidOptions{Options: opts, Message: "id or login is required"}
+	}
+
+	batches, resp, err := runBatches(ctx, batchCount(len(opts.Ids)+len(opts.Logins)), concurrency, func(ctx context.Context, i int) ([]*User, *Response, error) {
+		ids, logins := splitBatch(opts.Ids, opts.Logins, i)
+		return s.GetUsers(ctx, &UsersOptions{Ids: ids, Logins: logins})
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	byId := make(map[string]*User)
+	byLogin := make(map[string]*User)
+	for _, batch := range batches {
+		for _, user := range batch {
+			byId[user.Id] = user
+			byLogin[strings.ToLower(user.Login)] = user
+		}
+	}
+
+	users := make([]*User, 0, len(byId))
+	seen := make(map[string]bool, len(byId))
+	add := func(user *User) {
+		if user != nil && !seen[user.Id] {
+			seen[user.Id] = true
+			users = append(users, user)
+		}
+	}
+
+	for _, id := range opts.Ids {
+		add(byId[id])
+	}
+	for _, login := range opts.Logins {
+		add(byLogin[strings.ToLower(login)])
+	}
+
+	return users, resp, nil
+}
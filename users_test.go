@@ -1,10 +1,14 @@
-package bot
+package twitch
 
 import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -33,12 +37,31 @@ func TestGetUsers(t *testing.T) {
 		}
 	})
 
+	t.Run("must send ids and logins as repeated parameters", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+getUsersPath, func(w http.ResponseWriter, r *http.Request) {
+			want := url.Values{"id": {"12", "13"}, "login": {"aboba"}}
+			if got := r.URL.Query(); !reflect.DeepEqual(got, want) {
+				t.Errorf("\ngot: %v\nwant: %v", got, want)
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		_, _, err := c.Users.GetUsers(context.Background(), &UsersOptions{
+			Ids:    []string{"12", "13"},
+			Logins: []string{"aboba"},
+		})
+		assertNoError(t, err)
+	})
+
 	t.Run("empty parameters returns error", func(t *testing.T) {
 		client, _ := NewClient(creds, nil)
 		ctx := context.Background()
 		_, _, err := client.Users.GetUsers(ctx, nil)
 		assertErrorPresence(t, err)
-		assertErrorMessage(t, err, userIdLoginIsRequired)
+		assertErrorMessage(t, err, "id or login is required")
 
 		_, _, err = client.Users.GetUsers(ctx, &UsersOptions{})
 		assertErrorPresence(t, err)
@@ -55,7 +78,7 @@ func TestGetUsers(t *testing.T) {
 			Ids: ids[:],
 		})
 		assertErrorPresence(t, err)
-		assertErrorMessage(t, err, users100LimitError)
+		assertErrorMessage(t, err, "id and login must be at most 100 in total")
 
 		logins := [71]string{}
 
@@ -64,6 +87,129 @@ func TestGetUsers(t *testing.T) {
 			Logins: logins[:],
 		})
 		assertErrorPresence(t, err)
-		assertErrorMessage(t, err, users100LimitError)
+		assertErrorMessage(t, err, "id and login must be at most 100 in total")
+	})
+}
+
+func TestGetUserBlockList(t *testing.T) {
+	t.Run("tests parameters and body to be valid", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+getUserBlockListPath, func(w http.ResponseWriter, r *http.Request) {
+			assertMethod(t, r, http.MethodGet)
+			assertQuery(t, r, params{"broadcaster_id": "141981764", "first": "2"})
+			fmt.Fprint(w, `{"data":[{"user_id":"12","user_login":"aboba","display_name":"Aboba"}],"pagination":{"cursor":"next"}}`)
+		})
+
+		blocked, _, err := c.Users.GetUserBlockList(context.Background(), &UserBlockListOptions{BroadcasterId: "141981764", First: 2})
+		assertNoError(t, err)
+
+		want := &BlockedUsersResponse{
+			Data:       []*BlockedUser{{UserId: "12", UserLogin: "aboba", DisplayName: "Aboba"}},
+			Pagination: Pagination{Cursor: "next"},
+		}
+		if !reflect.DeepEqual(blocked, want) {
+			t.Errorf("\ngot: %v\nwant: %v", blocked, want)
+		}
+	})
+
+	t.Run("must default to the broadcaster of the client", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+getUserBlockListPath, func(w http.ResponseWriter, r *http.Request) {
+			assertQuery(t, r, params{"broadcaster_id": "141981764"})
+			fmt.Fprint(w, `{"data":[]}`)
+		})
+
+		opts := &UserBlockListOptions{}
+		_, _, err := c.With(WithBroadcasterId("141981764")).Users.GetUserBlockList(context.Background(), opts)
+		assertNoError(t, err)
+
+		if opts.BroadcasterId != "" {
+			t.Errorf("options must not be changed, got: %+v", opts)
+		}
+	})
+
+	t.Run("empty parameters returns error", func(t *testing.T) {
+		client, _ := NewClient(creds, nil)
+		_, _, err := client.Users.GetUserBlockList(context.Background(), nil)
+		assertErrorMessage(t, err, "broadcaster_id is required")
+	})
+}
+
+func TestGetUsersAll(t *testing.T) {
+	t.Run("must request batches and return users in input order", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		var mu sync.Mutex
+		requests := 0
+		mux.HandleFunc("/"+getUsersPath, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests++
+			mu.Unlock()
+
+			q := r.URL.Query()
+			if n := len(q["id"]) + len(q["login"]); n > 100 {
+				t.Errorf("batch of %d ids and logins", n)
+			}
+
+			// Helix returns users in any order and leaves out unknown ones.
+			var users []string
+			for _, id := range q["id"] {
+				if id != "unknown" {
+					users = append([]string{fmt.Sprintf(`{"id":"%s","login":"user%s"}`, id, id)}, users...)
+				}
+			}
+			for _, login := range q["login"] {
+				users = append(users, fmt.Sprintf(`{"id":"%s","login":"%s"}`, strings.TrimPrefix(login, "user"), login))
+			}
+			fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(users, ","))
+		})
+
+		var ids []string
+		for i := 0; i < 250; i++ {
+			ids = append(ids, strconv.Itoa(i))
+		}
+		ids = append(ids, "unknown")
+
+		users, _, err := c.Users.GetUsersAll(context.Background(), &UsersOptions{
+			Ids:    ids,
+			Logins: []string{"user0", "user300"},
+		}, 2)
+		assertNoError(t, err)
+
+		if requests != 3 {
+			t.Errorf("got %d requests, want 3", requests)
+		}
+
+		if len(users) != 251 {
+			t.Fatalf("got %d users, want 251", len(users))
+		}
+		for i, user := range users[:250] {
+			if user.Id != ids[i] {
+				t.Fatalf("user %d is %s, want %s", i, user.Id, ids[i])
+			}
+		}
+		if users[250].Login != "user300" {
+			t.Errorf("last user is %s, want user300", users[250].Login)
+		}
+	})
+
+	t.Run("must return error", func(t *testing.T) {
+		c, mux, _, teardown := setup()
+		defer teardown()
+
+		mux.HandleFunc("/"+getUsersPath, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		_, _, err := c.Users.GetUsersAll(context.Background(), &UsersOptions{Ids: make([]string, 150)}, 0)
+		assertErrorPresence(t, err)
+
+		_, _, err = c.Users.GetUsersAll(context.Background(), nil, 0)
+		assertErrorMessage(t, err, "id or login is required")
 	})
 }
@@ -0,0 +1,240 @@
+package twitch
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// enum is implemented by the typed constants of fields, e.g. StreamType.
+type enum interface {
+	Valid() bool
+}
+
+var enumType = reflect.TypeOf((*enum)(nil)).Elem()
+
+// optionsRules are the rules of the struct tags of an options type,
+// they are parsed once per type.
+type optionsRules struct {
+	fields    []fieldRule
+	groups    []*groupRule
+	exclusive []*groupRule
+}
+
+type fieldRule struct {
+	index    int
+	name     string
+	required bool
+	max      int
+	enum     bool
+	nested   bool
+}
+
+type groupRule struct {
+	fields   []int
+	names    []string
+	required bool
+	max      int
+}
+
+var rulesCache sync.Map // reflect.Type -> *optionsRules
+
+// validateOptions returns ErrorInvalidOptions for the first violated rule
+// of the struct tags of opts. Options are validated before they are encoded
+// by addParams or NewRequest:
+//
+//	validate:"required"  the field must be set
+//	max:"100"            the length of the slice or string, or the number, is at most 100
+//	group:"users"        the fields of the group are validated together: one of them
+//	                     must be set if they are required, max limits their total length
+//	exclusive:"cursor"   at most one field of the same name may be set
+//
+// Fields of typed constants, e.g. StreamType, must be valid if they are set.
+//
+// Fields are named by their url or json keys, nested structs are validated
+// with the name of their field as prefix, e.g. transport.method.
+// Requirements depending on the endpoint are still checked by the endpoint.
+func validateOptions(opts interface{}) error {
+	if opts == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(opts)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+		} else {
+			v = v.Elem()
+		}
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if msg := validateStruct(v, ""); msg != "" {
+		return &ErrorInvalidOptions{Options: opts, Message: msg}
+	}
+
+	return nil
+}
+
+func validateStruct(v reflect.Value, prefix string) string {
+	rules := rulesOf(v.Type())
+
+	for _, r := range rules.fields {
+		fv := v.Field(r.index)
+		set := !fv.IsZero()
+
+		if r.enum && set && !fv.Interface().(enum).Valid() {
+			return fmt.Sprintf("%s%s %q is invalid", prefix, r.name, fv.String())
+		}
+
+		if r.required && !set {
+			return prefix + r.name + " is required"
+		}
+
+		if r.max >= 0 && size(fv) > r.max {
+			return fmt.Sprintf("%s%s must be at most %d", prefix, r.name, r.max)
+		}
+
+		if r.nested {
+			if msg := validateStruct(fv, prefix+r.name+"."); msg != "" {
+				return msg
+			}
+		}
+	}
+
+	for _, g := range rules.groups {
+		set, total := false, 0
+		for _, i := range g.fields {
+			fv := v.Field(i)
+			set = set || !fv.IsZero()
+			total += size(fv)
+		}
+
+		if g.required && !set {
+			return prefix + strings.Join(g.names, " or "+prefix) + " is required"
+		}
+
+		if g.max >= 0 && total > g.max {
+			return fmt.Sprintf("%s must be at most %d in total", prefix+strings.Join(g.names, " and "+prefix), g.max)
+		}
+	}
+
+	for _, g := range rules.exclusive {
+		var names []string
+		for j, i := range g.fields {
+			if !v.Field(i).IsZero() {
+				names = append(names, prefix+g.names[j])
+			}
+		}
+
+		if len(names) > 1 {
+			return "only one of " + strings.Join(names, " or ") + " may be set"
+		}
+	}
+
+	return ""
+}
+
+// rulesOf returns the rules of the struct type t.
+func rulesOf(t reflect.Type) *optionsRules {
+	if rules, ok := rulesCache.Load(t); ok {
+		return rules.(*optionsRules)
+	}
+
+	rules := new(optionsRules)
+	groups := make(map[string]*groupRule)
+	exclusive := make(map[string]*groupRule)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := fieldName(f)
+		required := f.Tag.Get("validate") == "required"
+
+		max := -1
+		if tag := f.Tag.Get("max"); tag != "" {
+			n, err := strconv.Atoi(tag)
+			if err != nil {
+				panic(fmt.Sprintf("twitch: invalid max tag of %s.%s: %q", t.Name(), f.Name, tag))
+			}
+			max = n
+		}
+
+		if tag := f.Tag.Get("exclusive"); tag != "" {
+			g := exclusive[tag]
+			if g == nil {
+				g = new(groupRule)
+				exclusive[tag] = g
+				rules.exclusive = append(rules.exclusive, g)
+			}
+			g.fields = append(g.fields, i)
+			g.names = append(g.names, name)
+		}
+
+		r := fieldRule{
+			index:  i,
+			name:   name,
+			max:    -1,
+			enum:   f.Type.Implements(enumType),
+			nested: f.Type.Kind() == reflect.Struct && f.Type.PkgPath() == t.PkgPath(),
+		}
+
+		if tag := f.Tag.Get("group"); tag != "" {
+			g := groups[tag]
+			if g == nil {
+				g = &groupRule{max: -1}
+				groups[tag] = g
+				rules.groups = append(rules.groups, g)
+			}
+			g.fields = append(g.fields, i)
+			g.names = append(g.names, name)
+			g.required = g.required || required
+			if max >= 0 {
+				g.max = max
+			}
+		} else {
+			r.required, r.max = required, max
+		}
+
+		if r.enum || r.required || r.max >= 0 || r.nested {
+			rules.fields = append(rules.fields, r)
+		}
+	}
+
+	actual, _ := rulesCache.LoadOrStore(t, rules)
+	return actual.(*optionsRules)
+}
+
+// fieldName returns the url or json key of the field.
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"url", "json"} {
+		if name := strings.Split(f.Tag.Get(key), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return f.Name
+}
+
+// size returns the length of slices, maps and strings,
+// and the value of numbers.
+func size(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return v.Len()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint())
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,78 @@
+package twitch
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateOptions(t *testing.T) {
+	type nested struct {
+		Method string `json:"method,omitempty" validate:"required"`
+	}
+
+	type options struct {
+		Id        string   `url:"id,omitempty" validate:"required"`
+		First     int      `url:"first,omitempty" max:"100"`
+		Ids       []string `url:"ids,omitempty" group:"users" validate:"required" max:"3"`
+		Logins    []string `url:"logins,omitempty" group:"users"`
+		After     string   `url:"after,omitempty" exclusive:"cursor"`
+		Before    string   `url:"before,omitempty" exclusive:"cursor"`
+		Transport nested   `json:"transport,omitempty"`
+	}
+
+	valid := func() *options {
+		return &options{Id: "1", Ids: []string{"1"}, Transport: nested{Method: "websocket"}}
+	}
+
+	t.Run("must accept valid options", func(t *testing.T) {
+		assertNoError(t, validateOptions(valid()))
+		assertNoError(t, validateOptions(nil))
+		assertNoError(t, validateOptions(&struct{ Id string }{}))
+	})
+
+	t.Run("must return error of the violated rule", func(t *testing.T) {
+		for msg, modify := range map[string]func(o *options){
+			"id is required":            func(o *options) { o.Id = "" },
+			"first must be at most 100": func(o *options) { o.First = 101 },
+			"ids or logins is required": func(o *options) { o.Ids = nil },
+			"ids and logins must be at most 3 in total": func(o *options) {
+				o.Logins = []string{"a", "b", "c"}
+			},
+			"only one of after or before may be set": func(o *options) { o.After, o.Before = "a", "b" },
+			"transport.method is required":           func(o *options) { o.Transport.Method = "" },
+		} {
+			opts := valid()
+			modify(opts)
+
+			err := validateOptions(opts)
+			assertErrorPresence(t, err)
+			assertErrorMessage(t, err, msg)
+
+			if e, ok := err.(*ErrorInvalidOptions); !ok || e.Options != opts {
+				t.Errorf("unexpected error: %#v", err)
+			}
+		}
+	})
+
+	t.Run("must treat nil options as empty", func(t *testing.T) {
+		var opts *options
+		assertErrorMessage(t, validateOptions(opts), "id is required")
+	})
+
+	t.Run("must validate options of requests", func(t *testing.T) {
+		c, _ := NewClient(creds, nil)
+		ctx := context.Background()
+
+		_, _, err := c.Streams.GetStreams(ctx, &StreamsOptions{First: 101})
+		assertErrorMessage(t, err, "first must be at most 100")
+
+		_, _, err = c.Streams.GetStreams(ctx, &StreamsOptions{After: "a", Before: "b"})
+		assertErrorMessage(t, err, "only one of after or before may be set")
+
+		_, _, err = c.Streams.CreateStreamMarker(ctx, &StreamMarkerOptions{})
+		assertErrorMessage(t, err, "user_id is required")
+
+		_, _, err = c.Streams.GetStreams(ctx, &StreamsOptions{Type: "offline"})
+		assertErrorMessage(t, err, `type "offline" is invalid`)
+	})
+}